@@ -28,8 +28,24 @@ type CreateOrderRequest struct {
 	UserId           int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	ConcertSessionId int32                  `protobuf:"varint,2,opt,name=concert_session_id,json=concertSessionId,proto3" json:"concert_session_id,omitempty"`
 	NumberOfTickets  int32                  `protobuf:"varint,3,opt,name=number_of_tickets,json=numberOfTickets,proto3" json:"number_of_tickets,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// idempotency_key makes retries of the same request return the original response.
+	// It may also be sent as the "idempotency-key" metadata header.
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// seat_ids buys these exact seats, identified by the ticket ids from GetSeatMap.
+	// number_of_tickets may then be omitted. If any seat is taken the request fails with
+	// FAILED_PRECONDITION and a PreconditionFailure violation per unavailable seat.
+	SeatIds []string `protobuf:"bytes,5,rep,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+	// Without seat_ids, reserved seating sessions allocate the best block of adjacent seats in one
+	// row. allow_split_seating accepts scattered seats when no such block is free; otherwise the
+	// request fails with FAILED_PRECONDITION (reason ADJACENT_SEATS_UNAVAILABLE).
+	AllowSplitSeating bool `protobuf:"varint,6,opt,name=allow_split_seating,json=allowSplitSeating,proto3" json:"allow_split_seating,omitempty"`
+	// ticket_types buys a mix of the session's ticket types, each at its own price.
+	// number_of_tickets may then be omitted. Sessions with ticket types require it.
+	TicketTypes []*TicketSelection `protobuf:"bytes,7,rep,name=ticket_types,json=ticketTypes,proto3" json:"ticket_types,omitempty"`
+	// promo_code discounts the order; it is matched case-insensitively
+	PromoCode     string `protobuf:"bytes,8,opt,name=promo_code,json=promoCode,proto3" json:"promo_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateOrderRequest) Reset() {
@@ -83,21 +99,123 @@ func (x *CreateOrderRequest) GetNumberOfTickets() int32 {
 	return 0
 }
 
-// CreateOrderResponse represents the response from creating an order
-type CreateOrderResponse struct {
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetSeatIds() []string {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetAllowSplitSeating() bool {
+	if x != nil {
+		return x.AllowSplitSeating
+	}
+	return false
+}
+
+func (x *CreateOrderRequest) GetTicketTypes() []*TicketSelection {
+	if x != nil {
+		return x.TicketTypes
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
+// TicketSelection is a quantity of tickets of one ticket type
+type TicketSelection struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	TicketIds     []string               `protobuf:"bytes,3,rep,name=ticket_ids,json=ticketIds,proto3" json:"ticket_ids,omitempty"`
-	TotalPrice    float64                `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TicketTypeId  int32                  `protobuf:"varint,1,opt,name=ticket_type_id,json=ticketTypeId,proto3" json:"ticket_type_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *TicketSelection) Reset() {
+	*x = TicketSelection{}
+	mi := &file_proto_tickets_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketSelection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketSelection) ProtoMessage() {}
+
+func (x *TicketSelection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketSelection.ProtoReflect.Descriptor instead.
+func (*TicketSelection) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TicketSelection) GetTicketTypeId() int32 {
+	if x != nil {
+		return x.TicketTypeId
+	}
+	return 0
+}
+
+func (x *TicketSelection) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// CreateOrderResponse represents the response from creating an order
+type CreateOrderResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	OrderId    int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status     string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TicketIds  []string               `protobuf:"bytes,3,rep,name=ticket_ids,json=ticketIds,proto3" json:"ticket_ids,omitempty"`
+	TotalPrice float64                `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// expires_at is when the pending order releases its tickets unless it is paid
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// subtotal is the sum of the ticket prices; total_price is subtotal less discount plus
+	// service_fee and tax
+	Subtotal float64 `protobuf:"fixed64,7,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount float64 `protobuf:"fixed64,8,opt,name=discount,proto3" json:"discount,omitempty"`
+	// promo_code is the code that was applied, if any
+	PromoCode  string  `protobuf:"bytes,9,opt,name=promo_code,json=promoCode,proto3" json:"promo_code,omitempty"`
+	ServiceFee float64 `protobuf:"fixed64,10,opt,name=service_fee,json=serviceFee,proto3" json:"service_fee,omitempty"`
+	// tax is tax_rate percent of the discounted subtotal and service fee, charged in tax_jurisdiction
+	Tax     float64 `protobuf:"fixed64,11,opt,name=tax,proto3" json:"tax,omitempty"`
+	TaxRate float64 `protobuf:"fixed64,12,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	// tax_jurisdiction is empty for untaxed orders
+	TaxJurisdiction string `protobuf:"bytes,13,opt,name=tax_jurisdiction,json=taxJurisdiction,proto3" json:"tax_jurisdiction,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *CreateOrderResponse) Reset() {
 	*x = CreateOrderResponse{}
-	mi := &file_proto_tickets_proto_msgTypes[1]
+	mi := &file_proto_tickets_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -109,7 +227,7 @@ func (x *CreateOrderResponse) String() string {
 func (*CreateOrderResponse) ProtoMessage() {}
 
 func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[1]
+	mi := &file_proto_tickets_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -122,7 +240,7 @@ func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
 func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{1}
+	return file_proto_tickets_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateOrderResponse) GetOrderId() int32 {
@@ -160,6 +278,62 @@ func (x *CreateOrderResponse) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *CreateOrderResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateOrderResponse) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *CreateOrderResponse) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *CreateOrderResponse) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
+func (x *CreateOrderResponse) GetServiceFee() float64 {
+	if x != nil {
+		return x.ServiceFee
+	}
+	return 0
+}
+
+func (x *CreateOrderResponse) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *CreateOrderResponse) GetTaxRate() float64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+func (x *CreateOrderResponse) GetTaxJurisdiction() string {
+	if x != nil {
+		return x.TaxJurisdiction
+	}
+	return ""
+}
+
 // GetOrderRequest represents a request to retrieve an order
 type GetOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -170,7 +344,7 @@ type GetOrderRequest struct {
 
 func (x *GetOrderRequest) Reset() {
 	*x = GetOrderRequest{}
-	mi := &file_proto_tickets_proto_msgTypes[2]
+	mi := &file_proto_tickets_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -182,7 +356,7 @@ func (x *GetOrderRequest) String() string {
 func (*GetOrderRequest) ProtoMessage() {}
 
 func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[2]
+	mi := &file_proto_tickets_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,7 +369,7 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{2}
+	return file_proto_tickets_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetOrderRequest) GetOrderId() int32 {
@@ -215,7 +389,7 @@ type GetOrderResponse struct {
 
 func (x *GetOrderResponse) Reset() {
 	*x = GetOrderResponse{}
-	mi := &file_proto_tickets_proto_msgTypes[3]
+	mi := &file_proto_tickets_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -227,7 +401,7 @@ func (x *GetOrderResponse) String() string {
 func (*GetOrderResponse) ProtoMessage() {}
 
 func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[3]
+	mi := &file_proto_tickets_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -240,7 +414,7 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{3}
+	return file_proto_tickets_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetOrderResponse) GetOrder() *Order {
@@ -252,17 +426,20 @@ func (x *GetOrderResponse) GetOrder() *Order {
 
 // ListOrdersRequest represents a request to list orders
 type ListOrdersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// page is kept for backward compatibility; prefer page_token. It cannot be combined with page_token.
+	Page     int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of a previous response; empty for the first page
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListOrdersRequest) Reset() {
 	*x = ListOrdersRequest{}
-	mi := &file_proto_tickets_proto_msgTypes[4]
+	mi := &file_proto_tickets_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -274,7 +451,7 @@ func (x *ListOrdersRequest) String() string {
 func (*ListOrdersRequest) ProtoMessage() {}
 
 func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[4]
+	mi := &file_proto_tickets_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -287,7 +464,7 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
 func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{4}
+	return file_proto_tickets_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListOrdersRequest) GetUserId() int32 {
@@ -311,20 +488,30 @@ func (x *ListOrdersRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 // ListOrdersResponse represents the response from listing orders
 type ListOrdersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Orders     []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	TotalCount int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// page is 0 when the request paged by page_token
+	Page     int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token retrieves the next page; empty on the last page
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListOrdersResponse) Reset() {
 	*x = ListOrdersResponse{}
-	mi := &file_proto_tickets_proto_msgTypes[5]
+	mi := &file_proto_tickets_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -336,7 +523,7 @@ func (x *ListOrdersResponse) String() string {
 func (*ListOrdersResponse) ProtoMessage() {}
 
 func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[5]
+	mi := &file_proto_tickets_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,7 +536,7 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
 func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{5}
+	return file_proto_tickets_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListOrdersResponse) GetOrders() []*Order {
@@ -380,29 +567,36 @@ func (x *ListOrdersResponse) GetPageSize() int32 {
 	return 0
 }
 
-// GetConcertSessionRequest represents a request to retrieve a concert session
-type GetConcertSessionRequest struct {
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ConfirmOrderRequest represents a request to confirm payment of an order
+type ConfirmOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	OrderId       int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConcertSessionRequest) Reset() {
-	*x = GetConcertSessionRequest{}
-	mi := &file_proto_tickets_proto_msgTypes[6]
+func (x *ConfirmOrderRequest) Reset() {
+	*x = ConfirmOrderRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConcertSessionRequest) String() string {
+func (x *ConfirmOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConcertSessionRequest) ProtoMessage() {}
+func (*ConfirmOrderRequest) ProtoMessage() {}
 
-func (x *GetConcertSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[6]
+func (x *ConfirmOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,41 +607,41 @@ func (x *GetConcertSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConcertSessionRequest.ProtoReflect.Descriptor instead.
-func (*GetConcertSessionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ConfirmOrderRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetConcertSessionRequest) GetSessionId() int32 {
+func (x *ConfirmOrderRequest) GetOrderId() int32 {
 	if x != nil {
-		return x.SessionId
+		return x.OrderId
 	}
 	return 0
 }
 
-// GetConcertSessionResponse represents the response from retrieving a concert session
-type GetConcertSessionResponse struct {
+// ConfirmOrderResponse represents the response from confirming an order
+type ConfirmOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Session       *ConcertSession        `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConcertSessionResponse) Reset() {
-	*x = GetConcertSessionResponse{}
-	mi := &file_proto_tickets_proto_msgTypes[7]
+func (x *ConfirmOrderResponse) Reset() {
+	*x = ConfirmOrderResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConcertSessionResponse) String() string {
+func (x *ConfirmOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConcertSessionResponse) ProtoMessage() {}
+func (*ConfirmOrderResponse) ProtoMessage() {}
 
-func (x *GetConcertSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[7]
+func (x *ConfirmOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,42 +652,42 @@ func (x *GetConcertSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConcertSessionResponse.ProtoReflect.Descriptor instead.
-func (*GetConcertSessionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ConfirmOrderResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetConcertSessionResponse) GetSession() *ConcertSession {
+func (x *ConfirmOrderResponse) GetOrder() *Order {
 	if x != nil {
-		return x.Session
+		return x.Order
 	}
 	return nil
 }
 
-// ListConcertSessionsRequest represents a request to list concert sessions
-type ListConcertSessionsRequest struct {
+// CancelOrderRequest represents a request to cancel an order
+type CancelOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	OrderId       int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListConcertSessionsRequest) Reset() {
-	*x = ListConcertSessionsRequest{}
-	mi := &file_proto_tickets_proto_msgTypes[8]
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListConcertSessionsRequest) String() string {
+func (x *CancelOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListConcertSessionsRequest) ProtoMessage() {}
+func (*CancelOrderRequest) ProtoMessage() {}
 
-func (x *ListConcertSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[8]
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -504,51 +698,48 @@ func (x *ListConcertSessionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListConcertSessionsRequest.ProtoReflect.Descriptor instead.
-func (*ListConcertSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListConcertSessionsRequest) GetPage() int32 {
+func (x *CancelOrderRequest) GetOrderId() int32 {
 	if x != nil {
-		return x.Page
+		return x.OrderId
 	}
 	return 0
 }
 
-func (x *ListConcertSessionsRequest) GetPageSize() int32 {
+func (x *CancelOrderRequest) GetReason() string {
 	if x != nil {
-		return x.PageSize
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-// ListConcertSessionsResponse represents the response from listing concert sessions
-type ListConcertSessionsResponse struct {
+// CancelOrderResponse represents the response from cancelling an order
+type CancelOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sessions      []*ConcertSession      `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListConcertSessionsResponse) Reset() {
-	*x = ListConcertSessionsResponse{}
-	mi := &file_proto_tickets_proto_msgTypes[9]
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListConcertSessionsResponse) String() string {
+func (x *CancelOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListConcertSessionsResponse) ProtoMessage() {}
+func (*CancelOrderResponse) ProtoMessage() {}
 
-func (x *ListConcertSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[9]
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -559,63 +750,3084 @@ func (x *ListConcertSessionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListConcertSessionsResponse.ProtoReflect.Descriptor instead.
-func (*ListConcertSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ListConcertSessionsResponse) GetSessions() []*ConcertSession {
+func (x *CancelOrderResponse) GetOrder() *Order {
 	if x != nil {
-		return x.Sessions
+		return x.Order
 	}
 	return nil
 }
 
-func (x *ListConcertSessionsResponse) GetTotalCount() int32 {
-	if x != nil {
-		return x.TotalCount
-	}
-	return 0
+// PayOrderRequest represents a request to pay an order
+type PayOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// payment_method is the token the payment provider charges, such as a tokenised card
+	PaymentMethod string `protobuf:"bytes,2,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListConcertSessionsResponse) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
+func (x *PayOrderRequest) Reset() {
+	*x = PayOrderRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListConcertSessionsResponse) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
+func (x *PayOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-// GetAvailableTicketsRequest represents a request to get available tickets
-type GetAvailableTicketsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+func (*PayOrderRequest) ProtoMessage() {}
+
+func (x *PayOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderRequest.ProtoReflect.Descriptor instead.
+func (*PayOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PayOrderRequest) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *PayOrderRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+// PayOrderResponse represents the response from paying an order
+type PayOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// payment is unset for a free order, which is confirmed without a charge
+	Payment       *Payment `protobuf:"bytes,2,opt,name=payment,proto3" json:"payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayOrderResponse) Reset() {
+	*x = PayOrderResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderResponse) ProtoMessage() {}
+
+func (x *PayOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderResponse.ProtoReflect.Descriptor instead.
+func (*PayOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PayOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *PayOrderResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+// RefundOrderRequest represents a request to refund an order in full
+type RefundOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason  string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// refunded_by identifies who issues the refund, for the refund ledger
+	RefundedBy    string `protobuf:"bytes,3,opt,name=refunded_by,json=refundedBy,proto3" json:"refunded_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RefundOrderRequest) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *RefundOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetRefundedBy() string {
+	if x != nil {
+		return x.RefundedBy
+	}
+	return ""
+}
+
+// RefundOrderResponse represents the response from refunding an order
+type RefundOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,2,opt,name=refund,proto3" json:"refund,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderResponse) ProtoMessage() {}
+
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RefundOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *RefundOrderResponse) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+// RefundTicketsRequest represents a request to refund some tickets of an order
+type RefundTicketsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	OrderId   int32                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TicketIds []string               `protobuf:"bytes,2,rep,name=ticket_ids,json=ticketIds,proto3" json:"ticket_ids,omitempty"`
+	Reason    string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// refunded_by identifies who issues the refund, for the refund ledger
+	RefundedBy    string `protobuf:"bytes,4,opt,name=refunded_by,json=refundedBy,proto3" json:"refunded_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundTicketsRequest) Reset() {
+	*x = RefundTicketsRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundTicketsRequest) ProtoMessage() {}
+
+func (x *RefundTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundTicketsRequest.ProtoReflect.Descriptor instead.
+func (*RefundTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RefundTicketsRequest) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *RefundTicketsRequest) GetTicketIds() []string {
+	if x != nil {
+		return x.TicketIds
+	}
+	return nil
+}
+
+func (x *RefundTicketsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundTicketsRequest) GetRefundedBy() string {
+	if x != nil {
+		return x.RefundedBy
+	}
+	return ""
+}
+
+// RefundTicketsResponse represents the response from refunding tickets
+type RefundTicketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,2,opt,name=refund,proto3" json:"refund,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundTicketsResponse) Reset() {
+	*x = RefundTicketsResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundTicketsResponse) ProtoMessage() {}
+
+func (x *RefundTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundTicketsResponse.ProtoReflect.Descriptor instead.
+func (*RefundTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RefundTicketsResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *RefundTicketsResponse) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+// GetConcertSessionRequest represents a request to retrieve a concert session
+type GetConcertSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConcertSessionRequest) Reset() {
+	*x = GetConcertSessionRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConcertSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConcertSessionRequest) ProtoMessage() {}
+
+func (x *GetConcertSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConcertSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetConcertSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetConcertSessionRequest) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+// GetConcertSessionResponse represents the response from retrieving a concert session
+type GetConcertSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *ConcertSession        `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConcertSessionResponse) Reset() {
+	*x = GetConcertSessionResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConcertSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConcertSessionResponse) ProtoMessage() {}
+
+func (x *GetConcertSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConcertSessionResponse.ProtoReflect.Descriptor instead.
+func (*GetConcertSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetConcertSessionResponse) GetSession() *ConcertSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// ListConcertSessionsRequest represents a request to list concert sessions
+type ListConcertSessionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page is kept for backward compatibility; prefer page_token. It cannot be combined with page_token.
+	Page     int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional filters; unset values are ignored
+	StartTimeFrom *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time_from,json=startTimeFrom,proto3" json:"start_time_from,omitempty"`
+	StartTimeTo   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time_to,json=startTimeTo,proto3" json:"start_time_to,omitempty"`
+	// venue and location match case-insensitively
+	Venue    string  `protobuf:"bytes,5,opt,name=venue,proto3" json:"venue,omitempty"`
+	Location string  `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	MinPrice float64 `protobuf:"fixed64,7,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice float64 `protobuf:"fixed64,8,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	// only_available limits results to sessions with seats left
+	OnlyAvailable bool `protobuf:"varint,9,opt,name=only_available,json=onlyAvailable,proto3" json:"only_available,omitempty"`
+	// order_by is "start_time" (default) or "price", optionally followed by "asc" or "desc"
+	OrderBy string `protobuf:"bytes,10,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// page_token is the next_page_token of a previous response made with the same filters and order_by
+	PageToken     string `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConcertSessionsRequest) Reset() {
+	*x = ListConcertSessionsRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConcertSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConcertSessionsRequest) ProtoMessage() {}
+
+func (x *ListConcertSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConcertSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListConcertSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListConcertSessionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListConcertSessionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListConcertSessionsRequest) GetStartTimeFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTimeFrom
+	}
+	return nil
+}
+
+func (x *ListConcertSessionsRequest) GetStartTimeTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTimeTo
+	}
+	return nil
+}
+
+func (x *ListConcertSessionsRequest) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *ListConcertSessionsRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ListConcertSessionsRequest) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListConcertSessionsRequest) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListConcertSessionsRequest) GetOnlyAvailable() bool {
+	if x != nil {
+		return x.OnlyAvailable
+	}
+	return false
+}
+
+func (x *ListConcertSessionsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListConcertSessionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListConcertSessionsResponse represents the response from listing concert sessions
+type ListConcertSessionsResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Sessions   []*ConcertSession      `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	TotalCount int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// page is 0 when the request paged by page_token
+	Page     int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token retrieves the next page; empty on the last page
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConcertSessionsResponse) Reset() {
+	*x = ListConcertSessionsResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConcertSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConcertSessionsResponse) ProtoMessage() {}
+
+func (x *ListConcertSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConcertSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListConcertSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListConcertSessionsResponse) GetSessions() []*ConcertSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListConcertSessionsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListConcertSessionsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListConcertSessionsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListConcertSessionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// GetAvailableTicketsRequest represents a request to get available tickets
+type GetAvailableTicketsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// limit caps the tickets returned; defaults to 20 and is capped at 100
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableTicketsRequest) Reset() {
+	*x = GetAvailableTicketsRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableTicketsRequest) ProtoMessage() {}
+
+func (x *GetAvailableTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableTicketsRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailableTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetAvailableTicketsRequest) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *GetAvailableTicketsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// GetAvailableTicketsResponse represents the response from getting available tickets
+type GetAvailableTicketsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Tickets []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	// total_available counts every available ticket for the session, not just those returned
+	TotalAvailable int32 `protobuf:"varint,2,opt,name=total_available,json=totalAvailable,proto3" json:"total_available,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetAvailableTicketsResponse) Reset() {
+	*x = GetAvailableTicketsResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableTicketsResponse) ProtoMessage() {}
+
+func (x *GetAvailableTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableTicketsResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailableTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetAvailableTicketsResponse) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *GetAvailableTicketsResponse) GetTotalAvailable() int32 {
+	if x != nil {
+		return x.TotalAvailable
+	}
+	return 0
+}
+
+// CreateConcertRequest represents a request to create a concert
+type CreateConcertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConcertRequest) Reset() {
+	*x = CreateConcertRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConcertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConcertRequest) ProtoMessage() {}
+
+func (x *CreateConcertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConcertRequest.ProtoReflect.Descriptor instead.
+func (*CreateConcertRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateConcertRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateConcertRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *CreateConcertRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// CreateConcertResponse represents the response from creating a concert
+type CreateConcertResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Concert       *Concert               `protobuf:"bytes,1,opt,name=concert,proto3" json:"concert,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConcertResponse) Reset() {
+	*x = CreateConcertResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConcertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConcertResponse) ProtoMessage() {}
+
+func (x *CreateConcertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConcertResponse.ProtoReflect.Descriptor instead.
+func (*CreateConcertResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateConcertResponse) GetConcert() *Concert {
+	if x != nil {
+		return x.Concert
+	}
+	return nil
+}
+
+// UpdateConcertRequest represents a request to update a concert
+type UpdateConcertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConcertId     int32                  `protobuf:"varint,1,opt,name=concert_id,json=concertId,proto3" json:"concert_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateConcertRequest) Reset() {
+	*x = UpdateConcertRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConcertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConcertRequest) ProtoMessage() {}
+
+func (x *UpdateConcertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConcertRequest.ProtoReflect.Descriptor instead.
+func (*UpdateConcertRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateConcertRequest) GetConcertId() int32 {
+	if x != nil {
+		return x.ConcertId
+	}
+	return 0
+}
+
+func (x *UpdateConcertRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateConcertRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *UpdateConcertRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// UpdateConcertResponse represents the response from updating a concert
+type UpdateConcertResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Concert       *Concert               `protobuf:"bytes,1,opt,name=concert,proto3" json:"concert,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateConcertResponse) Reset() {
+	*x = UpdateConcertResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConcertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConcertResponse) ProtoMessage() {}
+
+func (x *UpdateConcertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConcertResponse.ProtoReflect.Descriptor instead.
+func (*UpdateConcertResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateConcertResponse) GetConcert() *Concert {
+	if x != nil {
+		return x.Concert
+	}
+	return nil
+}
+
+// DeleteConcertRequest represents a request to delete a concert
+type DeleteConcertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConcertId     int32                  `protobuf:"varint,1,opt,name=concert_id,json=concertId,proto3" json:"concert_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConcertRequest) Reset() {
+	*x = DeleteConcertRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConcertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConcertRequest) ProtoMessage() {}
+
+func (x *DeleteConcertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConcertRequest.ProtoReflect.Descriptor instead.
+func (*DeleteConcertRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteConcertRequest) GetConcertId() int32 {
+	if x != nil {
+		return x.ConcertId
+	}
+	return 0
+}
+
+// DeleteConcertResponse represents the response from deleting a concert
+type DeleteConcertResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConcertResponse) Reset() {
+	*x = DeleteConcertResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConcertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConcertResponse) ProtoMessage() {}
+
+func (x *DeleteConcertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConcertResponse.ProtoReflect.Descriptor instead.
+func (*DeleteConcertResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{28}
+}
+
+// CreateConcertSessionRequest represents a request to schedule a concert session
+type CreateConcertSessionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ConcertId int32                  `protobuf:"varint,1,opt,name=concert_id,json=concertId,proto3" json:"concert_id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// end_time must be after start_time
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Venue   string                 `protobuf:"bytes,4,opt,name=venue,proto3" json:"venue,omitempty"`
+	// number_of_seats general admission tickets are created for the session; at most 100000
+	NumberOfSeats int32   `protobuf:"varint,5,opt,name=number_of_seats,json=numberOfSeats,proto3" json:"number_of_seats,omitempty"`
+	Price         float64 `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	// sections makes the session reserved seating with one ticket per seat;
+	// number_of_seats may then be omitted
+	Sections      []*SeatingSection `protobuf:"bytes,7,rep,name=sections,proto3" json:"sections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConcertSessionRequest) Reset() {
+	*x = CreateConcertSessionRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConcertSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConcertSessionRequest) ProtoMessage() {}
+
+func (x *CreateConcertSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConcertSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateConcertSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateConcertSessionRequest) GetConcertId() int32 {
+	if x != nil {
+		return x.ConcertId
+	}
+	return 0
+}
+
+func (x *CreateConcertSessionRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CreateConcertSessionRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *CreateConcertSessionRequest) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *CreateConcertSessionRequest) GetNumberOfSeats() int32 {
+	if x != nil {
+		return x.NumberOfSeats
+	}
+	return 0
+}
+
+func (x *CreateConcertSessionRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateConcertSessionRequest) GetSections() []*SeatingSection {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+// CreateConcertSessionResponse represents the response from scheduling a concert session
+type CreateConcertSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *ConcertSession        `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConcertSessionResponse) Reset() {
+	*x = CreateConcertSessionResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConcertSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConcertSessionResponse) ProtoMessage() {}
+
+func (x *CreateConcertSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConcertSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateConcertSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateConcertSessionResponse) GetSession() *ConcertSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// UpdateConcertSessionRequest represents a request to update a concert session.
+// Existing orders keep the price they were placed at.
+type UpdateConcertSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Venue         string                 `protobuf:"bytes,4,opt,name=venue,proto3" json:"venue,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateConcertSessionRequest) Reset() {
+	*x = UpdateConcertSessionRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConcertSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConcertSessionRequest) ProtoMessage() {}
+
+func (x *UpdateConcertSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConcertSessionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateConcertSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateConcertSessionRequest) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *UpdateConcertSessionRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *UpdateConcertSessionRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *UpdateConcertSessionRequest) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *UpdateConcertSessionRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+// UpdateConcertSessionResponse represents the response from updating a concert session
+type UpdateConcertSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *ConcertSession        `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateConcertSessionResponse) Reset() {
+	*x = UpdateConcertSessionResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConcertSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConcertSessionResponse) ProtoMessage() {}
+
+func (x *UpdateConcertSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConcertSessionResponse.ProtoReflect.Descriptor instead.
+func (*UpdateConcertSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateConcertSessionResponse) GetSession() *ConcertSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// UpdateSessionCapacityRequest represents a request to change a session's number of seats.
+// Shrinking only removes available tickets; it fails if sold or held tickets would be affected.
+type UpdateSessionCapacityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	NumberOfSeats int32                  `protobuf:"varint,2,opt,name=number_of_seats,json=numberOfSeats,proto3" json:"number_of_seats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSessionCapacityRequest) Reset() {
+	*x = UpdateSessionCapacityRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSessionCapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSessionCapacityRequest) ProtoMessage() {}
+
+func (x *UpdateSessionCapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSessionCapacityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSessionCapacityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateSessionCapacityRequest) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *UpdateSessionCapacityRequest) GetNumberOfSeats() int32 {
+	if x != nil {
+		return x.NumberOfSeats
+	}
+	return 0
+}
+
+// UpdateSessionCapacityResponse represents the response from changing a session's capacity
+type UpdateSessionCapacityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *ConcertSession        `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSessionCapacityResponse) Reset() {
+	*x = UpdateSessionCapacityResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSessionCapacityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSessionCapacityResponse) ProtoMessage() {}
+
+func (x *UpdateSessionCapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSessionCapacityResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSessionCapacityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateSessionCapacityResponse) GetSession() *ConcertSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// DeleteConcertSessionRequest represents a request to delete a concert session
+type DeleteConcertSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConcertSessionRequest) Reset() {
+	*x = DeleteConcertSessionRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConcertSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConcertSessionRequest) ProtoMessage() {}
+
+func (x *DeleteConcertSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConcertSessionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteConcertSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteConcertSessionRequest) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+// DeleteConcertSessionResponse represents the response from deleting a concert session
+type DeleteConcertSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConcertSessionResponse) Reset() {
+	*x = DeleteConcertSessionResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConcertSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConcertSessionResponse) ProtoMessage() {}
+
+func (x *DeleteConcertSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConcertSessionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteConcertSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{36}
+}
+
+// GetSeatMapRequest represents a request for a session's seat map
+type GetSeatMapRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// section limits the map to one section; empty for all sections
+	Section       string `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeatMapRequest) Reset() {
+	*x = GetSeatMapRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeatMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatMapRequest) ProtoMessage() {}
+
+func (x *GetSeatMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeatMapRequest.ProtoReflect.Descriptor instead.
+func (*GetSeatMapRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetSeatMapRequest) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *GetSeatMapRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+// GetSeatMapResponse lists seats in section, row and seat number order.
+// seats is empty for general admission sessions.
+type GetSeatMapResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Seats     []*Ticket              `protobuf:"bytes,2,rep,name=seats,proto3" json:"seats,omitempty"`
+	// available counts the listed seats that can be bought
+	Available     int32 `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeatMapResponse) Reset() {
+	*x = GetSeatMapResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeatMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatMapResponse) ProtoMessage() {}
+
+func (x *GetSeatMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeatMapResponse.ProtoReflect.Descriptor instead.
+func (*GetSeatMapResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetSeatMapResponse) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *GetSeatMapResponse) GetSeats() []*Ticket {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *GetSeatMapResponse) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+// ListTicketTypesRequest represents a request for a session's ticket types
+type ListTicketTypesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketTypesRequest) Reset() {
+	*x = ListTicketTypesRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketTypesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketTypesRequest) ProtoMessage() {}
+
+func (x *ListTicketTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketTypesRequest.ProtoReflect.Descriptor instead.
+func (*ListTicketTypesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListTicketTypesRequest) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+// ListTicketTypesResponse lists ticket types cheapest first.
+// ticket_types is empty for sessions that sell every ticket at the session price.
+type ListTicketTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketTypes   []*TicketType          `protobuf:"bytes,1,rep,name=ticket_types,json=ticketTypes,proto3" json:"ticket_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketTypesResponse) Reset() {
+	*x = ListTicketTypesResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketTypesResponse) ProtoMessage() {}
+
+func (x *ListTicketTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListTicketTypesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListTicketTypesResponse) GetTicketTypes() []*TicketType {
+	if x != nil {
+		return x.TicketTypes
+	}
+	return nil
+}
+
+// CreateTicketTypeRequest represents a request to add a ticket type to a session
+type CreateTicketTypeRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId int32                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// name is unique within the session, ignoring letter case
+	Name        string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string  `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	// quota caps how many tickets of the type pending and paid orders may hold
+	Quota         int32 `protobuf:"varint,5,opt,name=quota,proto3" json:"quota,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTicketTypeRequest) Reset() {
+	*x = CreateTicketTypeRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTicketTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTicketTypeRequest) ProtoMessage() {}
+
+func (x *CreateTicketTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTicketTypeRequest.ProtoReflect.Descriptor instead.
+func (*CreateTicketTypeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateTicketTypeRequest) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *CreateTicketTypeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTicketTypeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTicketTypeRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateTicketTypeRequest) GetQuota() int32 {
+	if x != nil {
+		return x.Quota
+	}
+	return 0
+}
+
+// CreateTicketTypeResponse represents the response from adding a ticket type
+type CreateTicketTypeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketType    *TicketType            `protobuf:"bytes,1,opt,name=ticket_type,json=ticketType,proto3" json:"ticket_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTicketTypeResponse) Reset() {
+	*x = CreateTicketTypeResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTicketTypeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTicketTypeResponse) ProtoMessage() {}
+
+func (x *CreateTicketTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTicketTypeResponse.ProtoReflect.Descriptor instead.
+func (*CreateTicketTypeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateTicketTypeResponse) GetTicketType() *TicketType {
+	if x != nil {
+		return x.TicketType
+	}
+	return nil
+}
+
+// UpdateTicketTypeRequest represents a request to update a ticket type.
+// Existing orders keep the price they were placed at; quota cannot drop below the tickets held.
+type UpdateTicketTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketTypeId  int32                  `protobuf:"varint,1,opt,name=ticket_type_id,json=ticketTypeId,proto3" json:"ticket_type_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Quota         int32                  `protobuf:"varint,5,opt,name=quota,proto3" json:"quota,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTicketTypeRequest) Reset() {
+	*x = UpdateTicketTypeRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTicketTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTicketTypeRequest) ProtoMessage() {}
+
+func (x *UpdateTicketTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTicketTypeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTicketTypeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateTicketTypeRequest) GetTicketTypeId() int32 {
+	if x != nil {
+		return x.TicketTypeId
+	}
+	return 0
+}
+
+func (x *UpdateTicketTypeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTicketTypeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTicketTypeRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateTicketTypeRequest) GetQuota() int32 {
+	if x != nil {
+		return x.Quota
+	}
+	return 0
+}
+
+// UpdateTicketTypeResponse represents the response from updating a ticket type
+type UpdateTicketTypeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketType    *TicketType            `protobuf:"bytes,1,opt,name=ticket_type,json=ticketType,proto3" json:"ticket_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTicketTypeResponse) Reset() {
+	*x = UpdateTicketTypeResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTicketTypeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTicketTypeResponse) ProtoMessage() {}
+
+func (x *UpdateTicketTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTicketTypeResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTicketTypeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UpdateTicketTypeResponse) GetTicketType() *TicketType {
+	if x != nil {
+		return x.TicketType
+	}
+	return nil
+}
+
+// DeleteTicketTypeRequest represents a request to delete a ticket type
+type DeleteTicketTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketTypeId  int32                  `protobuf:"varint,1,opt,name=ticket_type_id,json=ticketTypeId,proto3" json:"ticket_type_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTicketTypeRequest) Reset() {
+	*x = DeleteTicketTypeRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTicketTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTicketTypeRequest) ProtoMessage() {}
+
+func (x *DeleteTicketTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTicketTypeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTicketTypeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteTicketTypeRequest) GetTicketTypeId() int32 {
+	if x != nil {
+		return x.TicketTypeId
+	}
+	return 0
+}
+
+// DeleteTicketTypeResponse represents the response from deleting a ticket type
+type DeleteTicketTypeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTicketTypeResponse) Reset() {
+	*x = DeleteTicketTypeResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTicketTypeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTicketTypeResponse) ProtoMessage() {}
+
+func (x *DeleteTicketTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTicketTypeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTicketTypeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{46}
+}
+
+// CreatePromoCodeRequest represents a request to create a promo code. Zero-valued limits and
+// scopes do not apply.
+type CreatePromoCodeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code is 1 to 50 letters, digits, hyphens or underscores, stored upper case
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// discount_type is "percentage" (discount_value of at most 100) or "fixed"
+	DiscountType  string  `protobuf:"bytes,2,opt,name=discount_type,json=discountType,proto3" json:"discount_type,omitempty"`
+	DiscountValue float64 `protobuf:"fixed64,3,opt,name=discount_value,json=discountValue,proto3" json:"discount_value,omitempty"`
+	// valid_from and valid_until bound when the code may be redeemed; valid_until is exclusive
+	ValidFrom             *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidUntil            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	MaxRedemptions        int32                  `protobuf:"varint,6,opt,name=max_redemptions,json=maxRedemptions,proto3" json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser int32                  `protobuf:"varint,7,opt,name=max_redemptions_per_user,json=maxRedemptionsPerUser,proto3" json:"max_redemptions_per_user,omitempty"`
+	// concert_id or session_id, not both, limit the code to one concert or session
+	ConcertId     int32 `protobuf:"varint,8,opt,name=concert_id,json=concertId,proto3" json:"concert_id,omitempty"`
+	SessionId     int32 `protobuf:"varint,9,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromoCodeRequest) Reset() {
+	*x = CreatePromoCodeRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromoCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromoCodeRequest) ProtoMessage() {}
+
+func (x *CreatePromoCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromoCodeRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromoCodeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreatePromoCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreatePromoCodeRequest) GetDiscountType() string {
+	if x != nil {
+		return x.DiscountType
+	}
+	return ""
+}
+
+func (x *CreatePromoCodeRequest) GetDiscountValue() float64 {
+	if x != nil {
+		return x.DiscountValue
+	}
+	return 0
+}
+
+func (x *CreatePromoCodeRequest) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *CreatePromoCodeRequest) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *CreatePromoCodeRequest) GetMaxRedemptions() int32 {
+	if x != nil {
+		return x.MaxRedemptions
+	}
+	return 0
+}
+
+func (x *CreatePromoCodeRequest) GetMaxRedemptionsPerUser() int32 {
+	if x != nil {
+		return x.MaxRedemptionsPerUser
+	}
+	return 0
+}
+
+func (x *CreatePromoCodeRequest) GetConcertId() int32 {
+	if x != nil {
+		return x.ConcertId
+	}
+	return 0
+}
+
+func (x *CreatePromoCodeRequest) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+// CreatePromoCodeResponse represents the response from creating a promo code
+type CreatePromoCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromoCode     *PromoCode             `protobuf:"bytes,1,opt,name=promo_code,json=promoCode,proto3" json:"promo_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromoCodeResponse) Reset() {
+	*x = CreatePromoCodeResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromoCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromoCodeResponse) ProtoMessage() {}
+
+func (x *CreatePromoCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromoCodeResponse.ProtoReflect.Descriptor instead.
+func (*CreatePromoCodeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreatePromoCodeResponse) GetPromoCode() *PromoCode {
+	if x != nil {
+		return x.PromoCode
+	}
+	return nil
+}
+
+// GetPromoCodeRequest represents a request to retrieve a promo code
+type GetPromoCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromoCodeRequest) Reset() {
+	*x = GetPromoCodeRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromoCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromoCodeRequest) ProtoMessage() {}
+
+func (x *GetPromoCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromoCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetPromoCodeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetPromoCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// GetPromoCodeResponse represents the response from retrieving a promo code
+type GetPromoCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromoCode     *PromoCode             `protobuf:"bytes,1,opt,name=promo_code,json=promoCode,proto3" json:"promo_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromoCodeResponse) Reset() {
+	*x = GetPromoCodeResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromoCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromoCodeResponse) ProtoMessage() {}
+
+func (x *GetPromoCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromoCodeResponse.ProtoReflect.Descriptor instead.
+func (*GetPromoCodeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetPromoCodeResponse) GetPromoCode() *PromoCode {
+	if x != nil {
+		return x.PromoCode
+	}
+	return nil
+}
+
+// DeletePromoCodeRequest represents a request to delete a promo code
+type DeletePromoCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromoCodeId   int32                  `protobuf:"varint,1,opt,name=promo_code_id,json=promoCodeId,proto3" json:"promo_code_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePromoCodeRequest) Reset() {
+	*x = DeletePromoCodeRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePromoCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePromoCodeRequest) ProtoMessage() {}
+
+func (x *DeletePromoCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePromoCodeRequest.ProtoReflect.Descriptor instead.
+func (*DeletePromoCodeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DeletePromoCodeRequest) GetPromoCodeId() int32 {
+	if x != nil {
+		return x.PromoCodeId
+	}
+	return 0
+}
+
+// DeletePromoCodeResponse represents the response from deleting a promo code
+type DeletePromoCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePromoCodeResponse) Reset() {
+	*x = DeletePromoCodeResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePromoCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePromoCodeResponse) ProtoMessage() {}
+
+func (x *DeletePromoCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePromoCodeResponse.ProtoReflect.Descriptor instead.
+func (*DeletePromoCodeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{52}
+}
+
+// GetLedgerBalancesRequest represents a request for ledger account balances
+type GetLedgerBalancesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// account limits the response to one account; every account if unset
+	Account       string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLedgerBalancesRequest) Reset() {
+	*x = GetLedgerBalancesRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLedgerBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLedgerBalancesRequest) ProtoMessage() {}
+
+func (x *GetLedgerBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLedgerBalancesRequest.ProtoReflect.Descriptor instead.
+func (*GetLedgerBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetLedgerBalancesRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+// GetLedgerBalancesResponse represents the response with ledger account balances
+type GetLedgerBalancesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balances      []*AccountBalance      `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLedgerBalancesResponse) Reset() {
+	*x = GetLedgerBalancesResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLedgerBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLedgerBalancesResponse) ProtoMessage() {}
+
+func (x *GetLedgerBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLedgerBalancesResponse.ProtoReflect.Descriptor instead.
+func (*GetLedgerBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetLedgerBalancesResponse) GetBalances() []*AccountBalance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+// CheckLedgerRequest represents a request to check that the ledger balances
+type CheckLedgerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckLedgerRequest) Reset() {
+	*x = CheckLedgerRequest{}
+	mi := &file_proto_tickets_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckLedgerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckLedgerRequest) ProtoMessage() {}
+
+func (x *CheckLedgerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckLedgerRequest.ProtoReflect.Descriptor instead.
+func (*CheckLedgerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{55}
+}
+
+// CheckLedgerResponse represents the result of checking the ledger
+type CheckLedgerResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Balanced     bool                   `protobuf:"varint,1,opt,name=balanced,proto3" json:"balanced,omitempty"`
+	TotalDebits  float64                `protobuf:"fixed64,2,opt,name=total_debits,json=totalDebits,proto3" json:"total_debits,omitempty"`
+	TotalCredits float64                `protobuf:"fixed64,3,opt,name=total_credits,json=totalCredits,proto3" json:"total_credits,omitempty"`
+	// unbalanced_transaction_ids lists transactions whose debits differ from their credits
+	UnbalancedTransactionIds []int32 `protobuf:"varint,4,rep,packed,name=unbalanced_transaction_ids,json=unbalancedTransactionIds,proto3" json:"unbalanced_transaction_ids,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CheckLedgerResponse) Reset() {
+	*x = CheckLedgerResponse{}
+	mi := &file_proto_tickets_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckLedgerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckLedgerResponse) ProtoMessage() {}
+
+func (x *CheckLedgerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckLedgerResponse.ProtoReflect.Descriptor instead.
+func (*CheckLedgerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CheckLedgerResponse) GetBalanced() bool {
+	if x != nil {
+		return x.Balanced
+	}
+	return false
+}
+
+func (x *CheckLedgerResponse) GetTotalDebits() float64 {
+	if x != nil {
+		return x.TotalDebits
+	}
+	return 0
+}
+
+func (x *CheckLedgerResponse) GetTotalCredits() float64 {
+	if x != nil {
+		return x.TotalCredits
+	}
+	return 0
+}
+
+func (x *CheckLedgerResponse) GetUnbalancedTransactionIds() []int32 {
+	if x != nil {
+		return x.UnbalancedTransactionIds
+	}
+	return nil
+}
+
+// SeatingSection describes a block of reserved seats: rows labelled A, B, ... Z, AA, ...
+// each with seats numbered from 1
+type SeatingSection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rows          int32                  `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	SeatsPerRow   int32                  `protobuf:"varint,3,opt,name=seats_per_row,json=seatsPerRow,proto3" json:"seats_per_row,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatingSection) Reset() {
+	*x = SeatingSection{}
+	mi := &file_proto_tickets_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatingSection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatingSection) ProtoMessage() {}
+
+func (x *SeatingSection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatingSection.ProtoReflect.Descriptor instead.
+func (*SeatingSection) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SeatingSection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SeatingSection) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *SeatingSection) GetSeatsPerRow() int32 {
+	if x != nil {
+		return x.SeatsPerRow
+	}
+	return 0
+}
+
+// Order represents an order in the system
+type Order struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status             string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TotalPrice         float64                `protobuf:"fixed64,3,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Items              []*OrderItem           `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	UserId             int32                  `protobuf:"varint,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpiresAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CancelledAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	CancellationReason string                 `protobuf:"bytes,9,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
+	// subtotal is the sum of the item prices; total_price is subtotal less discount plus
+	// service_fee and tax
+	Subtotal  float64 `protobuf:"fixed64,10,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount  float64 `protobuf:"fixed64,11,opt,name=discount,proto3" json:"discount,omitempty"`
+	PromoCode string  `protobuf:"bytes,12,opt,name=promo_code,json=promoCode,proto3" json:"promo_code,omitempty"`
+	// refunded_amount is the sum of the order's succeeded refunds
+	RefundedAmount float64 `protobuf:"fixed64,13,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	ServiceFee     float64 `protobuf:"fixed64,14,opt,name=service_fee,json=serviceFee,proto3" json:"service_fee,omitempty"`
+	// tax is tax_rate percent of the discounted subtotal and service fee, charged in tax_jurisdiction
+	Tax     float64 `protobuf:"fixed64,15,opt,name=tax,proto3" json:"tax,omitempty"`
+	TaxRate float64 `protobuf:"fixed64,16,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	// tax_jurisdiction is empty for untaxed orders
+	TaxJurisdiction string `protobuf:"bytes,17,opt,name=tax_jurisdiction,json=taxJurisdiction,proto3" json:"tax_jurisdiction,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_proto_tickets_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *Order) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Order) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Order) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+func (x *Order) GetCancellationReason() string {
+	if x != nil {
+		return x.CancellationReason
+	}
+	return ""
+}
+
+func (x *Order) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *Order) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *Order) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
+func (x *Order) GetRefundedAmount() float64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+func (x *Order) GetServiceFee() float64 {
+	if x != nil {
+		return x.ServiceFee
+	}
+	return 0
+}
+
+func (x *Order) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *Order) GetTaxRate() float64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+func (x *Order) GetTaxJurisdiction() string {
+	if x != nil {
+		return x.TaxJurisdiction
+	}
+	return ""
+}
+
+// Payment is one attempt to pay an order with the payment provider
+type Payment struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId           int32                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Provider          string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	ProviderReference string                 `protobuf:"bytes,4,opt,name=provider_reference,json=providerReference,proto3" json:"provider_reference,omitempty"`
+	Amount            float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status            string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// failure_code and failure_message explain a declined or failed payment
+	FailureCode    string                 `protobuf:"bytes,7,opt,name=failure_code,json=failureCode,proto3" json:"failure_code,omitempty"`
+	FailureMessage string                 `protobuf:"bytes,8,opt,name=failure_message,json=failureMessage,proto3" json:"failure_message,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_proto_tickets_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *Payment) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Payment) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Payment) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Payment) GetProviderReference() string {
+	if x != nil {
+		return x.ProviderReference
+	}
+	return ""
+}
+
+func (x *Payment) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Payment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Payment) GetFailureCode() string {
+	if x != nil {
+		return x.FailureCode
+	}
+	return ""
+}
+
+func (x *Payment) GetFailureMessage() string {
+	if x != nil {
+		return x.FailureMessage
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Refund pays back some or all tickets of a paid order
+type Refund struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId int32                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// payment_id is the payment refunded; 0 for an order confirmed without a payment
+	PaymentId      int32                  `protobuf:"varint,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Amount         float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	RefundedBy     string                 `protobuf:"bytes,6,opt,name=refunded_by,json=refundedBy,proto3" json:"refunded_by,omitempty"`
+	Status         string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	FailureMessage string                 `protobuf:"bytes,8,opt,name=failure_message,json=failureMessage,proto3" json:"failure_message,omitempty"`
+	TicketIds      []string               `protobuf:"bytes,9,rep,name=ticket_ids,json=ticketIds,proto3" json:"ticket_ids,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Refund) Reset() {
+	*x = Refund{}
+	mi := &file_proto_tickets_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refund) ProtoMessage() {}
+
+func (x *Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refund.ProtoReflect.Descriptor instead.
+func (*Refund) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *Refund) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Refund) GetOrderId() int32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Refund) GetPaymentId() int32 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *Refund) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Refund) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Refund) GetRefundedBy() string {
+	if x != nil {
+		return x.RefundedBy
+	}
+	return ""
+}
+
+func (x *Refund) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Refund) GetFailureMessage() string {
+	if x != nil {
+		return x.FailureMessage
+	}
+	return ""
+}
+
+func (x *Refund) GetTicketIds() []string {
+	if x != nil {
+		return x.TicketIds
+	}
+	return nil
+}
+
+func (x *Refund) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// OrderItem represents an item in an order
+type OrderItem struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TicketId string                 `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	Price    float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Ticket   *Ticket                `protobuf:"bytes,4,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	// ticket_type_id is the ticket type the item was sold as; 0 for the session price
+	TicketTypeId int32 `protobuf:"varint,5,opt,name=ticket_type_id,json=ticketTypeId,proto3" json:"ticket_type_id,omitempty"`
+	// refund_id is the refund that refunded the item; 0 if it was not refunded
+	RefundId      int32 `protobuf:"varint,6,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAvailableTicketsRequest) Reset() {
-	*x = GetAvailableTicketsRequest{}
-	mi := &file_proto_tickets_proto_msgTypes[10]
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_proto_tickets_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAvailableTicketsRequest) String() string {
+func (x *OrderItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailableTicketsRequest) ProtoMessage() {}
+func (*OrderItem) ProtoMessage() {}
 
-func (x *GetAvailableTicketsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[10]
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -626,49 +3838,85 @@ func (x *GetAvailableTicketsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailableTicketsRequest.ProtoReflect.Descriptor instead.
-func (*GetAvailableTicketsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *GetAvailableTicketsRequest) GetSessionId() int32 {
+func (x *OrderItem) GetId() int32 {
 	if x != nil {
-		return x.SessionId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *GetAvailableTicketsRequest) GetLimit() int32 {
+func (x *OrderItem) GetTicketId() string {
 	if x != nil {
-		return x.Limit
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *OrderItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
 	}
 	return 0
 }
 
-// GetAvailableTicketsResponse represents the response from getting available tickets
-type GetAvailableTicketsResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Tickets        []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
-	TotalAvailable int32                  `protobuf:"varint,2,opt,name=total_available,json=totalAvailable,proto3" json:"total_available,omitempty"`
+func (x *OrderItem) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *OrderItem) GetTicketTypeId() int32 {
+	if x != nil {
+		return x.TicketTypeId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetRefundId() int32 {
+	if x != nil {
+		return x.RefundId
+	}
+	return 0
+}
+
+// ConcertSession represents a concert session
+type ConcertSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConcertId     int32                  `protobuf:"varint,2,opt,name=concert_id,json=concertId,proto3" json:"concert_id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Venue         string                 `protobuf:"bytes,5,opt,name=venue,proto3" json:"venue,omitempty"`
+	NumberOfSeats int32                  `protobuf:"varint,6,opt,name=number_of_seats,json=numberOfSeats,proto3" json:"number_of_seats,omitempty"`
+	Price         float64                `protobuf:"fixed64,7,opt,name=price,proto3" json:"price,omitempty"`
+	Concert       *Concert               `protobuf:"bytes,8,opt,name=concert,proto3" json:"concert,omitempty"`
+	// remaining_seats is the number of tickets still available for purchase
+	RemainingSeats int32 `protobuf:"varint,9,opt,name=remaining_seats,json=remainingSeats,proto3" json:"remaining_seats,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GetAvailableTicketsResponse) Reset() {
-	*x = GetAvailableTicketsResponse{}
-	mi := &file_proto_tickets_proto_msgTypes[11]
+func (x *ConcertSession) Reset() {
+	*x = ConcertSession{}
+	mi := &file_proto_tickets_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAvailableTicketsResponse) String() string {
+func (x *ConcertSession) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailableTicketsResponse) ProtoMessage() {}
+func (*ConcertSession) ProtoMessage() {}
 
-func (x *GetAvailableTicketsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[11]
+func (x *ConcertSession) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -679,52 +3927,101 @@ func (x *GetAvailableTicketsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailableTicketsResponse.ProtoReflect.Descriptor instead.
-func (*GetAvailableTicketsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ConcertSession.ProtoReflect.Descriptor instead.
+func (*ConcertSession) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *GetAvailableTicketsResponse) GetTickets() []*Ticket {
+func (x *ConcertSession) GetId() int32 {
 	if x != nil {
-		return x.Tickets
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConcertSession) GetConcertId() int32 {
+	if x != nil {
+		return x.ConcertId
+	}
+	return 0
+}
+
+func (x *ConcertSession) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
 	}
 	return nil
 }
 
-func (x *GetAvailableTicketsResponse) GetTotalAvailable() int32 {
+func (x *ConcertSession) GetEndTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TotalAvailable
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ConcertSession) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *ConcertSession) GetNumberOfSeats() int32 {
+	if x != nil {
+		return x.NumberOfSeats
 	}
 	return 0
 }
 
-// Order represents an order in the system
-type Order struct {
+func (x *ConcertSession) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ConcertSession) GetConcert() *Concert {
+	if x != nil {
+		return x.Concert
+	}
+	return nil
+}
+
+func (x *ConcertSession) GetRemainingSeats() int32 {
+	if x != nil {
+		return x.RemainingSeats
+	}
+	return 0
+}
+
+// Concert represents a concert
+type Concert struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	TotalPrice    float64                `protobuf:"fixed64,3,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order) Reset() {
-	*x = Order{}
-	mi := &file_proto_tickets_proto_msgTypes[12]
+func (x *Concert) Reset() {
+	*x = Concert{}
+	mi := &file_proto_tickets_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order) String() string {
+func (x *Concert) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Order) ProtoMessage() {}
+func (*Concert) ProtoMessage() {}
 
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[12]
+func (x *Concert) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -735,72 +4032,77 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use Concert.ProtoReflect.Descriptor instead.
+func (*Concert) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *Order) GetId() int32 {
+func (x *Concert) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Order) GetStatus() string {
+func (x *Concert) GetName() string {
 	if x != nil {
-		return x.Status
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Order) GetTotalPrice() float64 {
+func (x *Concert) GetLocation() string {
 	if x != nil {
-		return x.TotalPrice
+		return x.Location
 	}
-	return 0
+	return ""
 }
 
-func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+func (x *Concert) GetDescription() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Description
 	}
-	return nil
+	return ""
 }
 
-func (x *Order) GetItems() []*OrderItem {
+func (x *Concert) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Items
+		return x.CreatedAt
 	}
 	return nil
 }
 
-// OrderItem represents an item in an order
-type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	TicketId      string                 `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Ticket        *Ticket                `protobuf:"bytes,4,opt,name=ticket,proto3" json:"ticket,omitempty"`
+// Ticket represents a ticket
+type Ticket struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId int32                  `protobuf:"varint,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Status    string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// section, row and seat_number locate a reserved seat; empty for general admission
+	Section    string `protobuf:"bytes,4,opt,name=section,proto3" json:"section,omitempty"`
+	Row        string `protobuf:"bytes,5,opt,name=row,proto3" json:"row,omitempty"`
+	SeatNumber int32  `protobuf:"varint,6,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"`
+	// ticket_type names the ticket type the ticket was sold as, when loaded with its order
+	TicketType    string `protobuf:"bytes,7,opt,name=ticket_type,json=ticketType,proto3" json:"ticket_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_proto_tickets_proto_msgTypes[13]
+func (x *Ticket) Reset() {
+	*x = Ticket{}
+	mi := &file_proto_tickets_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *Ticket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*Ticket) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[13]
+func (x *Ticket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -811,69 +4113,90 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
+func (*Ticket) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *OrderItem) GetId() int32 {
+func (x *Ticket) GetId() string {
 	if x != nil {
 		return x.Id
 	}
+	return ""
+}
+
+func (x *Ticket) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
 	return 0
 }
 
-func (x *OrderItem) GetTicketId() string {
+func (x *Ticket) GetStatus() string {
 	if x != nil {
-		return x.TicketId
+		return x.Status
 	}
 	return ""
 }
 
-func (x *OrderItem) GetPrice() float64 {
+func (x *Ticket) GetSection() string {
 	if x != nil {
-		return x.Price
+		return x.Section
+	}
+	return ""
+}
+
+func (x *Ticket) GetRow() string {
+	if x != nil {
+		return x.Row
+	}
+	return ""
+}
+
+func (x *Ticket) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
 	}
 	return 0
 }
 
-func (x *OrderItem) GetTicket() *Ticket {
+func (x *Ticket) GetTicketType() string {
 	if x != nil {
-		return x.Ticket
+		return x.TicketType
 	}
-	return nil
+	return ""
 }
 
-// ConcertSession represents a concert session
-type ConcertSession struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ConcertId     int32                  `protobuf:"varint,2,opt,name=concert_id,json=concertId,proto3" json:"concert_id,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Venue         string                 `protobuf:"bytes,5,opt,name=venue,proto3" json:"venue,omitempty"`
-	NumberOfSeats int32                  `protobuf:"varint,6,opt,name=number_of_seats,json=numberOfSeats,proto3" json:"number_of_seats,omitempty"`
-	Price         float64                `protobuf:"fixed64,7,opt,name=price,proto3" json:"price,omitempty"`
-	Concert       *Concert               `protobuf:"bytes,8,opt,name=concert,proto3" json:"concert,omitempty"`
+// TicketType is a priced tier of a session's tickets, such as VIP or Early Bird
+type TicketType struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId   int32                  `protobuf:"varint,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quota       int32                  `protobuf:"varint,6,opt,name=quota,proto3" json:"quota,omitempty"`
+	// remaining is the quota not yet held by pending or paid orders
+	Remaining     int32 `protobuf:"varint,7,opt,name=remaining,proto3" json:"remaining,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConcertSession) Reset() {
-	*x = ConcertSession{}
-	mi := &file_proto_tickets_proto_msgTypes[14]
+func (x *TicketType) Reset() {
+	*x = TicketType{}
+	mi := &file_proto_tickets_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConcertSession) String() string {
+func (x *TicketType) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConcertSession) ProtoMessage() {}
+func (*TicketType) ProtoMessage() {}
 
-func (x *ConcertSession) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[14]
+func (x *TicketType) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -884,94 +4207,95 @@ func (x *ConcertSession) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConcertSession.ProtoReflect.Descriptor instead.
-func (*ConcertSession) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use TicketType.ProtoReflect.Descriptor instead.
+func (*TicketType) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *ConcertSession) GetId() int32 {
+func (x *TicketType) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *ConcertSession) GetConcertId() int32 {
+func (x *TicketType) GetSessionId() int32 {
 	if x != nil {
-		return x.ConcertId
+		return x.SessionId
 	}
 	return 0
 }
 
-func (x *ConcertSession) GetStartTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.StartTime
-	}
-	return nil
-}
-
-func (x *ConcertSession) GetEndTime() *timestamppb.Timestamp {
+func (x *TicketType) GetName() string {
 	if x != nil {
-		return x.EndTime
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *ConcertSession) GetVenue() string {
+func (x *TicketType) GetDescription() string {
 	if x != nil {
-		return x.Venue
+		return x.Description
 	}
 	return ""
 }
 
-func (x *ConcertSession) GetNumberOfSeats() int32 {
+func (x *TicketType) GetPrice() float64 {
 	if x != nil {
-		return x.NumberOfSeats
+		return x.Price
 	}
 	return 0
 }
 
-func (x *ConcertSession) GetPrice() float64 {
+func (x *TicketType) GetQuota() int32 {
 	if x != nil {
-		return x.Price
+		return x.Quota
 	}
 	return 0
 }
 
-func (x *ConcertSession) GetConcert() *Concert {
+func (x *TicketType) GetRemaining() int32 {
 	if x != nil {
-		return x.Concert
+		return x.Remaining
 	}
-	return nil
+	return 0
 }
 
-// Concert represents a concert
-type Concert struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+// PromoCode is a checkout discount. Zero-valued limits and scopes do not apply.
+type PromoCode struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code                  string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	DiscountType          string                 `protobuf:"bytes,3,opt,name=discount_type,json=discountType,proto3" json:"discount_type,omitempty"`
+	DiscountValue         float64                `protobuf:"fixed64,4,opt,name=discount_value,json=discountValue,proto3" json:"discount_value,omitempty"`
+	ValidFrom             *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidUntil            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	MaxRedemptions        int32                  `protobuf:"varint,7,opt,name=max_redemptions,json=maxRedemptions,proto3" json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser int32                  `protobuf:"varint,8,opt,name=max_redemptions_per_user,json=maxRedemptionsPerUser,proto3" json:"max_redemptions_per_user,omitempty"`
+	ConcertId             int32                  `protobuf:"varint,9,opt,name=concert_id,json=concertId,proto3" json:"concert_id,omitempty"`
+	SessionId             int32                  `protobuf:"varint,10,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// redemptions counts the pending and paid orders that used the code
+	Redemptions   int32                  `protobuf:"varint,11,opt,name=redemptions,proto3" json:"redemptions,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Concert) Reset() {
-	*x = Concert{}
-	mi := &file_proto_tickets_proto_msgTypes[15]
+func (x *PromoCode) Reset() {
+	*x = PromoCode{}
+	mi := &file_proto_tickets_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Concert) String() string {
+func (x *PromoCode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Concert) ProtoMessage() {}
+func (*PromoCode) ProtoMessage() {}
 
-func (x *Concert) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[15]
+func (x *PromoCode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -982,71 +4306,122 @@ func (x *Concert) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Concert.ProtoReflect.Descriptor instead.
-func (*Concert) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use PromoCode.ProtoReflect.Descriptor instead.
+func (*PromoCode) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *Concert) GetId() int32 {
+func (x *PromoCode) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Concert) GetName() string {
+func (x *PromoCode) GetCode() string {
 	if x != nil {
-		return x.Name
+		return x.Code
 	}
 	return ""
 }
 
-func (x *Concert) GetLocation() string {
+func (x *PromoCode) GetDiscountType() string {
 	if x != nil {
-		return x.Location
+		return x.DiscountType
 	}
 	return ""
 }
 
-func (x *Concert) GetDescription() string {
+func (x *PromoCode) GetDiscountValue() float64 {
 	if x != nil {
-		return x.Description
+		return x.DiscountValue
 	}
-	return ""
+	return 0
 }
 
-func (x *Concert) GetCreatedAt() *timestamppb.Timestamp {
+func (x *PromoCode) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *PromoCode) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *PromoCode) GetMaxRedemptions() int32 {
+	if x != nil {
+		return x.MaxRedemptions
+	}
+	return 0
+}
+
+func (x *PromoCode) GetMaxRedemptionsPerUser() int32 {
+	if x != nil {
+		return x.MaxRedemptionsPerUser
+	}
+	return 0
+}
+
+func (x *PromoCode) GetConcertId() int32 {
+	if x != nil {
+		return x.ConcertId
+	}
+	return 0
+}
+
+func (x *PromoCode) GetSessionId() int32 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *PromoCode) GetRedemptions() int32 {
+	if x != nil {
+		return x.Redemptions
+	}
+	return 0
+}
+
+func (x *PromoCode) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return nil
 }
 
-// Ticket represents a ticket
-type Ticket struct {
+// AccountBalance totals a ledger account's entries. balance is debits less credits for asset
+// and contra-revenue accounts, and credits less debits for the others.
+type AccountBalance struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	SessionId     int32                  `protobuf:"varint,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Debits        float64                `protobuf:"fixed64,2,opt,name=debits,proto3" json:"debits,omitempty"`
+	Credits       float64                `protobuf:"fixed64,3,opt,name=credits,proto3" json:"credits,omitempty"`
+	Balance       float64                `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Ticket) Reset() {
-	*x = Ticket{}
-	mi := &file_proto_tickets_proto_msgTypes[16]
+func (x *AccountBalance) Reset() {
+	*x = AccountBalance{}
+	mi := &file_proto_tickets_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Ticket) String() string {
+func (x *AccountBalance) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Ticket) ProtoMessage() {}
+func (*AccountBalance) ProtoMessage() {}
 
-func (x *Ticket) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tickets_proto_msgTypes[16]
+func (x *AccountBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tickets_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1057,41 +4432,57 @@ func (x *Ticket) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
-func (*Ticket) Descriptor() ([]byte, []int) {
-	return file_proto_tickets_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use AccountBalance.ProtoReflect.Descriptor instead.
+func (*AccountBalance) Descriptor() ([]byte, []int) {
+	return file_proto_tickets_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *Ticket) GetId() string {
+func (x *AccountBalance) GetAccount() string {
 	if x != nil {
-		return x.Id
+		return x.Account
 	}
 	return ""
 }
 
-func (x *Ticket) GetSessionId() int32 {
+func (x *AccountBalance) GetDebits() float64 {
 	if x != nil {
-		return x.SessionId
+		return x.Debits
 	}
 	return 0
 }
 
-func (x *Ticket) GetStatus() string {
+func (x *AccountBalance) GetCredits() float64 {
 	if x != nil {
-		return x.Status
+		return x.Credits
 	}
-	return ""
+	return 0
+}
+
+func (x *AccountBalance) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
 }
 
 var File_proto_tickets_proto protoreflect.FileDescriptor
 
 const file_proto_tickets_proto_rawDesc = "" +
 	"\n" +
-	"\x13proto/tickets.proto\x12\atickets\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\x01\n" +
+	"\x13proto/tickets.proto\x12\atickets\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd7\x02\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12,\n" +
 	"\x12concert_session_id\x18\x02 \x01(\x05R\x10concertSessionId\x12*\n" +
-	"\x11number_of_tickets\x18\x03 \x01(\x05R\x0fnumberOfTickets\"\xc3\x01\n" +
+	"\x11number_of_tickets\x18\x03 \x01(\x05R\x0fnumberOfTickets\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12\x19\n" +
+	"\bseat_ids\x18\x05 \x03(\tR\aseatIds\x12.\n" +
+	"\x13allow_split_seating\x18\x06 \x01(\bR\x11allowSplitSeating\x12;\n" +
+	"\fticket_types\x18\a \x03(\v2\x18.tickets.TicketSelectionR\vticketTypes\x12\x1d\n" +
+	"\n" +
+	"promo_code\x18\b \x01(\tR\tpromoCode\"S\n" +
+	"\x0fTicketSelection\x12$\n" +
+	"\x0eticket_type_id\x18\x01 \x01(\x05R\fticketTypeId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xce\x03\n" +
 	"\x13CreateOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x05R\aorderId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
@@ -1100,42 +4491,227 @@ const file_proto_tickets_proto_rawDesc = "" +
 	"\vtotal_price\x18\x04 \x01(\x01R\n" +
 	"totalPrice\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\",\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1a\n" +
+	"\bsubtotal\x18\a \x01(\x01R\bsubtotal\x12\x1a\n" +
+	"\bdiscount\x18\b \x01(\x01R\bdiscount\x12\x1d\n" +
+	"\n" +
+	"promo_code\x18\t \x01(\tR\tpromoCode\x12\x1f\n" +
+	"\vservice_fee\x18\n" +
+	" \x01(\x01R\n" +
+	"serviceFee\x12\x10\n" +
+	"\x03tax\x18\v \x01(\x01R\x03tax\x12\x19\n" +
+	"\btax_rate\x18\f \x01(\x01R\ataxRate\x12)\n" +
+	"\x10tax_jurisdiction\x18\r \x01(\tR\x0ftaxJurisdiction\",\n" +
 	"\x0fGetOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x05R\aorderId\"8\n" +
 	"\x10GetOrderResponse\x12$\n" +
-	"\x05order\x18\x01 \x01(\v2\x0e.tickets.OrderR\x05order\"]\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.tickets.OrderR\x05order\"|\n" +
 	"\x11ListOrdersRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x8e\x01\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\xb6\x01\n" +
 	"\x12ListOrdersResponse\x12&\n" +
 	"\x06orders\x18\x01 \x03(\v2\x0e.tickets.OrderR\x06orders\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"9\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\"0\n" +
+	"\x13ConfirmOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\"<\n" +
+	"\x14ConfirmOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.tickets.OrderR\x05order\"G\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\";\n" +
+	"\x13CancelOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.tickets.OrderR\x05order\"S\n" +
+	"\x0fPayOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\x12%\n" +
+	"\x0epayment_method\x18\x02 \x01(\tR\rpaymentMethod\"d\n" +
+	"\x10PayOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.tickets.OrderR\x05order\x12*\n" +
+	"\apayment\x18\x02 \x01(\v2\x10.tickets.PaymentR\apayment\"h\n" +
+	"\x12RefundOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1f\n" +
+	"\vrefunded_by\x18\x03 \x01(\tR\n" +
+	"refundedBy\"d\n" +
+	"\x13RefundOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.tickets.OrderR\x05order\x12'\n" +
+	"\x06refund\x18\x02 \x01(\v2\x0f.tickets.RefundR\x06refund\"\x89\x01\n" +
+	"\x14RefundTicketsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x05R\aorderId\x12\x1d\n" +
+	"\n" +
+	"ticket_ids\x18\x02 \x03(\tR\tticketIds\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1f\n" +
+	"\vrefunded_by\x18\x04 \x01(\tR\n" +
+	"refundedBy\"f\n" +
+	"\x15RefundTicketsResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.tickets.OrderR\x05order\x12'\n" +
+	"\x06refund\x18\x02 \x01(\v2\x0f.tickets.RefundR\x06refund\"9\n" +
 	"\x18GetConcertSessionRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\x05R\tsessionId\"N\n" +
 	"\x19GetConcertSessionResponse\x121\n" +
-	"\asession\x18\x01 \x01(\v2\x17.tickets.ConcertSessionR\asession\"M\n" +
+	"\asession\x18\x01 \x01(\v2\x17.tickets.ConcertSessionR\asession\"\x9e\x03\n" +
 	"\x1aListConcertSessionsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\xa4\x01\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12B\n" +
+	"\x0fstart_time_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rstartTimeFrom\x12>\n" +
+	"\rstart_time_to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vstartTimeTo\x12\x14\n" +
+	"\x05venue\x18\x05 \x01(\tR\x05venue\x12\x1a\n" +
+	"\blocation\x18\x06 \x01(\tR\blocation\x12\x1b\n" +
+	"\tmin_price\x18\a \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\b \x01(\x01R\bmaxPrice\x12%\n" +
+	"\x0eonly_available\x18\t \x01(\bR\ronlyAvailable\x12\x19\n" +
+	"\border_by\x18\n" +
+	" \x01(\tR\aorderBy\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\"\xcc\x01\n" +
 	"\x1bListConcertSessionsResponse\x123\n" +
 	"\bsessions\x18\x01 \x03(\v2\x17.tickets.ConcertSessionR\bsessions\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"Q\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\"Q\n" +
 	"\x1aGetAvailableTicketsRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\x05R\tsessionId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\"q\n" +
 	"\x1bGetAvailableTicketsResponse\x12)\n" +
 	"\atickets\x18\x01 \x03(\v2\x0f.tickets.TicketR\atickets\x12'\n" +
-	"\x0ftotal_available\x18\x02 \x01(\x05R\x0etotalAvailable\"\xb5\x01\n" +
+	"\x0ftotal_available\x18\x02 \x01(\x05R\x0etotalAvailable\"h\n" +
+	"\x14CreateConcertRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"C\n" +
+	"\x15CreateConcertResponse\x12*\n" +
+	"\aconcert\x18\x01 \x01(\v2\x10.tickets.ConcertR\aconcert\"\x87\x01\n" +
+	"\x14UpdateConcertRequest\x12\x1d\n" +
+	"\n" +
+	"concert_id\x18\x01 \x01(\x05R\tconcertId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"C\n" +
+	"\x15UpdateConcertResponse\x12*\n" +
+	"\aconcert\x18\x01 \x01(\v2\x10.tickets.ConcertR\aconcert\"5\n" +
+	"\x14DeleteConcertRequest\x12\x1d\n" +
+	"\n" +
+	"concert_id\x18\x01 \x01(\x05R\tconcertId\"\x17\n" +
+	"\x15DeleteConcertResponse\"\xb7\x02\n" +
+	"\x1bCreateConcertSessionRequest\x12\x1d\n" +
+	"\n" +
+	"concert_id\x18\x01 \x01(\x05R\tconcertId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05venue\x18\x04 \x01(\tR\x05venue\x12&\n" +
+	"\x0fnumber_of_seats\x18\x05 \x01(\x05R\rnumberOfSeats\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x01R\x05price\x123\n" +
+	"\bsections\x18\a \x03(\v2\x17.tickets.SeatingSectionR\bsections\"Q\n" +
+	"\x1cCreateConcertSessionResponse\x121\n" +
+	"\asession\x18\x01 \x01(\v2\x17.tickets.ConcertSessionR\asession\"\xda\x01\n" +
+	"\x1bUpdateConcertSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\x05R\tsessionId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05venue\x18\x04 \x01(\tR\x05venue\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\"Q\n" +
+	"\x1cUpdateConcertSessionResponse\x121\n" +
+	"\asession\x18\x01 \x01(\v2\x17.tickets.ConcertSessionR\asession\"e\n" +
+	"\x1cUpdateSessionCapacityRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\x05R\tsessionId\x12&\n" +
+	"\x0fnumber_of_seats\x18\x02 \x01(\x05R\rnumberOfSeats\"R\n" +
+	"\x1dUpdateSessionCapacityResponse\x121\n" +
+	"\asession\x18\x01 \x01(\v2\x17.tickets.ConcertSessionR\asession\"<\n" +
+	"\x1bDeleteConcertSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\x05R\tsessionId\"\x1e\n" +
+	"\x1cDeleteConcertSessionResponse\"L\n" +
+	"\x11GetSeatMapRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\x05R\tsessionId\x12\x18\n" +
+	"\asection\x18\x02 \x01(\tR\asection\"x\n" +
+	"\x12GetSeatMapResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\x05R\tsessionId\x12%\n" +
+	"\x05seats\x18\x02 \x03(\v2\x0f.tickets.TicketR\x05seats\x12\x1c\n" +
+	"\tavailable\x18\x03 \x01(\x05R\tavailable\"7\n" +
+	"\x16ListTicketTypesRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\x05R\tsessionId\"Q\n" +
+	"\x17ListTicketTypesResponse\x126\n" +
+	"\fticket_types\x18\x01 \x03(\v2\x13.tickets.TicketTypeR\vticketTypes\"\x9a\x01\n" +
+	"\x17CreateTicketTypeRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\x05R\tsessionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x14\n" +
+	"\x05quota\x18\x05 \x01(\x05R\x05quota\"P\n" +
+	"\x18CreateTicketTypeResponse\x124\n" +
+	"\vticket_type\x18\x01 \x01(\v2\x13.tickets.TicketTypeR\n" +
+	"ticketType\"\xa1\x01\n" +
+	"\x17UpdateTicketTypeRequest\x12$\n" +
+	"\x0eticket_type_id\x18\x01 \x01(\x05R\fticketTypeId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x14\n" +
+	"\x05quota\x18\x05 \x01(\x05R\x05quota\"P\n" +
+	"\x18UpdateTicketTypeResponse\x124\n" +
+	"\vticket_type\x18\x01 \x01(\v2\x13.tickets.TicketTypeR\n" +
+	"ticketType\"?\n" +
+	"\x17DeleteTicketTypeRequest\x12$\n" +
+	"\x0eticket_type_id\x18\x01 \x01(\x05R\fticketTypeId\"\x1a\n" +
+	"\x18DeleteTicketTypeResponse\"\x90\x03\n" +
+	"\x16CreatePromoCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12#\n" +
+	"\rdiscount_type\x18\x02 \x01(\tR\fdiscountType\x12%\n" +
+	"\x0ediscount_value\x18\x03 \x01(\x01R\rdiscountValue\x129\n" +
+	"\n" +
+	"valid_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x12;\n" +
+	"\vvalid_until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12'\n" +
+	"\x0fmax_redemptions\x18\x06 \x01(\x05R\x0emaxRedemptions\x127\n" +
+	"\x18max_redemptions_per_user\x18\a \x01(\x05R\x15maxRedemptionsPerUser\x12\x1d\n" +
+	"\n" +
+	"concert_id\x18\b \x01(\x05R\tconcertId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\t \x01(\x05R\tsessionId\"L\n" +
+	"\x17CreatePromoCodeResponse\x121\n" +
+	"\n" +
+	"promo_code\x18\x01 \x01(\v2\x12.tickets.PromoCodeR\tpromoCode\")\n" +
+	"\x13GetPromoCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"I\n" +
+	"\x14GetPromoCodeResponse\x121\n" +
+	"\n" +
+	"promo_code\x18\x01 \x01(\v2\x12.tickets.PromoCodeR\tpromoCode\"<\n" +
+	"\x16DeletePromoCodeRequest\x12\"\n" +
+	"\rpromo_code_id\x18\x01 \x01(\x05R\vpromoCodeId\"\x19\n" +
+	"\x17DeletePromoCodeResponse\"4\n" +
+	"\x18GetLedgerBalancesRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\"P\n" +
+	"\x19GetLedgerBalancesResponse\x123\n" +
+	"\bbalances\x18\x01 \x03(\v2\x17.tickets.AccountBalanceR\bbalances\"\x14\n" +
+	"\x12CheckLedgerRequest\"\xb7\x01\n" +
+	"\x13CheckLedgerResponse\x12\x1a\n" +
+	"\bbalanced\x18\x01 \x01(\bR\bbalanced\x12!\n" +
+	"\ftotal_debits\x18\x02 \x01(\x01R\vtotalDebits\x12#\n" +
+	"\rtotal_credits\x18\x03 \x01(\x01R\ftotalCredits\x12<\n" +
+	"\x1aunbalanced_transaction_ids\x18\x04 \x03(\x05R\x18unbalancedTransactionIds\"\\\n" +
+	"\x0eSeatingSection\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04rows\x18\x02 \x01(\x05R\x04rows\x12\"\n" +
+	"\rseats_per_row\x18\x03 \x01(\x05R\vseatsPerRow\"\xf2\x04\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
@@ -1143,12 +4719,57 @@ const file_proto_tickets_proto_rawDesc = "" +
 	"totalPrice\x129\n" +
 	"\n" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12(\n" +
-	"\x05items\x18\x05 \x03(\v2\x12.tickets.OrderItemR\x05items\"w\n" +
+	"\x05items\x18\x05 \x03(\v2\x12.tickets.OrderItemR\x05items\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\x05R\x06userId\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12=\n" +
+	"\fcancelled_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\x12/\n" +
+	"\x13cancellation_reason\x18\t \x01(\tR\x12cancellationReason\x12\x1a\n" +
+	"\bsubtotal\x18\n" +
+	" \x01(\x01R\bsubtotal\x12\x1a\n" +
+	"\bdiscount\x18\v \x01(\x01R\bdiscount\x12\x1d\n" +
+	"\n" +
+	"promo_code\x18\f \x01(\tR\tpromoCode\x12'\n" +
+	"\x0frefunded_amount\x18\r \x01(\x01R\x0erefundedAmount\x12\x1f\n" +
+	"\vservice_fee\x18\x0e \x01(\x01R\n" +
+	"serviceFee\x12\x10\n" +
+	"\x03tax\x18\x0f \x01(\x01R\x03tax\x12\x19\n" +
+	"\btax_rate\x18\x10 \x01(\x01R\ataxRate\x12)\n" +
+	"\x10tax_jurisdiction\x18\x11 \x01(\tR\x0ftaxJurisdiction\"\xb6\x02\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x05R\aorderId\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12-\n" +
+	"\x12provider_reference\x18\x04 \x01(\tR\x11providerReference\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12!\n" +
+	"\ffailure_code\x18\a \x01(\tR\vfailureCode\x12'\n" +
+	"\x0ffailure_message\x18\b \x01(\tR\x0efailureMessage\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xbe\x02\n" +
+	"\x06Refund\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x05R\aorderId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x03 \x01(\x05R\tpaymentId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1f\n" +
+	"\vrefunded_by\x18\x06 \x01(\tR\n" +
+	"refundedBy\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12'\n" +
+	"\x0ffailure_message\x18\b \x01(\tR\x0efailureMessage\x12\x1d\n" +
+	"\n" +
+	"ticket_ids\x18\t \x03(\tR\tticketIds\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xba\x01\n" +
 	"\tOrderItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
 	"\tticket_id\x18\x02 \x01(\tR\bticketId\x12\x14\n" +
 	"\x05price\x18\x03 \x01(\x01R\x05price\x12'\n" +
-	"\x06ticket\x18\x04 \x01(\v2\x0f.tickets.TicketR\x06ticket\"\xb1\x02\n" +
+	"\x06ticket\x18\x04 \x01(\v2\x0f.tickets.TicketR\x06ticket\x12$\n" +
+	"\x0eticket_type_id\x18\x05 \x01(\x05R\fticketTypeId\x12\x1b\n" +
+	"\trefund_id\x18\x06 \x01(\x05R\brefundId\"\xda\x02\n" +
 	"\x0eConcertSession\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
 	"\n" +
@@ -1159,27 +4780,93 @@ const file_proto_tickets_proto_rawDesc = "" +
 	"\x05venue\x18\x05 \x01(\tR\x05venue\x12&\n" +
 	"\x0fnumber_of_seats\x18\x06 \x01(\x05R\rnumberOfSeats\x12\x14\n" +
 	"\x05price\x18\a \x01(\x01R\x05price\x12*\n" +
-	"\aconcert\x18\b \x01(\v2\x10.tickets.ConcertR\aconcert\"\xa6\x01\n" +
+	"\aconcert\x18\b \x01(\v2\x10.tickets.ConcertR\aconcert\x12'\n" +
+	"\x0fremaining_seats\x18\t \x01(\x05R\x0eremainingSeats\"\xa6\x01\n" +
 	"\aConcert\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
 	"\blocation\x18\x03 \x01(\tR\blocation\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"O\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xbd\x01\n" +
 	"\x06Ticket\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x02 \x01(\x05R\tsessionId\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status2\x82\x04\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\asection\x18\x04 \x01(\tR\asection\x12\x10\n" +
+	"\x03row\x18\x05 \x01(\tR\x03row\x12\x1f\n" +
+	"\vseat_number\x18\x06 \x01(\x05R\n" +
+	"seatNumber\x12\x1f\n" +
+	"\vticket_type\x18\a \x01(\tR\n" +
+	"ticketType\"\xbb\x01\n" +
+	"\n" +
+	"TicketType\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\x05R\tsessionId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x14\n" +
+	"\x05quota\x18\x06 \x01(\x05R\x05quota\x12\x1c\n" +
+	"\tremaining\x18\a \x01(\x05R\tremaining\"\xf0\x03\n" +
+	"\tPromoCode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12#\n" +
+	"\rdiscount_type\x18\x03 \x01(\tR\fdiscountType\x12%\n" +
+	"\x0ediscount_value\x18\x04 \x01(\x01R\rdiscountValue\x129\n" +
+	"\n" +
+	"valid_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x12;\n" +
+	"\vvalid_until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\x12'\n" +
+	"\x0fmax_redemptions\x18\a \x01(\x05R\x0emaxRedemptions\x127\n" +
+	"\x18max_redemptions_per_user\x18\b \x01(\x05R\x15maxRedemptionsPerUser\x12\x1d\n" +
+	"\n" +
+	"concert_id\x18\t \x01(\x05R\tconcertId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\n" +
+	" \x01(\x05R\tsessionId\x12 \n" +
+	"\vredemptions\x18\v \x01(\x05R\vredemptions\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"v\n" +
+	"\x0eAccountBalance\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x16\n" +
+	"\x06debits\x18\x02 \x01(\x01R\x06debits\x12\x18\n" +
+	"\acredits\x18\x03 \x01(\x01R\acredits\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x01R\abalance2\x91\b\n" +
 	"\x0eTicketsService\x12H\n" +
 	"\vCreateOrder\x12\x1b.tickets.CreateOrderRequest\x1a\x1c.tickets.CreateOrderResponse\x12?\n" +
 	"\bGetOrder\x12\x18.tickets.GetOrderRequest\x1a\x19.tickets.GetOrderResponse\x12E\n" +
 	"\n" +
-	"ListOrders\x12\x1a.tickets.ListOrdersRequest\x1a\x1b.tickets.ListOrdersResponse\x12Z\n" +
+	"ListOrders\x12\x1a.tickets.ListOrdersRequest\x1a\x1b.tickets.ListOrdersResponse\x12K\n" +
+	"\fConfirmOrder\x12\x1c.tickets.ConfirmOrderRequest\x1a\x1d.tickets.ConfirmOrderResponse\x12H\n" +
+	"\vCancelOrder\x12\x1b.tickets.CancelOrderRequest\x1a\x1c.tickets.CancelOrderResponse\x12?\n" +
+	"\bPayOrder\x12\x18.tickets.PayOrderRequest\x1a\x19.tickets.PayOrderResponse\x12H\n" +
+	"\vRefundOrder\x12\x1b.tickets.RefundOrderRequest\x1a\x1c.tickets.RefundOrderResponse\x12N\n" +
+	"\rRefundTickets\x12\x1d.tickets.RefundTicketsRequest\x1a\x1e.tickets.RefundTicketsResponse\x12Z\n" +
 	"\x11GetConcertSession\x12!.tickets.GetConcertSessionRequest\x1a\".tickets.GetConcertSessionResponse\x12`\n" +
 	"\x13ListConcertSessions\x12#.tickets.ListConcertSessionsRequest\x1a$.tickets.ListConcertSessionsResponse\x12`\n" +
-	"\x13GetAvailableTickets\x12#.tickets.GetAvailableTicketsRequest\x1a$.tickets.GetAvailableTicketsResponseB\rZ\vtickets/apib\x06proto3"
+	"\x13GetAvailableTickets\x12#.tickets.GetAvailableTicketsRequest\x1a$.tickets.GetAvailableTicketsResponse\x12E\n" +
+	"\n" +
+	"GetSeatMap\x12\x1a.tickets.GetSeatMapRequest\x1a\x1b.tickets.GetSeatMapResponse\x12T\n" +
+	"\x0fListTicketTypes\x12\x1f.tickets.ListTicketTypesRequest\x1a .tickets.ListTicketTypesResponse2\xbf\n" +
+	"\n" +
+	"\fAdminService\x12N\n" +
+	"\rCreateConcert\x12\x1d.tickets.CreateConcertRequest\x1a\x1e.tickets.CreateConcertResponse\x12N\n" +
+	"\rUpdateConcert\x12\x1d.tickets.UpdateConcertRequest\x1a\x1e.tickets.UpdateConcertResponse\x12N\n" +
+	"\rDeleteConcert\x12\x1d.tickets.DeleteConcertRequest\x1a\x1e.tickets.DeleteConcertResponse\x12c\n" +
+	"\x14CreateConcertSession\x12$.tickets.CreateConcertSessionRequest\x1a%.tickets.CreateConcertSessionResponse\x12c\n" +
+	"\x14UpdateConcertSession\x12$.tickets.UpdateConcertSessionRequest\x1a%.tickets.UpdateConcertSessionResponse\x12f\n" +
+	"\x15UpdateSessionCapacity\x12%.tickets.UpdateSessionCapacityRequest\x1a&.tickets.UpdateSessionCapacityResponse\x12c\n" +
+	"\x14DeleteConcertSession\x12$.tickets.DeleteConcertSessionRequest\x1a%.tickets.DeleteConcertSessionResponse\x12W\n" +
+	"\x10CreateTicketType\x12 .tickets.CreateTicketTypeRequest\x1a!.tickets.CreateTicketTypeResponse\x12W\n" +
+	"\x10UpdateTicketType\x12 .tickets.UpdateTicketTypeRequest\x1a!.tickets.UpdateTicketTypeResponse\x12W\n" +
+	"\x10DeleteTicketType\x12 .tickets.DeleteTicketTypeRequest\x1a!.tickets.DeleteTicketTypeResponse\x12T\n" +
+	"\x0fCreatePromoCode\x12\x1f.tickets.CreatePromoCodeRequest\x1a .tickets.CreatePromoCodeResponse\x12K\n" +
+	"\fGetPromoCode\x12\x1c.tickets.GetPromoCodeRequest\x1a\x1d.tickets.GetPromoCodeResponse\x12T\n" +
+	"\x0fDeletePromoCode\x12\x1f.tickets.DeletePromoCodeRequest\x1a .tickets.DeletePromoCodeResponse\x12Z\n" +
+	"\x11GetLedgerBalances\x12!.tickets.GetLedgerBalancesRequest\x1a\".tickets.GetLedgerBalancesResponse\x12H\n" +
+	"\vCheckLedger\x12\x1b.tickets.CheckLedgerRequest\x1a\x1c.tickets.CheckLedgerResponseB\rZ\vtickets/apib\x06proto3"
 
 var (
 	file_proto_tickets_proto_rawDescOnce sync.Once
@@ -1193,58 +4880,191 @@ func file_proto_tickets_proto_rawDescGZIP() []byte {
 	return file_proto_tickets_proto_rawDescData
 }
 
-var file_proto_tickets_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_tickets_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_proto_tickets_proto_goTypes = []any{
-	(*CreateOrderRequest)(nil),          // 0: tickets.CreateOrderRequest
-	(*CreateOrderResponse)(nil),         // 1: tickets.CreateOrderResponse
-	(*GetOrderRequest)(nil),             // 2: tickets.GetOrderRequest
-	(*GetOrderResponse)(nil),            // 3: tickets.GetOrderResponse
-	(*ListOrdersRequest)(nil),           // 4: tickets.ListOrdersRequest
-	(*ListOrdersResponse)(nil),          // 5: tickets.ListOrdersResponse
-	(*GetConcertSessionRequest)(nil),    // 6: tickets.GetConcertSessionRequest
-	(*GetConcertSessionResponse)(nil),   // 7: tickets.GetConcertSessionResponse
-	(*ListConcertSessionsRequest)(nil),  // 8: tickets.ListConcertSessionsRequest
-	(*ListConcertSessionsResponse)(nil), // 9: tickets.ListConcertSessionsResponse
-	(*GetAvailableTicketsRequest)(nil),  // 10: tickets.GetAvailableTicketsRequest
-	(*GetAvailableTicketsResponse)(nil), // 11: tickets.GetAvailableTicketsResponse
-	(*Order)(nil),                       // 12: tickets.Order
-	(*OrderItem)(nil),                   // 13: tickets.OrderItem
-	(*ConcertSession)(nil),              // 14: tickets.ConcertSession
-	(*Concert)(nil),                     // 15: tickets.Concert
-	(*Ticket)(nil),                      // 16: tickets.Ticket
-	(*timestamppb.Timestamp)(nil),       // 17: google.protobuf.Timestamp
+	(*CreateOrderRequest)(nil),            // 0: tickets.CreateOrderRequest
+	(*TicketSelection)(nil),               // 1: tickets.TicketSelection
+	(*CreateOrderResponse)(nil),           // 2: tickets.CreateOrderResponse
+	(*GetOrderRequest)(nil),               // 3: tickets.GetOrderRequest
+	(*GetOrderResponse)(nil),              // 4: tickets.GetOrderResponse
+	(*ListOrdersRequest)(nil),             // 5: tickets.ListOrdersRequest
+	(*ListOrdersResponse)(nil),            // 6: tickets.ListOrdersResponse
+	(*ConfirmOrderRequest)(nil),           // 7: tickets.ConfirmOrderRequest
+	(*ConfirmOrderResponse)(nil),          // 8: tickets.ConfirmOrderResponse
+	(*CancelOrderRequest)(nil),            // 9: tickets.CancelOrderRequest
+	(*CancelOrderResponse)(nil),           // 10: tickets.CancelOrderResponse
+	(*PayOrderRequest)(nil),               // 11: tickets.PayOrderRequest
+	(*PayOrderResponse)(nil),              // 12: tickets.PayOrderResponse
+	(*RefundOrderRequest)(nil),            // 13: tickets.RefundOrderRequest
+	(*RefundOrderResponse)(nil),           // 14: tickets.RefundOrderResponse
+	(*RefundTicketsRequest)(nil),          // 15: tickets.RefundTicketsRequest
+	(*RefundTicketsResponse)(nil),         // 16: tickets.RefundTicketsResponse
+	(*GetConcertSessionRequest)(nil),      // 17: tickets.GetConcertSessionRequest
+	(*GetConcertSessionResponse)(nil),     // 18: tickets.GetConcertSessionResponse
+	(*ListConcertSessionsRequest)(nil),    // 19: tickets.ListConcertSessionsRequest
+	(*ListConcertSessionsResponse)(nil),   // 20: tickets.ListConcertSessionsResponse
+	(*GetAvailableTicketsRequest)(nil),    // 21: tickets.GetAvailableTicketsRequest
+	(*GetAvailableTicketsResponse)(nil),   // 22: tickets.GetAvailableTicketsResponse
+	(*CreateConcertRequest)(nil),          // 23: tickets.CreateConcertRequest
+	(*CreateConcertResponse)(nil),         // 24: tickets.CreateConcertResponse
+	(*UpdateConcertRequest)(nil),          // 25: tickets.UpdateConcertRequest
+	(*UpdateConcertResponse)(nil),         // 26: tickets.UpdateConcertResponse
+	(*DeleteConcertRequest)(nil),          // 27: tickets.DeleteConcertRequest
+	(*DeleteConcertResponse)(nil),         // 28: tickets.DeleteConcertResponse
+	(*CreateConcertSessionRequest)(nil),   // 29: tickets.CreateConcertSessionRequest
+	(*CreateConcertSessionResponse)(nil),  // 30: tickets.CreateConcertSessionResponse
+	(*UpdateConcertSessionRequest)(nil),   // 31: tickets.UpdateConcertSessionRequest
+	(*UpdateConcertSessionResponse)(nil),  // 32: tickets.UpdateConcertSessionResponse
+	(*UpdateSessionCapacityRequest)(nil),  // 33: tickets.UpdateSessionCapacityRequest
+	(*UpdateSessionCapacityResponse)(nil), // 34: tickets.UpdateSessionCapacityResponse
+	(*DeleteConcertSessionRequest)(nil),   // 35: tickets.DeleteConcertSessionRequest
+	(*DeleteConcertSessionResponse)(nil),  // 36: tickets.DeleteConcertSessionResponse
+	(*GetSeatMapRequest)(nil),             // 37: tickets.GetSeatMapRequest
+	(*GetSeatMapResponse)(nil),            // 38: tickets.GetSeatMapResponse
+	(*ListTicketTypesRequest)(nil),        // 39: tickets.ListTicketTypesRequest
+	(*ListTicketTypesResponse)(nil),       // 40: tickets.ListTicketTypesResponse
+	(*CreateTicketTypeRequest)(nil),       // 41: tickets.CreateTicketTypeRequest
+	(*CreateTicketTypeResponse)(nil),      // 42: tickets.CreateTicketTypeResponse
+	(*UpdateTicketTypeRequest)(nil),       // 43: tickets.UpdateTicketTypeRequest
+	(*UpdateTicketTypeResponse)(nil),      // 44: tickets.UpdateTicketTypeResponse
+	(*DeleteTicketTypeRequest)(nil),       // 45: tickets.DeleteTicketTypeRequest
+	(*DeleteTicketTypeResponse)(nil),      // 46: tickets.DeleteTicketTypeResponse
+	(*CreatePromoCodeRequest)(nil),        // 47: tickets.CreatePromoCodeRequest
+	(*CreatePromoCodeResponse)(nil),       // 48: tickets.CreatePromoCodeResponse
+	(*GetPromoCodeRequest)(nil),           // 49: tickets.GetPromoCodeRequest
+	(*GetPromoCodeResponse)(nil),          // 50: tickets.GetPromoCodeResponse
+	(*DeletePromoCodeRequest)(nil),        // 51: tickets.DeletePromoCodeRequest
+	(*DeletePromoCodeResponse)(nil),       // 52: tickets.DeletePromoCodeResponse
+	(*GetLedgerBalancesRequest)(nil),      // 53: tickets.GetLedgerBalancesRequest
+	(*GetLedgerBalancesResponse)(nil),     // 54: tickets.GetLedgerBalancesResponse
+	(*CheckLedgerRequest)(nil),            // 55: tickets.CheckLedgerRequest
+	(*CheckLedgerResponse)(nil),           // 56: tickets.CheckLedgerResponse
+	(*SeatingSection)(nil),                // 57: tickets.SeatingSection
+	(*Order)(nil),                         // 58: tickets.Order
+	(*Payment)(nil),                       // 59: tickets.Payment
+	(*Refund)(nil),                        // 60: tickets.Refund
+	(*OrderItem)(nil),                     // 61: tickets.OrderItem
+	(*ConcertSession)(nil),                // 62: tickets.ConcertSession
+	(*Concert)(nil),                       // 63: tickets.Concert
+	(*Ticket)(nil),                        // 64: tickets.Ticket
+	(*TicketType)(nil),                    // 65: tickets.TicketType
+	(*PromoCode)(nil),                     // 66: tickets.PromoCode
+	(*AccountBalance)(nil),                // 67: tickets.AccountBalance
+	(*timestamppb.Timestamp)(nil),         // 68: google.protobuf.Timestamp
 }
 var file_proto_tickets_proto_depIdxs = []int32{
-	17, // 0: tickets.CreateOrderResponse.created_at:type_name -> google.protobuf.Timestamp
-	12, // 1: tickets.GetOrderResponse.order:type_name -> tickets.Order
-	12, // 2: tickets.ListOrdersResponse.orders:type_name -> tickets.Order
-	14, // 3: tickets.GetConcertSessionResponse.session:type_name -> tickets.ConcertSession
-	14, // 4: tickets.ListConcertSessionsResponse.sessions:type_name -> tickets.ConcertSession
-	16, // 5: tickets.GetAvailableTicketsResponse.tickets:type_name -> tickets.Ticket
-	17, // 6: tickets.Order.created_at:type_name -> google.protobuf.Timestamp
-	13, // 7: tickets.Order.items:type_name -> tickets.OrderItem
-	16, // 8: tickets.OrderItem.ticket:type_name -> tickets.Ticket
-	17, // 9: tickets.ConcertSession.start_time:type_name -> google.protobuf.Timestamp
-	17, // 10: tickets.ConcertSession.end_time:type_name -> google.protobuf.Timestamp
-	15, // 11: tickets.ConcertSession.concert:type_name -> tickets.Concert
-	17, // 12: tickets.Concert.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 13: tickets.TicketsService.CreateOrder:input_type -> tickets.CreateOrderRequest
-	2,  // 14: tickets.TicketsService.GetOrder:input_type -> tickets.GetOrderRequest
-	4,  // 15: tickets.TicketsService.ListOrders:input_type -> tickets.ListOrdersRequest
-	6,  // 16: tickets.TicketsService.GetConcertSession:input_type -> tickets.GetConcertSessionRequest
-	8,  // 17: tickets.TicketsService.ListConcertSessions:input_type -> tickets.ListConcertSessionsRequest
-	10, // 18: tickets.TicketsService.GetAvailableTickets:input_type -> tickets.GetAvailableTicketsRequest
-	1,  // 19: tickets.TicketsService.CreateOrder:output_type -> tickets.CreateOrderResponse
-	3,  // 20: tickets.TicketsService.GetOrder:output_type -> tickets.GetOrderResponse
-	5,  // 21: tickets.TicketsService.ListOrders:output_type -> tickets.ListOrdersResponse
-	7,  // 22: tickets.TicketsService.GetConcertSession:output_type -> tickets.GetConcertSessionResponse
-	9,  // 23: tickets.TicketsService.ListConcertSessions:output_type -> tickets.ListConcertSessionsResponse
-	11, // 24: tickets.TicketsService.GetAvailableTickets:output_type -> tickets.GetAvailableTicketsResponse
-	19, // [19:25] is the sub-list for method output_type
-	13, // [13:19] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	1,  // 0: tickets.CreateOrderRequest.ticket_types:type_name -> tickets.TicketSelection
+	68, // 1: tickets.CreateOrderResponse.created_at:type_name -> google.protobuf.Timestamp
+	68, // 2: tickets.CreateOrderResponse.expires_at:type_name -> google.protobuf.Timestamp
+	58, // 3: tickets.GetOrderResponse.order:type_name -> tickets.Order
+	58, // 4: tickets.ListOrdersResponse.orders:type_name -> tickets.Order
+	58, // 5: tickets.ConfirmOrderResponse.order:type_name -> tickets.Order
+	58, // 6: tickets.CancelOrderResponse.order:type_name -> tickets.Order
+	58, // 7: tickets.PayOrderResponse.order:type_name -> tickets.Order
+	59, // 8: tickets.PayOrderResponse.payment:type_name -> tickets.Payment
+	58, // 9: tickets.RefundOrderResponse.order:type_name -> tickets.Order
+	60, // 10: tickets.RefundOrderResponse.refund:type_name -> tickets.Refund
+	58, // 11: tickets.RefundTicketsResponse.order:type_name -> tickets.Order
+	60, // 12: tickets.RefundTicketsResponse.refund:type_name -> tickets.Refund
+	62, // 13: tickets.GetConcertSessionResponse.session:type_name -> tickets.ConcertSession
+	68, // 14: tickets.ListConcertSessionsRequest.start_time_from:type_name -> google.protobuf.Timestamp
+	68, // 15: tickets.ListConcertSessionsRequest.start_time_to:type_name -> google.protobuf.Timestamp
+	62, // 16: tickets.ListConcertSessionsResponse.sessions:type_name -> tickets.ConcertSession
+	64, // 17: tickets.GetAvailableTicketsResponse.tickets:type_name -> tickets.Ticket
+	63, // 18: tickets.CreateConcertResponse.concert:type_name -> tickets.Concert
+	63, // 19: tickets.UpdateConcertResponse.concert:type_name -> tickets.Concert
+	68, // 20: tickets.CreateConcertSessionRequest.start_time:type_name -> google.protobuf.Timestamp
+	68, // 21: tickets.CreateConcertSessionRequest.end_time:type_name -> google.protobuf.Timestamp
+	57, // 22: tickets.CreateConcertSessionRequest.sections:type_name -> tickets.SeatingSection
+	62, // 23: tickets.CreateConcertSessionResponse.session:type_name -> tickets.ConcertSession
+	68, // 24: tickets.UpdateConcertSessionRequest.start_time:type_name -> google.protobuf.Timestamp
+	68, // 25: tickets.UpdateConcertSessionRequest.end_time:type_name -> google.protobuf.Timestamp
+	62, // 26: tickets.UpdateConcertSessionResponse.session:type_name -> tickets.ConcertSession
+	62, // 27: tickets.UpdateSessionCapacityResponse.session:type_name -> tickets.ConcertSession
+	64, // 28: tickets.GetSeatMapResponse.seats:type_name -> tickets.Ticket
+	65, // 29: tickets.ListTicketTypesResponse.ticket_types:type_name -> tickets.TicketType
+	65, // 30: tickets.CreateTicketTypeResponse.ticket_type:type_name -> tickets.TicketType
+	65, // 31: tickets.UpdateTicketTypeResponse.ticket_type:type_name -> tickets.TicketType
+	68, // 32: tickets.CreatePromoCodeRequest.valid_from:type_name -> google.protobuf.Timestamp
+	68, // 33: tickets.CreatePromoCodeRequest.valid_until:type_name -> google.protobuf.Timestamp
+	66, // 34: tickets.CreatePromoCodeResponse.promo_code:type_name -> tickets.PromoCode
+	66, // 35: tickets.GetPromoCodeResponse.promo_code:type_name -> tickets.PromoCode
+	67, // 36: tickets.GetLedgerBalancesResponse.balances:type_name -> tickets.AccountBalance
+	68, // 37: tickets.Order.created_at:type_name -> google.protobuf.Timestamp
+	61, // 38: tickets.Order.items:type_name -> tickets.OrderItem
+	68, // 39: tickets.Order.expires_at:type_name -> google.protobuf.Timestamp
+	68, // 40: tickets.Order.cancelled_at:type_name -> google.protobuf.Timestamp
+	68, // 41: tickets.Payment.created_at:type_name -> google.protobuf.Timestamp
+	68, // 42: tickets.Refund.created_at:type_name -> google.protobuf.Timestamp
+	64, // 43: tickets.OrderItem.ticket:type_name -> tickets.Ticket
+	68, // 44: tickets.ConcertSession.start_time:type_name -> google.protobuf.Timestamp
+	68, // 45: tickets.ConcertSession.end_time:type_name -> google.protobuf.Timestamp
+	63, // 46: tickets.ConcertSession.concert:type_name -> tickets.Concert
+	68, // 47: tickets.Concert.created_at:type_name -> google.protobuf.Timestamp
+	68, // 48: tickets.PromoCode.valid_from:type_name -> google.protobuf.Timestamp
+	68, // 49: tickets.PromoCode.valid_until:type_name -> google.protobuf.Timestamp
+	68, // 50: tickets.PromoCode.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 51: tickets.TicketsService.CreateOrder:input_type -> tickets.CreateOrderRequest
+	3,  // 52: tickets.TicketsService.GetOrder:input_type -> tickets.GetOrderRequest
+	5,  // 53: tickets.TicketsService.ListOrders:input_type -> tickets.ListOrdersRequest
+	7,  // 54: tickets.TicketsService.ConfirmOrder:input_type -> tickets.ConfirmOrderRequest
+	9,  // 55: tickets.TicketsService.CancelOrder:input_type -> tickets.CancelOrderRequest
+	11, // 56: tickets.TicketsService.PayOrder:input_type -> tickets.PayOrderRequest
+	13, // 57: tickets.TicketsService.RefundOrder:input_type -> tickets.RefundOrderRequest
+	15, // 58: tickets.TicketsService.RefundTickets:input_type -> tickets.RefundTicketsRequest
+	17, // 59: tickets.TicketsService.GetConcertSession:input_type -> tickets.GetConcertSessionRequest
+	19, // 60: tickets.TicketsService.ListConcertSessions:input_type -> tickets.ListConcertSessionsRequest
+	21, // 61: tickets.TicketsService.GetAvailableTickets:input_type -> tickets.GetAvailableTicketsRequest
+	37, // 62: tickets.TicketsService.GetSeatMap:input_type -> tickets.GetSeatMapRequest
+	39, // 63: tickets.TicketsService.ListTicketTypes:input_type -> tickets.ListTicketTypesRequest
+	23, // 64: tickets.AdminService.CreateConcert:input_type -> tickets.CreateConcertRequest
+	25, // 65: tickets.AdminService.UpdateConcert:input_type -> tickets.UpdateConcertRequest
+	27, // 66: tickets.AdminService.DeleteConcert:input_type -> tickets.DeleteConcertRequest
+	29, // 67: tickets.AdminService.CreateConcertSession:input_type -> tickets.CreateConcertSessionRequest
+	31, // 68: tickets.AdminService.UpdateConcertSession:input_type -> tickets.UpdateConcertSessionRequest
+	33, // 69: tickets.AdminService.UpdateSessionCapacity:input_type -> tickets.UpdateSessionCapacityRequest
+	35, // 70: tickets.AdminService.DeleteConcertSession:input_type -> tickets.DeleteConcertSessionRequest
+	41, // 71: tickets.AdminService.CreateTicketType:input_type -> tickets.CreateTicketTypeRequest
+	43, // 72: tickets.AdminService.UpdateTicketType:input_type -> tickets.UpdateTicketTypeRequest
+	45, // 73: tickets.AdminService.DeleteTicketType:input_type -> tickets.DeleteTicketTypeRequest
+	47, // 74: tickets.AdminService.CreatePromoCode:input_type -> tickets.CreatePromoCodeRequest
+	49, // 75: tickets.AdminService.GetPromoCode:input_type -> tickets.GetPromoCodeRequest
+	51, // 76: tickets.AdminService.DeletePromoCode:input_type -> tickets.DeletePromoCodeRequest
+	53, // 77: tickets.AdminService.GetLedgerBalances:input_type -> tickets.GetLedgerBalancesRequest
+	55, // 78: tickets.AdminService.CheckLedger:input_type -> tickets.CheckLedgerRequest
+	2,  // 79: tickets.TicketsService.CreateOrder:output_type -> tickets.CreateOrderResponse
+	4,  // 80: tickets.TicketsService.GetOrder:output_type -> tickets.GetOrderResponse
+	6,  // 81: tickets.TicketsService.ListOrders:output_type -> tickets.ListOrdersResponse
+	8,  // 82: tickets.TicketsService.ConfirmOrder:output_type -> tickets.ConfirmOrderResponse
+	10, // 83: tickets.TicketsService.CancelOrder:output_type -> tickets.CancelOrderResponse
+	12, // 84: tickets.TicketsService.PayOrder:output_type -> tickets.PayOrderResponse
+	14, // 85: tickets.TicketsService.RefundOrder:output_type -> tickets.RefundOrderResponse
+	16, // 86: tickets.TicketsService.RefundTickets:output_type -> tickets.RefundTicketsResponse
+	18, // 87: tickets.TicketsService.GetConcertSession:output_type -> tickets.GetConcertSessionResponse
+	20, // 88: tickets.TicketsService.ListConcertSessions:output_type -> tickets.ListConcertSessionsResponse
+	22, // 89: tickets.TicketsService.GetAvailableTickets:output_type -> tickets.GetAvailableTicketsResponse
+	38, // 90: tickets.TicketsService.GetSeatMap:output_type -> tickets.GetSeatMapResponse
+	40, // 91: tickets.TicketsService.ListTicketTypes:output_type -> tickets.ListTicketTypesResponse
+	24, // 92: tickets.AdminService.CreateConcert:output_type -> tickets.CreateConcertResponse
+	26, // 93: tickets.AdminService.UpdateConcert:output_type -> tickets.UpdateConcertResponse
+	28, // 94: tickets.AdminService.DeleteConcert:output_type -> tickets.DeleteConcertResponse
+	30, // 95: tickets.AdminService.CreateConcertSession:output_type -> tickets.CreateConcertSessionResponse
+	32, // 96: tickets.AdminService.UpdateConcertSession:output_type -> tickets.UpdateConcertSessionResponse
+	34, // 97: tickets.AdminService.UpdateSessionCapacity:output_type -> tickets.UpdateSessionCapacityResponse
+	36, // 98: tickets.AdminService.DeleteConcertSession:output_type -> tickets.DeleteConcertSessionResponse
+	42, // 99: tickets.AdminService.CreateTicketType:output_type -> tickets.CreateTicketTypeResponse
+	44, // 100: tickets.AdminService.UpdateTicketType:output_type -> tickets.UpdateTicketTypeResponse
+	46, // 101: tickets.AdminService.DeleteTicketType:output_type -> tickets.DeleteTicketTypeResponse
+	48, // 102: tickets.AdminService.CreatePromoCode:output_type -> tickets.CreatePromoCodeResponse
+	50, // 103: tickets.AdminService.GetPromoCode:output_type -> tickets.GetPromoCodeResponse
+	52, // 104: tickets.AdminService.DeletePromoCode:output_type -> tickets.DeletePromoCodeResponse
+	54, // 105: tickets.AdminService.GetLedgerBalances:output_type -> tickets.GetLedgerBalancesResponse
+	56, // 106: tickets.AdminService.CheckLedger:output_type -> tickets.CheckLedgerResponse
+	79, // [79:107] is the sub-list for method output_type
+	51, // [51:79] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_proto_tickets_proto_init() }
@@ -1258,9 +5078,9 @@ func file_proto_tickets_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tickets_proto_rawDesc), len(file_proto_tickets_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   68,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_proto_tickets_proto_goTypes,
 		DependencyIndexes: file_proto_tickets_proto_depIdxs,
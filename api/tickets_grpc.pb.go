@@ -22,9 +22,16 @@ const (
 	TicketsService_CreateOrder_FullMethodName         = "/tickets.TicketsService/CreateOrder"
 	TicketsService_GetOrder_FullMethodName            = "/tickets.TicketsService/GetOrder"
 	TicketsService_ListOrders_FullMethodName          = "/tickets.TicketsService/ListOrders"
+	TicketsService_ConfirmOrder_FullMethodName        = "/tickets.TicketsService/ConfirmOrder"
+	TicketsService_CancelOrder_FullMethodName         = "/tickets.TicketsService/CancelOrder"
+	TicketsService_PayOrder_FullMethodName            = "/tickets.TicketsService/PayOrder"
+	TicketsService_RefundOrder_FullMethodName         = "/tickets.TicketsService/RefundOrder"
+	TicketsService_RefundTickets_FullMethodName       = "/tickets.TicketsService/RefundTickets"
 	TicketsService_GetConcertSession_FullMethodName   = "/tickets.TicketsService/GetConcertSession"
 	TicketsService_ListConcertSessions_FullMethodName = "/tickets.TicketsService/ListConcertSessions"
 	TicketsService_GetAvailableTickets_FullMethodName = "/tickets.TicketsService/GetAvailableTickets"
+	TicketsService_GetSeatMap_FullMethodName          = "/tickets.TicketsService/GetSeatMap"
+	TicketsService_ListTicketTypes_FullMethodName     = "/tickets.TicketsService/ListTicketTypes"
 )
 
 // TicketsServiceClient is the client API for TicketsService service.
@@ -39,12 +46,28 @@ type TicketsServiceClient interface {
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error)
 	// ListOrders retrieves orders for a user
 	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	// ConfirmOrder marks a pending order as paid and its tickets as sold
+	ConfirmOrder(ctx context.Context, in *ConfirmOrderRequest, opts ...grpc.CallOption) (*ConfirmOrderResponse, error)
+	// CancelOrder cancels a pending or paid order and releases its tickets, refunding a paid order
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	// PayOrder charges a pending order with the payment provider and confirms it once the
+	// payment is captured; a declined payment cancels the order and releases its tickets
+	PayOrder(ctx context.Context, in *PayOrderRequest, opts ...grpc.CallOption) (*PayOrderResponse, error)
+	// RefundOrder refunds every ticket of a paid order that is not refunded yet
+	RefundOrder(ctx context.Context, in *RefundOrderRequest, opts ...grpc.CallOption) (*RefundOrderResponse, error)
+	// RefundTickets refunds some tickets of a paid order; refunded tickets return to inventory,
+	// or are voided if their session has started
+	RefundTickets(ctx context.Context, in *RefundTicketsRequest, opts ...grpc.CallOption) (*RefundTicketsResponse, error)
 	// GetConcertSession retrieves a concert session by ID
 	GetConcertSession(ctx context.Context, in *GetConcertSessionRequest, opts ...grpc.CallOption) (*GetConcertSessionResponse, error)
-	// ListConcertSessions retrieves all concert sessions
+	// ListConcertSessions retrieves a page of concert sessions ordered by start time
 	ListConcertSessions(ctx context.Context, in *ListConcertSessionsRequest, opts ...grpc.CallOption) (*ListConcertSessionsResponse, error)
 	// GetAvailableTickets retrieves available tickets for a session
 	GetAvailableTickets(ctx context.Context, in *GetAvailableTicketsRequest, opts ...grpc.CallOption) (*GetAvailableTicketsResponse, error)
+	// GetSeatMap lists a reserved seating session's seats with their status
+	GetSeatMap(ctx context.Context, in *GetSeatMapRequest, opts ...grpc.CallOption) (*GetSeatMapResponse, error)
+	// ListTicketTypes lists a session's ticket types with their prices and remaining quotas
+	ListTicketTypes(ctx context.Context, in *ListTicketTypesRequest, opts ...grpc.CallOption) (*ListTicketTypesResponse, error)
 }
 
 type ticketsServiceClient struct {
@@ -85,6 +108,56 @@ func (c *ticketsServiceClient) ListOrders(ctx context.Context, in *ListOrdersReq
 	return out, nil
 }
 
+func (c *ticketsServiceClient) ConfirmOrder(ctx context.Context, in *ConfirmOrderRequest, opts ...grpc.CallOption) (*ConfirmOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmOrderResponse)
+	err := c.cc.Invoke(ctx, TicketsService_ConfirmOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketsServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, TicketsService_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketsServiceClient) PayOrder(ctx context.Context, in *PayOrderRequest, opts ...grpc.CallOption) (*PayOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PayOrderResponse)
+	err := c.cc.Invoke(ctx, TicketsService_PayOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketsServiceClient) RefundOrder(ctx context.Context, in *RefundOrderRequest, opts ...grpc.CallOption) (*RefundOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundOrderResponse)
+	err := c.cc.Invoke(ctx, TicketsService_RefundOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketsServiceClient) RefundTickets(ctx context.Context, in *RefundTicketsRequest, opts ...grpc.CallOption) (*RefundTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundTicketsResponse)
+	err := c.cc.Invoke(ctx, TicketsService_RefundTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ticketsServiceClient) GetConcertSession(ctx context.Context, in *GetConcertSessionRequest, opts ...grpc.CallOption) (*GetConcertSessionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetConcertSessionResponse)
@@ -115,6 +188,26 @@ func (c *ticketsServiceClient) GetAvailableTickets(ctx context.Context, in *GetA
 	return out, nil
 }
 
+func (c *ticketsServiceClient) GetSeatMap(ctx context.Context, in *GetSeatMapRequest, opts ...grpc.CallOption) (*GetSeatMapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSeatMapResponse)
+	err := c.cc.Invoke(ctx, TicketsService_GetSeatMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketsServiceClient) ListTicketTypes(ctx context.Context, in *ListTicketTypesRequest, opts ...grpc.CallOption) (*ListTicketTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTicketTypesResponse)
+	err := c.cc.Invoke(ctx, TicketsService_ListTicketTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TicketsServiceServer is the server API for TicketsService service.
 // All implementations must embed UnimplementedTicketsServiceServer
 // for forward compatibility.
@@ -127,12 +220,28 @@ type TicketsServiceServer interface {
 	GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error)
 	// ListOrders retrieves orders for a user
 	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	// ConfirmOrder marks a pending order as paid and its tickets as sold
+	ConfirmOrder(context.Context, *ConfirmOrderRequest) (*ConfirmOrderResponse, error)
+	// CancelOrder cancels a pending or paid order and releases its tickets, refunding a paid order
+	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	// PayOrder charges a pending order with the payment provider and confirms it once the
+	// payment is captured; a declined payment cancels the order and releases its tickets
+	PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error)
+	// RefundOrder refunds every ticket of a paid order that is not refunded yet
+	RefundOrder(context.Context, *RefundOrderRequest) (*RefundOrderResponse, error)
+	// RefundTickets refunds some tickets of a paid order; refunded tickets return to inventory,
+	// or are voided if their session has started
+	RefundTickets(context.Context, *RefundTicketsRequest) (*RefundTicketsResponse, error)
 	// GetConcertSession retrieves a concert session by ID
 	GetConcertSession(context.Context, *GetConcertSessionRequest) (*GetConcertSessionResponse, error)
-	// ListConcertSessions retrieves all concert sessions
+	// ListConcertSessions retrieves a page of concert sessions ordered by start time
 	ListConcertSessions(context.Context, *ListConcertSessionsRequest) (*ListConcertSessionsResponse, error)
 	// GetAvailableTickets retrieves available tickets for a session
 	GetAvailableTickets(context.Context, *GetAvailableTicketsRequest) (*GetAvailableTicketsResponse, error)
+	// GetSeatMap lists a reserved seating session's seats with their status
+	GetSeatMap(context.Context, *GetSeatMapRequest) (*GetSeatMapResponse, error)
+	// ListTicketTypes lists a session's ticket types with their prices and remaining quotas
+	ListTicketTypes(context.Context, *ListTicketTypesRequest) (*ListTicketTypesResponse, error)
 	mustEmbedUnimplementedTicketsServiceServer()
 }
 
@@ -152,6 +261,21 @@ func (UnimplementedTicketsServiceServer) GetOrder(context.Context, *GetOrderRequ
 func (UnimplementedTicketsServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
 }
+func (UnimplementedTicketsServiceServer) ConfirmOrder(context.Context, *ConfirmOrderRequest) (*ConfirmOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmOrder not implemented")
+}
+func (UnimplementedTicketsServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedTicketsServiceServer) PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PayOrder not implemented")
+}
+func (UnimplementedTicketsServiceServer) RefundOrder(context.Context, *RefundOrderRequest) (*RefundOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundOrder not implemented")
+}
+func (UnimplementedTicketsServiceServer) RefundTickets(context.Context, *RefundTicketsRequest) (*RefundTicketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundTickets not implemented")
+}
 func (UnimplementedTicketsServiceServer) GetConcertSession(context.Context, *GetConcertSessionRequest) (*GetConcertSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConcertSession not implemented")
 }
@@ -161,6 +285,12 @@ func (UnimplementedTicketsServiceServer) ListConcertSessions(context.Context, *L
 func (UnimplementedTicketsServiceServer) GetAvailableTickets(context.Context, *GetAvailableTicketsRequest) (*GetAvailableTicketsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailableTickets not implemented")
 }
+func (UnimplementedTicketsServiceServer) GetSeatMap(context.Context, *GetSeatMapRequest) (*GetSeatMapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeatMap not implemented")
+}
+func (UnimplementedTicketsServiceServer) ListTicketTypes(context.Context, *ListTicketTypesRequest) (*ListTicketTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTicketTypes not implemented")
+}
 func (UnimplementedTicketsServiceServer) mustEmbedUnimplementedTicketsServiceServer() {}
 func (UnimplementedTicketsServiceServer) testEmbeddedByValue()                        {}
 
@@ -236,6 +366,96 @@ func _TicketsService_ListOrders_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicketsService_ConfirmOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketsServiceServer).ConfirmOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketsService_ConfirmOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketsServiceServer).ConfirmOrder(ctx, req.(*ConfirmOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketsService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketsServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketsService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketsServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketsService_PayOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketsServiceServer).PayOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketsService_PayOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketsServiceServer).PayOrder(ctx, req.(*PayOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketsService_RefundOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketsServiceServer).RefundOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketsService_RefundOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketsServiceServer).RefundOrder(ctx, req.(*RefundOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketsService_RefundTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketsServiceServer).RefundTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketsService_RefundTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketsServiceServer).RefundTickets(ctx, req.(*RefundTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TicketsService_GetConcertSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetConcertSessionRequest)
 	if err := dec(in); err != nil {
@@ -290,6 +510,42 @@ func _TicketsService_GetAvailableTickets_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicketsService_GetSeatMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeatMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketsServiceServer).GetSeatMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketsService_GetSeatMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketsServiceServer).GetSeatMap(ctx, req.(*GetSeatMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketsService_ListTicketTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTicketTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketsServiceServer).ListTicketTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketsService_ListTicketTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketsServiceServer).ListTicketTypes(ctx, req.(*ListTicketTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TicketsService_ServiceDesc is the grpc.ServiceDesc for TicketsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -309,6 +565,26 @@ var TicketsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListOrders",
 			Handler:    _TicketsService_ListOrders_Handler,
 		},
+		{
+			MethodName: "ConfirmOrder",
+			Handler:    _TicketsService_ConfirmOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _TicketsService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "PayOrder",
+			Handler:    _TicketsService_PayOrder_Handler,
+		},
+		{
+			MethodName: "RefundOrder",
+			Handler:    _TicketsService_RefundOrder_Handler,
+		},
+		{
+			MethodName: "RefundTickets",
+			Handler:    _TicketsService_RefundTickets_Handler,
+		},
 		{
 			MethodName: "GetConcertSession",
 			Handler:    _TicketsService_GetConcertSession_Handler,
@@ -321,6 +597,682 @@ var TicketsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAvailableTickets",
 			Handler:    _TicketsService_GetAvailableTickets_Handler,
 		},
+		{
+			MethodName: "GetSeatMap",
+			Handler:    _TicketsService_GetSeatMap_Handler,
+		},
+		{
+			MethodName: "ListTicketTypes",
+			Handler:    _TicketsService_ListTicketTypes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/tickets.proto",
+}
+
+const (
+	AdminService_CreateConcert_FullMethodName         = "/tickets.AdminService/CreateConcert"
+	AdminService_UpdateConcert_FullMethodName         = "/tickets.AdminService/UpdateConcert"
+	AdminService_DeleteConcert_FullMethodName         = "/tickets.AdminService/DeleteConcert"
+	AdminService_CreateConcertSession_FullMethodName  = "/tickets.AdminService/CreateConcertSession"
+	AdminService_UpdateConcertSession_FullMethodName  = "/tickets.AdminService/UpdateConcertSession"
+	AdminService_UpdateSessionCapacity_FullMethodName = "/tickets.AdminService/UpdateSessionCapacity"
+	AdminService_DeleteConcertSession_FullMethodName  = "/tickets.AdminService/DeleteConcertSession"
+	AdminService_CreateTicketType_FullMethodName      = "/tickets.AdminService/CreateTicketType"
+	AdminService_UpdateTicketType_FullMethodName      = "/tickets.AdminService/UpdateTicketType"
+	AdminService_DeleteTicketType_FullMethodName      = "/tickets.AdminService/DeleteTicketType"
+	AdminService_CreatePromoCode_FullMethodName       = "/tickets.AdminService/CreatePromoCode"
+	AdminService_GetPromoCode_FullMethodName          = "/tickets.AdminService/GetPromoCode"
+	AdminService_DeletePromoCode_FullMethodName       = "/tickets.AdminService/DeletePromoCode"
+	AdminService_GetLedgerBalances_FullMethodName     = "/tickets.AdminService/GetLedgerBalances"
+	AdminService_CheckLedger_FullMethodName           = "/tickets.AdminService/CheckLedger"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService manages the concert catalogue
+type AdminServiceClient interface {
+	// CreateConcert creates a concert
+	CreateConcert(ctx context.Context, in *CreateConcertRequest, opts ...grpc.CallOption) (*CreateConcertResponse, error)
+	// UpdateConcert replaces a concert's name, location and description
+	UpdateConcert(ctx context.Context, in *UpdateConcertRequest, opts ...grpc.CallOption) (*UpdateConcertResponse, error)
+	// DeleteConcert deletes a concert that has no sessions
+	DeleteConcert(ctx context.Context, in *DeleteConcertRequest, opts ...grpc.CallOption) (*DeleteConcertResponse, error)
+	// CreateConcertSession schedules a session and creates one available ticket per seat
+	CreateConcertSession(ctx context.Context, in *CreateConcertSessionRequest, opts ...grpc.CallOption) (*CreateConcertSessionResponse, error)
+	// UpdateConcertSession replaces a session's schedule, venue and price
+	UpdateConcertSession(ctx context.Context, in *UpdateConcertSessionRequest, opts ...grpc.CallOption) (*UpdateConcertSessionResponse, error)
+	// UpdateSessionCapacity changes a session's number of seats, adding or removing available tickets
+	UpdateSessionCapacity(ctx context.Context, in *UpdateSessionCapacityRequest, opts ...grpc.CallOption) (*UpdateSessionCapacityResponse, error)
+	// DeleteConcertSession deletes a session without orders together with its tickets
+	DeleteConcertSession(ctx context.Context, in *DeleteConcertSessionRequest, opts ...grpc.CallOption) (*DeleteConcertSessionResponse, error)
+	// CreateTicketType adds a priced ticket type with a quota to a session
+	CreateTicketType(ctx context.Context, in *CreateTicketTypeRequest, opts ...grpc.CallOption) (*CreateTicketTypeResponse, error)
+	// UpdateTicketType replaces a ticket type's name, description, price and quota
+	UpdateTicketType(ctx context.Context, in *UpdateTicketTypeRequest, opts ...grpc.CallOption) (*UpdateTicketTypeResponse, error)
+	// DeleteTicketType deletes a ticket type that has never been ordered
+	DeleteTicketType(ctx context.Context, in *DeleteTicketTypeRequest, opts ...grpc.CallOption) (*DeleteTicketTypeResponse, error)
+	// CreatePromoCode creates a discount code for checkout
+	CreatePromoCode(ctx context.Context, in *CreatePromoCodeRequest, opts ...grpc.CallOption) (*CreatePromoCodeResponse, error)
+	// GetPromoCode retrieves a promo code with its number of redemptions
+	GetPromoCode(ctx context.Context, in *GetPromoCodeRequest, opts ...grpc.CallOption) (*GetPromoCodeResponse, error)
+	// DeletePromoCode deletes a promo code that has never been redeemed
+	DeletePromoCode(ctx context.Context, in *DeletePromoCodeRequest, opts ...grpc.CallOption) (*DeletePromoCodeResponse, error)
+	// GetLedgerBalances totals the ledger entries of one account, or of every account
+	GetLedgerBalances(ctx context.Context, in *GetLedgerBalancesRequest, opts ...grpc.CallOption) (*GetLedgerBalancesResponse, error)
+	// CheckLedger checks that the ledger's debits equal its credits
+	CheckLedger(ctx context.Context, in *CheckLedgerRequest, opts ...grpc.CallOption) (*CheckLedgerResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) CreateConcert(ctx context.Context, in *CreateConcertRequest, opts ...grpc.CallOption) (*CreateConcertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateConcertResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateConcert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateConcert(ctx context.Context, in *UpdateConcertRequest, opts ...grpc.CallOption) (*UpdateConcertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateConcertResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateConcert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteConcert(ctx context.Context, in *DeleteConcertRequest, opts ...grpc.CallOption) (*DeleteConcertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteConcertResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteConcert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateConcertSession(ctx context.Context, in *CreateConcertSessionRequest, opts ...grpc.CallOption) (*CreateConcertSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateConcertSessionResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateConcertSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateConcertSession(ctx context.Context, in *UpdateConcertSessionRequest, opts ...grpc.CallOption) (*UpdateConcertSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateConcertSessionResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateConcertSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateSessionCapacity(ctx context.Context, in *UpdateSessionCapacityRequest, opts ...grpc.CallOption) (*UpdateSessionCapacityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSessionCapacityResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateSessionCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteConcertSession(ctx context.Context, in *DeleteConcertSessionRequest, opts ...grpc.CallOption) (*DeleteConcertSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteConcertSessionResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteConcertSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateTicketType(ctx context.Context, in *CreateTicketTypeRequest, opts ...grpc.CallOption) (*CreateTicketTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTicketTypeResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateTicketType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateTicketType(ctx context.Context, in *UpdateTicketTypeRequest, opts ...grpc.CallOption) (*UpdateTicketTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTicketTypeResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateTicketType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteTicketType(ctx context.Context, in *DeleteTicketTypeRequest, opts ...grpc.CallOption) (*DeleteTicketTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTicketTypeResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteTicketType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreatePromoCode(ctx context.Context, in *CreatePromoCodeRequest, opts ...grpc.CallOption) (*CreatePromoCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePromoCodeResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreatePromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetPromoCode(ctx context.Context, in *GetPromoCodeRequest, opts ...grpc.CallOption) (*GetPromoCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPromoCodeResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetPromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeletePromoCode(ctx context.Context, in *DeletePromoCodeRequest, opts ...grpc.CallOption) (*DeletePromoCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePromoCodeResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeletePromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetLedgerBalances(ctx context.Context, in *GetLedgerBalancesRequest, opts ...grpc.CallOption) (*GetLedgerBalancesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLedgerBalancesResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetLedgerBalances_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CheckLedger(ctx context.Context, in *CheckLedgerRequest, opts ...grpc.CallOption) (*CheckLedgerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckLedgerResponse)
+	err := c.cc.Invoke(ctx, AdminService_CheckLedger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService manages the concert catalogue
+type AdminServiceServer interface {
+	// CreateConcert creates a concert
+	CreateConcert(context.Context, *CreateConcertRequest) (*CreateConcertResponse, error)
+	// UpdateConcert replaces a concert's name, location and description
+	UpdateConcert(context.Context, *UpdateConcertRequest) (*UpdateConcertResponse, error)
+	// DeleteConcert deletes a concert that has no sessions
+	DeleteConcert(context.Context, *DeleteConcertRequest) (*DeleteConcertResponse, error)
+	// CreateConcertSession schedules a session and creates one available ticket per seat
+	CreateConcertSession(context.Context, *CreateConcertSessionRequest) (*CreateConcertSessionResponse, error)
+	// UpdateConcertSession replaces a session's schedule, venue and price
+	UpdateConcertSession(context.Context, *UpdateConcertSessionRequest) (*UpdateConcertSessionResponse, error)
+	// UpdateSessionCapacity changes a session's number of seats, adding or removing available tickets
+	UpdateSessionCapacity(context.Context, *UpdateSessionCapacityRequest) (*UpdateSessionCapacityResponse, error)
+	// DeleteConcertSession deletes a session without orders together with its tickets
+	DeleteConcertSession(context.Context, *DeleteConcertSessionRequest) (*DeleteConcertSessionResponse, error)
+	// CreateTicketType adds a priced ticket type with a quota to a session
+	CreateTicketType(context.Context, *CreateTicketTypeRequest) (*CreateTicketTypeResponse, error)
+	// UpdateTicketType replaces a ticket type's name, description, price and quota
+	UpdateTicketType(context.Context, *UpdateTicketTypeRequest) (*UpdateTicketTypeResponse, error)
+	// DeleteTicketType deletes a ticket type that has never been ordered
+	DeleteTicketType(context.Context, *DeleteTicketTypeRequest) (*DeleteTicketTypeResponse, error)
+	// CreatePromoCode creates a discount code for checkout
+	CreatePromoCode(context.Context, *CreatePromoCodeRequest) (*CreatePromoCodeResponse, error)
+	// GetPromoCode retrieves a promo code with its number of redemptions
+	GetPromoCode(context.Context, *GetPromoCodeRequest) (*GetPromoCodeResponse, error)
+	// DeletePromoCode deletes a promo code that has never been redeemed
+	DeletePromoCode(context.Context, *DeletePromoCodeRequest) (*DeletePromoCodeResponse, error)
+	// GetLedgerBalances totals the ledger entries of one account, or of every account
+	GetLedgerBalances(context.Context, *GetLedgerBalancesRequest) (*GetLedgerBalancesResponse, error)
+	// CheckLedger checks that the ledger's debits equal its credits
+	CheckLedger(context.Context, *CheckLedgerRequest) (*CheckLedgerResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) CreateConcert(context.Context, *CreateConcertRequest) (*CreateConcertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConcert not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateConcert(context.Context, *UpdateConcertRequest) (*UpdateConcertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConcert not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteConcert(context.Context, *DeleteConcertRequest) (*DeleteConcertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConcert not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateConcertSession(context.Context, *CreateConcertSessionRequest) (*CreateConcertSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConcertSession not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateConcertSession(context.Context, *UpdateConcertSessionRequest) (*UpdateConcertSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConcertSession not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateSessionCapacity(context.Context, *UpdateSessionCapacityRequest) (*UpdateSessionCapacityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSessionCapacity not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteConcertSession(context.Context, *DeleteConcertSessionRequest) (*DeleteConcertSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConcertSession not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateTicketType(context.Context, *CreateTicketTypeRequest) (*CreateTicketTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTicketType not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateTicketType(context.Context, *UpdateTicketTypeRequest) (*UpdateTicketTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTicketType not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteTicketType(context.Context, *DeleteTicketTypeRequest) (*DeleteTicketTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTicketType not implemented")
+}
+func (UnimplementedAdminServiceServer) CreatePromoCode(context.Context, *CreatePromoCodeRequest) (*CreatePromoCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromoCode not implemented")
+}
+func (UnimplementedAdminServiceServer) GetPromoCode(context.Context, *GetPromoCodeRequest) (*GetPromoCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPromoCode not implemented")
+}
+func (UnimplementedAdminServiceServer) DeletePromoCode(context.Context, *DeletePromoCodeRequest) (*DeletePromoCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePromoCode not implemented")
+}
+func (UnimplementedAdminServiceServer) GetLedgerBalances(context.Context, *GetLedgerBalancesRequest) (*GetLedgerBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLedgerBalances not implemented")
+}
+func (UnimplementedAdminServiceServer) CheckLedger(context.Context, *CheckLedgerRequest) (*CheckLedgerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckLedger not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_CreateConcert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConcertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateConcert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateConcert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateConcert(ctx, req.(*CreateConcertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateConcert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConcertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateConcert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateConcert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateConcert(ctx, req.(*UpdateConcertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteConcert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConcertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteConcert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteConcert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteConcert(ctx, req.(*DeleteConcertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateConcertSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConcertSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateConcertSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateConcertSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateConcertSession(ctx, req.(*CreateConcertSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateConcertSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConcertSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateConcertSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateConcertSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateConcertSession(ctx, req.(*UpdateConcertSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateSessionCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSessionCapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateSessionCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateSessionCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateSessionCapacity(ctx, req.(*UpdateSessionCapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteConcertSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConcertSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteConcertSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteConcertSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteConcertSession(ctx, req.(*DeleteConcertSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateTicketType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTicketTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateTicketType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateTicketType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateTicketType(ctx, req.(*CreateTicketTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateTicketType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTicketTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateTicketType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateTicketType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateTicketType(ctx, req.(*UpdateTicketTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteTicketType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTicketTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteTicketType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteTicketType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteTicketType(ctx, req.(*DeleteTicketTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreatePromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreatePromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreatePromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreatePromoCode(ctx, req.(*CreatePromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetPromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetPromoCode(ctx, req.(*GetPromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeletePromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeletePromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeletePromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeletePromoCode(ctx, req.(*DeletePromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetLedgerBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLedgerBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLedgerBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetLedgerBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLedgerBalances(ctx, req.(*GetLedgerBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CheckLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckLedgerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CheckLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CheckLedger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CheckLedger(ctx, req.(*CheckLedgerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tickets.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateConcert",
+			Handler:    _AdminService_CreateConcert_Handler,
+		},
+		{
+			MethodName: "UpdateConcert",
+			Handler:    _AdminService_UpdateConcert_Handler,
+		},
+		{
+			MethodName: "DeleteConcert",
+			Handler:    _AdminService_DeleteConcert_Handler,
+		},
+		{
+			MethodName: "CreateConcertSession",
+			Handler:    _AdminService_CreateConcertSession_Handler,
+		},
+		{
+			MethodName: "UpdateConcertSession",
+			Handler:    _AdminService_UpdateConcertSession_Handler,
+		},
+		{
+			MethodName: "UpdateSessionCapacity",
+			Handler:    _AdminService_UpdateSessionCapacity_Handler,
+		},
+		{
+			MethodName: "DeleteConcertSession",
+			Handler:    _AdminService_DeleteConcertSession_Handler,
+		},
+		{
+			MethodName: "CreateTicketType",
+			Handler:    _AdminService_CreateTicketType_Handler,
+		},
+		{
+			MethodName: "UpdateTicketType",
+			Handler:    _AdminService_UpdateTicketType_Handler,
+		},
+		{
+			MethodName: "DeleteTicketType",
+			Handler:    _AdminService_DeleteTicketType_Handler,
+		},
+		{
+			MethodName: "CreatePromoCode",
+			Handler:    _AdminService_CreatePromoCode_Handler,
+		},
+		{
+			MethodName: "GetPromoCode",
+			Handler:    _AdminService_GetPromoCode_Handler,
+		},
+		{
+			MethodName: "DeletePromoCode",
+			Handler:    _AdminService_DeletePromoCode_Handler,
+		},
+		{
+			MethodName: "GetLedgerBalances",
+			Handler:    _AdminService_GetLedgerBalances_Handler,
+		},
+		{
+			MethodName: "CheckLedger",
+			Handler:    _AdminService_CheckLedger_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/tickets.proto",
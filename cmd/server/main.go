@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"tickets/api"
+	"tickets/internal/config"
+	"tickets/internal/handler"
+	"tickets/internal/logger"
+	"tickets/internal/migrations"
+	"tickets/internal/payment"
+	"tickets/internal/pricing"
+	"tickets/internal/repository"
+	"tickets/internal/service"
+	"tickets/internal/worker"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
+)
+
+// shutdownTimeout bounds how long in-flight RPCs may take to drain on shutdown
+const shutdownTimeout = 30 * time.Second
+
+// readHeaderTimeout bounds how long the HTTP server waits for request headers
+const readHeaderTimeout = 10 * time.Second
+
+func main() {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logger.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger
+	if err := logger.Init(&cfg.Logging); err != nil {
+		logger.Fatalf("Failed to initialize logger: %v", err)
+	}
+
+	// Connect to database
+	db, err := sqlx.Connect("postgres", cfg.Database.URL)
+	if err != nil {
+		logger.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Run migrations if enabled
+	if cfg.Database.AutoMigrate {
+		if err := migrations.RunMigrationsOnStartup(db.DB, cfg.Database.MigrationsPath); err != nil {
+			logger.Fatalf("Failed to run migrations: %v", err)
+		}
+	}
+
+	// Wire repositories, services and handlers
+	baseRepo := repository.NewBaseRepository(db)
+	if cfg.Pagination.TokenSecret != "" {
+		baseRepo.SetPageTokenSecret([]byte(cfg.Pagination.TokenSecret))
+	} else {
+		logger.Warn("pagination.token_secret is not set; page tokens are only valid until the server restarts")
+	}
+	baseService := service.NewBaseService(baseRepo)
+	orderService := service.NewOrderService(baseService)
+	orderService.SetHoldTTL(cfg.Orders.HoldTTL)
+	orderService.SetIdempotencyTTL(cfg.Orders.IdempotencyTTL)
+	pricingRules, err := pricing.New(cfg.Pricing)
+	if err != nil {
+		logger.Fatalf("Failed to load pricing: %v", err)
+	}
+	orderService.SetPricing(pricingRules)
+	paymentProvider, err := payment.NewProvider(cfg.Payments.Provider, cfg.Payments.Fake)
+	if err != nil {
+		logger.Fatalf("Failed to create payment provider: %v", err)
+	}
+	if cfg.Payments.Provider == payment.ProviderFake {
+		logger.Warnf("Using the fake payment provider (%s); no real payments are taken", cfg.Payments.Fake.Behavior)
+	}
+	orderService.SetPaymentProvider(paymentProvider)
+	orderService.SetPaymentTimeout(cfg.Payments.Timeout)
+	concertService := service.NewConcertService(baseService)
+	grpcHandler := handler.NewGRPCHandler(orderService, concertService)
+	adminHandler := handler.NewAdminHandler(service.NewAdminService(baseService))
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(handler.UnaryErrorInterceptor))
+	api.RegisterTicketsServiceServer(grpcServer, grpcHandler)
+	api.RegisterAdminServiceServer(grpcServer, adminHandler)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRPCPort))
+	if err != nil {
+		logger.Fatalf("Failed to listen on port %d: %v", cfg.Server.GRPCPort, err)
+	}
+
+	// Payment providers post asynchronous payment results over HTTP
+	if cfg.Payments.WebhookSecret == "" {
+		logger.Warn("payments.webhook_secret is not set; payment webhooks are refused")
+	}
+	mux := http.NewServeMux()
+	mux.Handle(handler.PaymentWebhookPath, handler.NewWebhookHandler(orderService, []byte(cfg.Payments.WebhookSecret)))
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Release tickets held by abandoned checkouts and prune stale idempotency keys in the background
+	expiryWorker := worker.NewOrderExpiryWorker(orderService, cfg.Orders.ExpiryInterval)
+	workerDone := make(chan struct{})
+	go func() {
+		expiryWorker.Run(ctx)
+		close(workerDone)
+	}()
+
+	serveErr := make(chan error, 2)
+	go func() {
+		logger.Infof("gRPC server listening on :%d", cfg.Server.GRPCPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			serveErr <- fmt.Errorf("gRPC server failed: %w", err)
+		}
+	}()
+	go func() {
+		logger.Infof("HTTP server listening on :%d", cfg.Server.Port)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("HTTP server failed: %w", err)
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		logger.Fatalf("%v", err)
+	case <-ctx.Done():
+		logger.Info("Shutdown signal received, draining in-flight requests...")
+	}
+
+	// Drain webhooks alongside RPCs; both share the shutdown timeout
+	httpStopped := make(chan struct{})
+	go func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warnf("HTTP server shutdown: %v", err)
+		}
+		close(httpStopped)
+	}()
+
+	// Stop accepting new RPCs and wait for in-flight ones, forcing a stop on timeout
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		logger.Info("gRPC server stopped gracefully")
+	case <-time.After(shutdownTimeout):
+		logger.Warnf("Graceful shutdown timed out after %s, forcing stop", shutdownTimeout)
+		grpcServer.Stop()
+	}
+
+	<-httpStopped
+	<-workerDone
+}
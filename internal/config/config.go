@@ -1,8 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"strings"
 	"tickets/internal/logger"
+	"tickets/internal/payment"
+	"tickets/internal/pricing"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -10,7 +14,7 @@ import (
 type Config struct {
 	Server struct {
 		Port     int
-		GRPCPort int
+		GRPCPort int `mapstructure:"grpc_port"`
 	}
 	Database struct {
 		URL      string
@@ -19,6 +23,34 @@ type Config struct {
 		User     string
 		Password string
 		DBName   string
+		// AutoMigrate runs pending migrations when the server starts
+		AutoMigrate    bool   `mapstructure:"auto_migrate"`
+		MigrationsPath string `mapstructure:"migrations_path"`
+	}
+	Orders struct {
+		// HoldTTL is how long a pending order holds its tickets before it expires
+		HoldTTL time.Duration `mapstructure:"hold_ttl"`
+		// ExpiryInterval is how often the server releases expired pending orders
+		ExpiryInterval time.Duration `mapstructure:"expiry_interval"`
+		// IdempotencyTTL is how long CreateOrder idempotency keys are remembered
+		IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"`
+	}
+	Payments struct {
+		// Provider names the payment provider orders are charged with
+		Provider string
+		// Timeout bounds each call to the payment provider
+		Timeout time.Duration
+		// Fake configures the in-process fake provider
+		Fake payment.FakeConfig
+		// WebhookSecret verifies the signatures of payment webhooks; when empty webhooks are refused
+		WebhookSecret string `mapstructure:"webhook_secret"`
+	}
+	// Pricing configures the service fees and tax added to ticket prices
+	Pricing    pricing.Config
+	Pagination struct {
+		// TokenSecret signs list page tokens. When empty a random secret is used and tokens
+		// are only valid until the server restarts.
+		TokenSecret string `mapstructure:"token_secret"`
 	}
 	Logging logger.Config `json:"logging" yaml:"logging"`
 	Mode    string
@@ -61,6 +93,45 @@ func LoadConfig() (*Config, error) {
 	if err := viper.BindEnv("database.url", "DATABASE_URL"); err != nil {
 		return nil, err
 	}
+	if err := viper.BindEnv("database.auto_migrate", "DATABASE_AUTO_MIGRATE"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("database.migrations_path", "DATABASE_MIGRATIONS_PATH"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("orders.hold_ttl", "ORDERS_HOLD_TTL"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("orders.expiry_interval", "ORDERS_EXPIRY_INTERVAL"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("orders.idempotency_ttl", "ORDERS_IDEMPOTENCY_TTL"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("payments.provider", "PAYMENTS_PROVIDER"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("payments.timeout", "PAYMENTS_TIMEOUT"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("payments.fake.behavior", "PAYMENTS_FAKE_BEHAVIOR"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("payments.webhook_secret", "PAYMENTS_WEBHOOK_SECRET"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("pricing.fee_per_ticket", "PRICING_FEE_PER_TICKET"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("pricing.fee_per_order", "PRICING_FEE_PER_ORDER"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("pricing.default_jurisdiction", "PRICING_DEFAULT_JURISDICTION"); err != nil {
+		return nil, err
+	}
+	if err := viper.BindEnv("pagination.token_secret", "PAGINATION_TOKEN_SECRET"); err != nil {
+		return nil, err
+	}
 	if err := viper.BindEnv("logging.level", "LOGGING_LEVEL"); err != nil {
 		return nil, err
 	}
@@ -99,9 +170,50 @@ func LoadConfig() (*Config, error) {
 	if cfg.Port == "" {
 		cfg.Port = "8080"
 	}
+	if cfg.Server.Port == 0 {
+		cfg.Server.Port = 8080
+	}
 	if cfg.Server.GRPCPort == 0 {
 		cfg.Server.GRPCPort = 9090
 	}
+	if cfg.Database.MigrationsPath == "" {
+		cfg.Database.MigrationsPath = "migrations"
+	}
+	if cfg.Orders.HoldTTL == 0 {
+		cfg.Orders.HoldTTL = 10 * time.Minute
+	}
+	if cfg.Orders.ExpiryInterval == 0 {
+		cfg.Orders.ExpiryInterval = time.Minute
+	}
+	if cfg.Orders.IdempotencyTTL == 0 {
+		cfg.Orders.IdempotencyTTL = 24 * time.Hour
+	}
+	if cfg.Payments.Provider == "" {
+		cfg.Payments.Provider = payment.ProviderFake
+	}
+	if cfg.Payments.Timeout == 0 {
+		cfg.Payments.Timeout = 30 * time.Second
+	}
+	if cfg.Payments.Fake.Behavior == "" {
+		cfg.Payments.Fake.Behavior = payment.FakeApprove
+	}
+
+	// Unset durations took their defaults above, so anything left that is not positive was
+	// configured that way
+	durations := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"orders.hold_ttl", cfg.Orders.HoldTTL},
+		{"orders.expiry_interval", cfg.Orders.ExpiryInterval},
+		{"orders.idempotency_ttl", cfg.Orders.IdempotencyTTL},
+		{"payments.timeout", cfg.Payments.Timeout},
+	}
+	for _, d := range durations {
+		if d.value <= 0 {
+			return nil, fmt.Errorf("%s must be positive, got %s", d.key, d.value)
+		}
+	}
 
 	return &cfg, nil
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"tickets/internal/logger"
 
@@ -87,9 +88,7 @@ func TestLoadConfig_WithEnvironmentVariables(t *testing.T) {
 	assert.Equal(t, "test-password", cfg.Database.Password)
 	assert.Equal(t, "test-db", cfg.Database.DBName)
 	assert.Equal(t, 8081, cfg.Server.Port)
-	// Note: GRPC_PORT environment variable might not be working as expected
-	// This is a known limitation of the current config system
-	// assert.Equal(t, 9091, cfg.Server.GRPCPort)
+	assert.Equal(t, 9091, cfg.Server.GRPCPort)
 	assert.Equal(t, logger.LogLevel("debug"), cfg.Logging.Level)
 	assert.Equal(t, logger.LogFormat("json"), cfg.Logging.Format)
 }
@@ -222,6 +221,123 @@ func TestLoadConfig_ServerConfiguration(t *testing.T) {
 	assert.Equal(t, 9090, cfg.Server.GRPCPort)
 }
 
+func TestLoadConfig_OrdersConfiguration(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, cfg.Orders.HoldTTL)
+	assert.Equal(t, time.Minute, cfg.Orders.ExpiryInterval)
+	assert.Equal(t, 24*time.Hour, cfg.Orders.IdempotencyTTL)
+
+	os.Setenv("ORDERS_HOLD_TTL", "90s")
+	defer os.Unsetenv("ORDERS_HOLD_TTL")
+	os.Setenv("ORDERS_IDEMPOTENCY_TTL", "1h")
+	defer os.Unsetenv("ORDERS_IDEMPOTENCY_TTL")
+
+	cfg, err = LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, cfg.Orders.HoldTTL)
+	assert.Equal(t, time.Hour, cfg.Orders.IdempotencyTTL)
+}
+
+func TestLoadConfig_NegativeDurations(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	for _, env := range []string{"ORDERS_HOLD_TTL", "ORDERS_EXPIRY_INTERVAL", "ORDERS_IDEMPOTENCY_TTL", "PAYMENTS_TIMEOUT"} {
+		t.Run(env, func(t *testing.T) {
+			os.Setenv(env, "-1m")
+			defer os.Unsetenv(env)
+
+			cfg, err := LoadConfig()
+			assert.Nil(t, cfg)
+			assert.ErrorContains(t, err, "must be positive")
+		})
+	}
+}
+
+func TestLoadConfig_PaymentsConfiguration(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "fake", cfg.Payments.Provider)
+	assert.Equal(t, 30*time.Second, cfg.Payments.Timeout)
+	assert.Equal(t, "approve", cfg.Payments.Fake.Behavior)
+	assert.Empty(t, cfg.Payments.WebhookSecret)
+
+	os.Setenv("PAYMENTS_TIMEOUT", "5s")
+	defer os.Unsetenv("PAYMENTS_TIMEOUT")
+	os.Setenv("PAYMENTS_FAKE_BEHAVIOR", "decline")
+	defer os.Unsetenv("PAYMENTS_FAKE_BEHAVIOR")
+	os.Setenv("PAYMENTS_WEBHOOK_SECRET", "whsec")
+	defer os.Unsetenv("PAYMENTS_WEBHOOK_SECRET")
+
+	cfg, err = LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.Payments.Timeout)
+	assert.Equal(t, "decline", cfg.Payments.Fake.Behavior)
+	assert.Equal(t, "whsec", cfg.Payments.WebhookSecret)
+}
+
+func TestLoadConfig_PricingConfiguration(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "0.00", cfg.Pricing.FeePerTicket)
+	assert.Equal(t, "0.00", cfg.Pricing.FeePerOrder)
+	assert.Empty(t, cfg.Pricing.TaxRates)
+	assert.Empty(t, cfg.Pricing.DefaultJurisdiction)
+
+	os.Setenv("PRICING_FEE_PER_TICKET", "1.50")
+	defer os.Unsetenv("PRICING_FEE_PER_TICKET")
+	os.Setenv("PRICING_FEE_PER_ORDER", "2.00")
+	defer os.Unsetenv("PRICING_FEE_PER_ORDER")
+
+	cfg, err = LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "1.50", cfg.Pricing.FeePerTicket)
+	assert.Equal(t, "2.00", cfg.Pricing.FeePerOrder)
+}
+
+func TestLoadConfig_PaginationConfiguration(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Pagination.TokenSecret)
+
+	os.Setenv("PAGINATION_TOKEN_SECRET", "s3cret")
+	defer os.Unsetenv("PAGINATION_TOKEN_SECRET")
+
+	cfg, err = LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", cfg.Pagination.TokenSecret)
+}
+
+func TestLoadConfig_MigrationConfiguration(t *testing.T) {
+	cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.False(t, cfg.Database.AutoMigrate)
+	assert.Equal(t, "migrations", cfg.Database.MigrationsPath)
+
+	os.Setenv("DATABASE_AUTO_MIGRATE", "true")
+	defer os.Unsetenv("DATABASE_AUTO_MIGRATE")
+
+	cfg, err = LoadConfig()
+	require.NoError(t, err)
+	assert.True(t, cfg.Database.AutoMigrate)
+}
+
 func TestLoadConfig_DefaultValues(t *testing.T) {
 	cleanup := setupTestEnvironment(t)
 	defer cleanup()
@@ -0,0 +1,378 @@
+package handler
+
+import (
+	"context"
+
+	"tickets/api"
+	"tickets/internal/logger"
+	models "tickets/internal/models/domain"
+	"tickets/internal/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// AdminHandler implements the AdminService gRPC interface. Like GRPCHandler it returns
+// service errors unchanged for UnaryErrorInterceptor to convert.
+type AdminHandler struct {
+	api.UnimplementedAdminServiceServer
+	adminService *service.AdminService
+}
+
+// NewAdminHandler creates a new admin gRPC handler
+func NewAdminHandler(adminService *service.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// CreateConcert implements the CreateConcert gRPC method
+func (h *AdminHandler) CreateConcert(ctx context.Context, req *api.CreateConcertRequest) (*api.CreateConcertResponse, error) {
+	logger.WithField("name", req.Name).Info("Creating concert via gRPC")
+
+	concert, err := h.adminService.CreateConcert(&service.CreateConcertRequest{
+		Name:        req.Name,
+		Location:    req.Location,
+		Description: req.Description,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to create concert")
+		return nil, err
+	}
+
+	logger.WithField("concert_id", concert.ID).Info("Concert created successfully via gRPC")
+
+	return &api.CreateConcertResponse{Concert: toAPIConcert(concert)}, nil
+}
+
+// UpdateConcert implements the UpdateConcert gRPC method
+func (h *AdminHandler) UpdateConcert(ctx context.Context, req *api.UpdateConcertRequest) (*api.UpdateConcertResponse, error) {
+	logger.WithField("concert_id", req.ConcertId).Info("Updating concert via gRPC")
+
+	// Validate request
+	if req.ConcertId <= 0 {
+		return nil, service.NewInvalidArgumentError("concert_id", "concert_id must be positive")
+	}
+
+	concert, err := h.adminService.UpdateConcert(&service.UpdateConcertRequest{
+		ConcertID:   int(req.ConcertId),
+		Name:        req.Name,
+		Location:    req.Location,
+		Description: req.Description,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("concert_id", req.ConcertId).Error("Failed to update concert")
+		return nil, err
+	}
+
+	return &api.UpdateConcertResponse{Concert: toAPIConcert(concert)}, nil
+}
+
+// DeleteConcert implements the DeleteConcert gRPC method
+func (h *AdminHandler) DeleteConcert(ctx context.Context, req *api.DeleteConcertRequest) (*api.DeleteConcertResponse, error) {
+	logger.WithField("concert_id", req.ConcertId).Info("Deleting concert via gRPC")
+
+	// Validate request
+	if req.ConcertId <= 0 {
+		return nil, service.NewInvalidArgumentError("concert_id", "concert_id must be positive")
+	}
+
+	if err := h.adminService.DeleteConcert(int(req.ConcertId)); err != nil {
+		logger.WithError(err).WithField("concert_id", req.ConcertId).Error("Failed to delete concert")
+		return nil, err
+	}
+
+	return &api.DeleteConcertResponse{}, nil
+}
+
+// CreateConcertSession implements the CreateConcertSession gRPC method
+func (h *AdminHandler) CreateConcertSession(ctx context.Context, req *api.CreateConcertSessionRequest) (*api.CreateConcertSessionResponse, error) {
+	logger.WithFields(map[string]interface{}{
+		"concert_id":      req.ConcertId,
+		"number_of_seats": req.NumberOfSeats,
+		"sections":        len(req.Sections),
+	}).Info("Creating concert session via gRPC")
+
+	// Validate request
+	if req.ConcertId <= 0 {
+		return nil, service.NewInvalidArgumentError("concert_id", "concert_id must be positive")
+	}
+	if req.StartTime == nil {
+		return nil, service.NewInvalidArgumentError("start_time", "start_time is required")
+	}
+	if req.EndTime == nil {
+		return nil, service.NewInvalidArgumentError("end_time", "end_time is required")
+	}
+	var sections []models.SectionLayout
+	for _, section := range req.Sections {
+		sections = append(sections, models.SectionLayout{
+			Name:        section.Name,
+			Rows:        int(section.Rows),
+			SeatsPerRow: int(section.SeatsPerRow),
+		})
+	}
+
+	session, err := h.adminService.CreateConcertSession(&service.CreateConcertSessionRequest{
+		ConcertID:     int(req.ConcertId),
+		StartTime:     timestampToMillis(req.StartTime),
+		EndTime:       timestampToMillis(req.EndTime),
+		Venue:         req.Venue,
+		NumberOfSeats: int(req.NumberOfSeats),
+		Price:         decimal.NewFromFloat(req.Price),
+		Sections:      sections,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("concert_id", req.ConcertId).Error("Failed to create concert session")
+		return nil, err
+	}
+
+	logger.WithField("session_id", session.ID).Info("Concert session created successfully via gRPC")
+
+	return &api.CreateConcertSessionResponse{Session: toAPIConcertSession(session)}, nil
+}
+
+// UpdateConcertSession implements the UpdateConcertSession gRPC method
+func (h *AdminHandler) UpdateConcertSession(ctx context.Context, req *api.UpdateConcertSessionRequest) (*api.UpdateConcertSessionResponse, error) {
+	logger.WithField("session_id", req.SessionId).Info("Updating concert session via gRPC")
+
+	// Validate request
+	if req.SessionId <= 0 {
+		return nil, service.NewInvalidArgumentError("session_id", "session_id must be positive")
+	}
+	if req.StartTime == nil {
+		return nil, service.NewInvalidArgumentError("start_time", "start_time is required")
+	}
+	if req.EndTime == nil {
+		return nil, service.NewInvalidArgumentError("end_time", "end_time is required")
+	}
+
+	session, err := h.adminService.UpdateConcertSession(&service.UpdateConcertSessionRequest{
+		SessionID: int(req.SessionId),
+		StartTime: timestampToMillis(req.StartTime),
+		EndTime:   timestampToMillis(req.EndTime),
+		Venue:     req.Venue,
+		Price:     decimal.NewFromFloat(req.Price),
+	})
+	if err != nil {
+		logger.WithError(err).WithField("session_id", req.SessionId).Error("Failed to update concert session")
+		return nil, err
+	}
+
+	return &api.UpdateConcertSessionResponse{Session: toAPIConcertSession(session)}, nil
+}
+
+// UpdateSessionCapacity implements the UpdateSessionCapacity gRPC method
+func (h *AdminHandler) UpdateSessionCapacity(ctx context.Context, req *api.UpdateSessionCapacityRequest) (*api.UpdateSessionCapacityResponse, error) {
+	logger.WithFields(map[string]interface{}{
+		"session_id":      req.SessionId,
+		"number_of_seats": req.NumberOfSeats,
+	}).Info("Updating concert session capacity via gRPC")
+
+	// Validate request
+	if req.SessionId <= 0 {
+		return nil, service.NewInvalidArgumentError("session_id", "session_id must be positive")
+	}
+	if req.NumberOfSeats <= 0 {
+		return nil, service.NewInvalidArgumentError("number_of_seats", "number_of_seats must be positive")
+	}
+
+	session, err := h.adminService.UpdateSessionCapacity(&service.UpdateSessionCapacityRequest{
+		SessionID:     int(req.SessionId),
+		NumberOfSeats: int(req.NumberOfSeats),
+	})
+	if err != nil {
+		logger.WithError(err).WithField("session_id", req.SessionId).Error("Failed to update concert session capacity")
+		return nil, err
+	}
+
+	return &api.UpdateSessionCapacityResponse{Session: toAPIConcertSession(session)}, nil
+}
+
+// DeleteConcertSession implements the DeleteConcertSession gRPC method
+func (h *AdminHandler) DeleteConcertSession(ctx context.Context, req *api.DeleteConcertSessionRequest) (*api.DeleteConcertSessionResponse, error) {
+	logger.WithField("session_id", req.SessionId).Info("Deleting concert session via gRPC")
+
+	// Validate request
+	if req.SessionId <= 0 {
+		return nil, service.NewInvalidArgumentError("session_id", "session_id must be positive")
+	}
+
+	if err := h.adminService.DeleteConcertSession(int(req.SessionId)); err != nil {
+		logger.WithError(err).WithField("session_id", req.SessionId).Error("Failed to delete concert session")
+		return nil, err
+	}
+
+	return &api.DeleteConcertSessionResponse{}, nil
+}
+
+// CreateTicketType implements the CreateTicketType gRPC method
+func (h *AdminHandler) CreateTicketType(ctx context.Context, req *api.CreateTicketTypeRequest) (*api.CreateTicketTypeResponse, error) {
+	logger.WithFields(map[string]interface{}{
+		"session_id": req.SessionId,
+		"name":       req.Name,
+	}).Info("Creating ticket type via gRPC")
+
+	// Validate request
+	if req.SessionId <= 0 {
+		return nil, service.NewInvalidArgumentError("session_id", "session_id must be positive")
+	}
+
+	ticketType, err := h.adminService.CreateTicketType(&service.CreateTicketTypeRequest{
+		SessionID:   int(req.SessionId),
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       decimal.NewFromFloat(req.Price),
+		Quota:       int(req.Quota),
+	})
+	if err != nil {
+		logger.WithError(err).WithField("session_id", req.SessionId).Error("Failed to create ticket type")
+		return nil, err
+	}
+
+	logger.WithField("ticket_type_id", ticketType.ID).Info("Ticket type created successfully via gRPC")
+
+	return &api.CreateTicketTypeResponse{TicketType: toAPITicketType(ticketType)}, nil
+}
+
+// UpdateTicketType implements the UpdateTicketType gRPC method
+func (h *AdminHandler) UpdateTicketType(ctx context.Context, req *api.UpdateTicketTypeRequest) (*api.UpdateTicketTypeResponse, error) {
+	logger.WithField("ticket_type_id", req.TicketTypeId).Info("Updating ticket type via gRPC")
+
+	// Validate request
+	if req.TicketTypeId <= 0 {
+		return nil, service.NewInvalidArgumentError("ticket_type_id", "ticket_type_id must be positive")
+	}
+
+	ticketType, err := h.adminService.UpdateTicketType(&service.UpdateTicketTypeRequest{
+		TicketTypeID: int(req.TicketTypeId),
+		Name:         req.Name,
+		Description:  req.Description,
+		Price:        decimal.NewFromFloat(req.Price),
+		Quota:        int(req.Quota),
+	})
+	if err != nil {
+		logger.WithError(err).WithField("ticket_type_id", req.TicketTypeId).Error("Failed to update ticket type")
+		return nil, err
+	}
+
+	return &api.UpdateTicketTypeResponse{TicketType: toAPITicketType(ticketType)}, nil
+}
+
+// DeleteTicketType implements the DeleteTicketType gRPC method
+func (h *AdminHandler) DeleteTicketType(ctx context.Context, req *api.DeleteTicketTypeRequest) (*api.DeleteTicketTypeResponse, error) {
+	logger.WithField("ticket_type_id", req.TicketTypeId).Info("Deleting ticket type via gRPC")
+
+	// Validate request
+	if req.TicketTypeId <= 0 {
+		return nil, service.NewInvalidArgumentError("ticket_type_id", "ticket_type_id must be positive")
+	}
+
+	if err := h.adminService.DeleteTicketType(int(req.TicketTypeId)); err != nil {
+		logger.WithError(err).WithField("ticket_type_id", req.TicketTypeId).Error("Failed to delete ticket type")
+		return nil, err
+	}
+
+	return &api.DeleteTicketTypeResponse{}, nil
+}
+
+// CreatePromoCode implements the CreatePromoCode gRPC method
+func (h *AdminHandler) CreatePromoCode(ctx context.Context, req *api.CreatePromoCodeRequest) (*api.CreatePromoCodeResponse, error) {
+	logger.WithFields(map[string]interface{}{
+		"code":          req.Code,
+		"discount_type": req.DiscountType,
+	}).Info("Creating promo code via gRPC")
+
+	promoCode, err := h.adminService.CreatePromoCode(&service.CreatePromoCodeRequest{
+		Code:                  req.Code,
+		DiscountType:          req.DiscountType,
+		DiscountValue:         decimal.NewFromFloat(req.DiscountValue),
+		ValidFrom:             timestampToMillis(req.ValidFrom),
+		ValidUntil:            timestampToMillis(req.ValidUntil),
+		MaxRedemptions:        int(req.MaxRedemptions),
+		MaxRedemptionsPerUser: int(req.MaxRedemptionsPerUser),
+		ConcertID:             int(req.ConcertId),
+		SessionID:             int(req.SessionId),
+	})
+	if err != nil {
+		logger.WithError(err).WithField("code", req.Code).Error("Failed to create promo code")
+		return nil, err
+	}
+
+	logger.WithField("promo_code_id", promoCode.ID).Info("Promo code created successfully via gRPC")
+
+	return &api.CreatePromoCodeResponse{PromoCode: toAPIPromoCode(promoCode)}, nil
+}
+
+// GetPromoCode implements the GetPromoCode gRPC method
+func (h *AdminHandler) GetPromoCode(ctx context.Context, req *api.GetPromoCodeRequest) (*api.GetPromoCodeResponse, error) {
+	logger.WithField("code", req.Code).Info("Getting promo code via gRPC")
+
+	promoCode, err := h.adminService.GetPromoCode(req.Code)
+	if err != nil {
+		logger.WithError(err).WithField("code", req.Code).Error("Failed to get promo code")
+		return nil, err
+	}
+
+	return &api.GetPromoCodeResponse{PromoCode: toAPIPromoCode(promoCode)}, nil
+}
+
+// DeletePromoCode implements the DeletePromoCode gRPC method
+func (h *AdminHandler) DeletePromoCode(ctx context.Context, req *api.DeletePromoCodeRequest) (*api.DeletePromoCodeResponse, error) {
+	logger.WithField("promo_code_id", req.PromoCodeId).Info("Deleting promo code via gRPC")
+
+	// Validate request
+	if req.PromoCodeId <= 0 {
+		return nil, service.NewInvalidArgumentError("promo_code_id", "promo_code_id must be positive")
+	}
+
+	if err := h.adminService.DeletePromoCode(int(req.PromoCodeId)); err != nil {
+		logger.WithError(err).WithField("promo_code_id", req.PromoCodeId).Error("Failed to delete promo code")
+		return nil, err
+	}
+
+	return &api.DeletePromoCodeResponse{}, nil
+}
+
+// GetLedgerBalances implements the GetLedgerBalances gRPC method
+func (h *AdminHandler) GetLedgerBalances(ctx context.Context, req *api.GetLedgerBalancesRequest) (*api.GetLedgerBalancesResponse, error) {
+	logger.WithField("account", req.Account).Info("Getting ledger balances via gRPC")
+
+	balances, err := h.adminService.GetLedgerBalances(req.Account)
+	if err != nil {
+		logger.WithError(err).WithField("account", req.Account).Error("Failed to get ledger balances")
+		return nil, err
+	}
+
+	resp := &api.GetLedgerBalancesResponse{Balances: make([]*api.AccountBalance, len(balances))}
+	for i, balance := range balances {
+		resp.Balances[i] = &api.AccountBalance{
+			Account: balance.Account,
+			Debits:  balance.Debits.InexactFloat64(),
+			Credits: balance.Credits.InexactFloat64(),
+			Balance: balance.Balance.InexactFloat64(),
+		}
+	}
+	return resp, nil
+}
+
+// CheckLedger implements the CheckLedger gRPC method
+func (h *AdminHandler) CheckLedger(ctx context.Context, req *api.CheckLedgerRequest) (*api.CheckLedgerResponse, error) {
+	logger.Info("Checking ledger via gRPC")
+
+	check, err := h.adminService.CheckLedger()
+	if err != nil {
+		logger.WithError(err).Error("Failed to check ledger")
+		return nil, err
+	}
+	if !check.Balanced() {
+		logger.WithField("unbalanced_transaction_ids", check.UnbalancedTransactionIDs).Error("Ledger does not balance")
+	}
+
+	unbalanced := make([]int32, len(check.UnbalancedTransactionIDs))
+	for i, id := range check.UnbalancedTransactionIDs {
+		unbalanced[i] = int32(id)
+	}
+	return &api.CheckLedgerResponse{
+		Balanced:                 check.Balanced(),
+		TotalDebits:              check.TotalDebits.InexactFloat64(),
+		TotalCredits:             check.TotalCredits.InexactFloat64(),
+		UnbalancedTransactionIds: unbalanced,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"tickets/api"
+	"tickets/internal/repository"
+	"tickets/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// setupTestAdminHandler creates an admin handler backed by the test database
+func setupTestAdminHandler(t *testing.T) (*AdminHandler, func()) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	return NewAdminHandler(service.NewAdminService(service.NewBaseService(baseRepo))), cleanup
+}
+
+func TestAdminHandler_ConcertAndSessionLifecycle(t *testing.T) {
+	handler, cleanup := setupTestAdminHandler(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	concert, err := handler.CreateConcert(ctx, &api.CreateConcertRequest{Name: "Handler Concert", Location: "Handler City"})
+	require.NoError(t, err)
+	require.NotNil(t, concert.Concert)
+	assert.NotZero(t, concert.Concert.Id)
+
+	start := time.Date(2027, 3, 1, 20, 0, 0, 0, time.UTC)
+	session, err := handler.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{
+		ConcertId:     concert.Concert.Id,
+		StartTime:     timestamppb.New(start),
+		EndTime:       timestamppb.New(start.Add(3 * time.Hour)),
+		Venue:         "Handler Hall",
+		NumberOfSeats: 3,
+		Price:         19.99,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), session.Session.NumberOfSeats)
+	assert.Equal(t, int32(3), session.Session.RemainingSeats)
+	assert.Equal(t, 19.99, session.Session.Price)
+	assert.True(t, start.Equal(session.Session.StartTime.AsTime()))
+
+	updated, err := handler.UpdateConcertSession(ctx, &api.UpdateConcertSessionRequest{
+		SessionId: session.Session.Id,
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(start.Add(2 * time.Hour)),
+		Venue:     "Handler Hall",
+		Price:     24.99,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 24.99, updated.Session.Price)
+
+	resized, err := handler.UpdateSessionCapacity(ctx, &api.UpdateSessionCapacityRequest{
+		SessionId:     session.Session.Id,
+		NumberOfSeats: 5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), resized.Session.NumberOfSeats)
+	assert.Equal(t, int32(5), resized.Session.RemainingSeats)
+
+	_, err = handler.DeleteConcert(ctx, &api.DeleteConcertRequest{ConcertId: concert.Concert.Id})
+	st, ok := status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+
+	_, err = handler.DeleteConcertSession(ctx, &api.DeleteConcertSessionRequest{SessionId: session.Session.Id})
+	require.NoError(t, err)
+	_, err = handler.DeleteConcert(ctx, &api.DeleteConcertRequest{ConcertId: concert.Concert.Id})
+	require.NoError(t, err)
+}
+
+func TestAdminHandler_InvalidRequests(t *testing.T) {
+	// Requests rejected before reaching the service need no database
+	handler := NewAdminHandler(nil)
+	ctx := context.Background()
+	now := timestamppb.Now()
+
+	testCases := []struct {
+		name  string
+		call  func() error
+		field string
+	}{
+		{"update concert without id", func() error {
+			_, err := handler.UpdateConcert(ctx, &api.UpdateConcertRequest{Name: "x", Location: "y"})
+			return err
+		}, "concert_id"},
+		{"delete concert without id", func() error {
+			_, err := handler.DeleteConcert(ctx, &api.DeleteConcertRequest{})
+			return err
+		}, "concert_id"},
+		{"create session without concert", func() error {
+			_, err := handler.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{StartTime: now, EndTime: now})
+			return err
+		}, "concert_id"},
+		{"create session without start", func() error {
+			_, err := handler.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{ConcertId: 1, EndTime: now})
+			return err
+		}, "start_time"},
+		{"create session without end", func() error {
+			_, err := handler.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{ConcertId: 1, StartTime: now})
+			return err
+		}, "end_time"},
+		{"update session without id", func() error {
+			_, err := handler.UpdateConcertSession(ctx, &api.UpdateConcertSessionRequest{StartTime: now, EndTime: now})
+			return err
+		}, "session_id"},
+		{"capacity without session", func() error {
+			_, err := handler.UpdateSessionCapacity(ctx, &api.UpdateSessionCapacityRequest{NumberOfSeats: 1})
+			return err
+		}, "session_id"},
+		{"capacity without seats", func() error {
+			_, err := handler.UpdateSessionCapacity(ctx, &api.UpdateSessionCapacityRequest{SessionId: 1})
+			return err
+		}, "number_of_seats"},
+		{"delete session without id", func() error {
+			_, err := handler.DeleteConcertSession(ctx, &api.DeleteConcertSessionRequest{})
+			return err
+		}, "session_id"},
+		{"create ticket type without session", func() error {
+			_, err := handler.CreateTicketType(ctx, &api.CreateTicketTypeRequest{Name: "VIP", Price: 1, Quota: 1})
+			return err
+		}, "session_id"},
+		{"update ticket type without id", func() error {
+			_, err := handler.UpdateTicketType(ctx, &api.UpdateTicketTypeRequest{Name: "VIP", Price: 1, Quota: 1})
+			return err
+		}, "ticket_type_id"},
+		{"delete ticket type without id", func() error {
+			_, err := handler.DeleteTicketType(ctx, &api.DeleteTicketTypeRequest{})
+			return err
+		}, "ticket_type_id"},
+		{"delete promo code without id", func() error {
+			_, err := handler.DeletePromoCode(ctx, &api.DeletePromoCodeRequest{})
+			return err
+		}, "promo_code_id"},
+		{"unknown ledger account", func() error {
+			_, err := handler.GetLedgerBalances(ctx, &api.GetLedgerBalancesRequest{Account: "petty_cash"})
+			return err
+		}, "account"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := toStatus(tc.call())
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			assert.Contains(t, st.Message(), tc.field)
+		})
+	}
+}
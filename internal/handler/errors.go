@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"tickets/internal/logger"
+	"tickets/internal/service"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifies this service in google.rpc.ErrorInfo details
+const errorDomain = "tickets"
+
+// UnaryErrorInterceptor converts errors returned by handlers into gRPC statuses
+func UnaryErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		st := toStatus(err)
+		if st.Code() == codes.Internal {
+			logger.WithError(err).WithField("method", info.FullMethod).Error("Unhandled error in gRPC handler")
+		}
+		return nil, st.Err()
+	}
+	return resp, nil
+}
+
+// toStatus maps an error to a gRPC status. Classified service errors carry ErrorInfo, BadRequest
+// details for request fields and PreconditionFailure details for itemised violations; statuses
+// pass through; anything else is Internal.
+func toStatus(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	var svcErr *service.Error
+	if !errors.As(err, &svcErr) {
+		return status.New(codes.Internal, "internal error")
+	}
+
+	st := status.New(errorCode(svcErr), svcErr.Message)
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: svcErr.Reason, Domain: errorDomain}}
+	if svcErr.Field != "" {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: svcErr.Field, Description: svcErr.Message},
+			},
+		})
+	}
+	if len(svcErr.Violations) > 0 {
+		violations := make([]*errdetails.PreconditionFailure_Violation, len(svcErr.Violations))
+		for i, v := range svcErr.Violations {
+			violations[i] = &errdetails.PreconditionFailure_Violation{
+				Type: svcErr.Reason, Subject: v.Subject, Description: v.Description,
+			}
+		}
+		details = append(details, &errdetails.PreconditionFailure{Violations: violations})
+	}
+
+	withDetails, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		return st
+	}
+	return withDetails
+}
+
+// errorCode returns the gRPC code for a service error kind
+func errorCode(err *service.Error) codes.Code {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, service.ErrInvalidArgument), errors.Is(err, service.ErrLimitExceeded):
+		return codes.InvalidArgument
+	case errors.Is(err, service.ErrSoldOut):
+		return codes.ResourceExhausted
+	case errors.Is(err, service.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, service.ErrFailedPrecondition):
+		return codes.FailedPrecondition
+	case errors.Is(err, service.ErrUnavailable):
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"tickets/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus_ServiceErrors(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{service.ErrOrderNotFound, codes.NotFound},
+		{service.ErrConcertSessionNotFound, codes.NotFound},
+		{service.ErrInvalidOrderID, codes.InvalidArgument},
+		{service.ErrTicketLimitExceeded, codes.InvalidArgument},
+		{service.ErrNoTicketsAvailable, codes.ResourceExhausted},
+		{service.ErrIdempotencyKeyReused, codes.AlreadyExists},
+		{service.ErrOrderExpired, codes.FailedPrecondition},
+		{service.ErrOrderNotCancellable, codes.FailedPrecondition},
+		{service.ErrPaymentUnavailable, codes.Unavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			st := toStatus(tc.err)
+			assert.Equal(t, tc.code, st.Code())
+			assert.Equal(t, tc.err.Error(), st.Message())
+		})
+	}
+}
+
+func TestToStatus_Details(t *testing.T) {
+	st := toStatus(service.ErrTicketLimitExceeded)
+
+	var info *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+
+	require.NotNil(t, info)
+	assert.Equal(t, "TICKET_LIMIT_EXCEEDED", info.Reason)
+	assert.Equal(t, "tickets", info.Domain)
+
+	require.NotNil(t, badRequest)
+	require.Len(t, badRequest.FieldViolations, 1)
+	assert.Equal(t, "number_of_tickets", badRequest.FieldViolations[0].Field)
+
+	// Errors without a field only carry ErrorInfo
+	st = toStatus(service.ErrOrderNotFound)
+	require.Len(t, st.Details(), 1)
+	assert.IsType(t, &errdetails.ErrorInfo{}, st.Details()[0])
+}
+
+func TestToStatus_PreconditionFailure(t *testing.T) {
+	err := &service.Error{
+		Kind:    service.ErrFailedPrecondition,
+		Reason:  "SEATS_UNAVAILABLE",
+		Field:   "seat_ids",
+		Message: "requested seats are not available: seat Stalls A1 is sold",
+		Violations: []service.Violation{
+			{Subject: "0b9c6f1e-5d43-4c2a-9a4e-2f6f0d7b8c11", Description: "seat Stalls A1 is sold"},
+		},
+	}
+	st := toStatus(fmt.Errorf("creating order: %w", err))
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+
+	var failure *errdetails.PreconditionFailure
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.PreconditionFailure); ok {
+			failure = d
+		}
+	}
+	require.NotNil(t, failure)
+	require.Len(t, failure.Violations, 1)
+	assert.Equal(t, "SEATS_UNAVAILABLE", failure.Violations[0].Type)
+	assert.Equal(t, "0b9c6f1e-5d43-4c2a-9a4e-2f6f0d7b8c11", failure.Violations[0].Subject)
+	assert.Equal(t, "seat Stalls A1 is sold", failure.Violations[0].Description)
+}
+
+func TestToStatus_WrappedAndUnknownErrors(t *testing.T) {
+	wrapped := fmt.Errorf("confirming order 7: %w", service.ErrOrderExpired)
+	st := toStatus(wrapped)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Equal(t, "order has expired", st.Message())
+
+	// Existing statuses pass through untouched
+	st = toStatus(status.Error(codes.Unimplemented, "not implemented"))
+	assert.Equal(t, codes.Unimplemented, st.Code())
+
+	// Unclassified errors do not leak their message
+	st = toStatus(errors.New("pq: connection refused"))
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "internal error", st.Message())
+}
+
+func TestUnaryErrorInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/tickets.TicketsService/GetOrder"}
+
+	resp, err := UnaryErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, service.ErrOrderNotFound
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	resp, err = UnaryErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
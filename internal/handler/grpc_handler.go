@@ -6,24 +6,29 @@ import (
 
 	"tickets/api"
 	"tickets/internal/logger"
+	models "tickets/internal/models/domain"
 	"tickets/internal/service"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// GRPCHandler implements the TicketsService gRPC interface
+// GRPCHandler implements the TicketsService gRPC interface. Methods return service errors
+// unchanged; UnaryErrorInterceptor converts them to gRPC statuses.
 type GRPCHandler struct {
 	api.UnimplementedTicketsServiceServer
-	orderService *service.OrderService
+	orderService   *service.OrderService
+	concertService *service.ConcertService
 	// Add other services as needed
 }
 
 // NewGRPCHandler creates a new gRPC handler
-func NewGRPCHandler(orderService *service.OrderService) *GRPCHandler {
+func NewGRPCHandler(orderService *service.OrderService, concertService *service.ConcertService) *GRPCHandler {
 	return &GRPCHandler{
-		orderService: orderService,
+		orderService:   orderService,
+		concertService: concertService,
 	}
 }
 
@@ -33,20 +38,43 @@ func (h *GRPCHandler) CreateOrder(ctx context.Context, req *api.CreateOrderReque
 		"user_id":            req.UserId,
 		"concert_session_id": req.ConcertSessionId,
 		"number_of_tickets":  req.NumberOfTickets,
+		"seats":              len(req.SeatIds),
+		"ticket_types":       len(req.TicketTypes),
 	}).Info("Creating order via gRPC")
 
 	// Validate request
 	if req.UserId <= 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "user_id must be positive")
+		return nil, service.NewInvalidArgumentError("user_id", "user_id must be positive")
 	}
 	if req.ConcertSessionId <= 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "concert_session_id must be positive")
+		return nil, service.NewInvalidArgumentError("concert_session_id", "concert_session_id must be positive")
 	}
-	if req.NumberOfTickets <= 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "number_of_tickets must be positive")
+	// number_of_tickets may be omitted when seats or ticket types are picked
+	if req.NumberOfTickets < 0 || (req.NumberOfTickets == 0 && len(req.SeatIds) == 0 && len(req.TicketTypes) == 0) {
+		return nil, service.NewInvalidArgumentError("number_of_tickets", "number_of_tickets must be positive")
 	}
-	if req.NumberOfTickets > 3 {
-		return nil, status.Errorf(codes.InvalidArgument, "maximum 3 tickets allowed per order")
+	ticketTypes := make([]service.TicketSelection, len(req.TicketTypes))
+	quantity := 0
+	for i, selection := range req.TicketTypes {
+		if selection.GetTicketTypeId() <= 0 || selection.GetQuantity() <= 0 {
+			return nil, service.NewInvalidArgumentError("ticket_types", "ticket_types must have positive ticket_type_id and quantity")
+		}
+		ticketTypes[i] = service.TicketSelection{
+			TicketTypeID: int(selection.TicketTypeId),
+			Quantity:     int(selection.Quantity),
+		}
+		quantity += int(selection.Quantity)
+	}
+	if req.NumberOfTickets > 3 || len(req.SeatIds) > 3 || quantity > 3 {
+		return nil, service.ErrTicketLimitExceeded
+	}
+	seatIDs := make([]uuid.UUID, len(req.SeatIds))
+	for i, id := range req.SeatIds {
+		seatID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, service.NewInvalidArgumentError("seat_ids", "seat_ids must be ticket UUIDs")
+		}
+		seatIDs[i] = seatID
 	}
 
 	// Convert gRPC request to service request
@@ -54,6 +82,12 @@ func (h *GRPCHandler) CreateOrder(ctx context.Context, req *api.CreateOrderReque
 		UserID:           int(req.UserId),
 		ConcertSessionID: int(req.ConcertSessionId),
 		NumberOfTickets:  int(req.NumberOfTickets),
+		IdempotencyKey:   idempotencyKey(ctx, req),
+		SeatIDs:          seatIDs,
+		TicketTypes:      ticketTypes,
+		PromoCode:        req.PromoCode,
+
+		AllowSplitSeating: req.AllowSplitSeating,
 	}
 
 	// Call service layer
@@ -63,22 +97,7 @@ func (h *GRPCHandler) CreateOrder(ctx context.Context, req *api.CreateOrderReque
 			"user_id":            req.UserId,
 			"concert_session_id": req.ConcertSessionId,
 		}).Error("Failed to create order")
-
-		// Convert service errors to gRPC status codes
-		switch err.Error() {
-		case "concert session not found":
-			return nil, status.Errorf(codes.NotFound, "concert session not found")
-		case "no tickets available":
-			return nil, status.Errorf(codes.ResourceExhausted, "no tickets available")
-		case "request cannot be nil":
-			return nil, status.Errorf(codes.InvalidArgument, "request cannot be nil")
-		case "number of tickets must be greater than 0":
-			return nil, status.Errorf(codes.InvalidArgument, "number_of_tickets must be positive")
-		case "maximum 3 tickets allowed per order":
-			return nil, status.Errorf(codes.InvalidArgument, "maximum 3 tickets allowed per order")
-		default:
-			return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
-		}
+		return nil, err
 	}
 
 	// Convert service response to gRPC response
@@ -87,7 +106,16 @@ func (h *GRPCHandler) CreateOrder(ctx context.Context, req *api.CreateOrderReque
 		Status:     serviceResp.Status,
 		TicketIds:  serviceResp.TicketIDs,
 		TotalPrice: float64(serviceResp.TotalPrice.InexactFloat64()),
-		CreatedAt:  timestamppb.New(time.Unix(serviceResp.CreatedAt/1000, 0)),
+		CreatedAt:  millisToTimestamp(serviceResp.CreatedAt),
+		ExpiresAt:  millisToTimestamp(serviceResp.ExpiresAt),
+		Subtotal:   serviceResp.Subtotal.InexactFloat64(),
+		Discount:   serviceResp.Discount.InexactFloat64(),
+		PromoCode:  serviceResp.PromoCode,
+
+		ServiceFee:      serviceResp.ServiceFee.InexactFloat64(),
+		Tax:             serviceResp.Tax.InexactFloat64(),
+		TaxRate:         serviceResp.TaxRate.InexactFloat64(),
+		TaxJurisdiction: serviceResp.TaxJurisdiction,
 	}
 
 	logger.WithFields(map[string]interface{}{
@@ -100,31 +128,580 @@ func (h *GRPCHandler) CreateOrder(ctx context.Context, req *api.CreateOrderReque
 }
 
 // GetOrder implements the GetOrder gRPC method
-// NOTE: This method is intentionally unimplemented as it's not part of the current scope
 func (h *GRPCHandler) GetOrder(ctx context.Context, req *api.GetOrderRequest) (*api.GetOrderResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "GetOrder not implemented")
+	logger.WithField("order_id", req.OrderId).Info("Getting order via gRPC")
+
+	// Validate request
+	if req.OrderId <= 0 {
+		return nil, service.NewInvalidArgumentError("order_id", "order_id must be positive")
+	}
+
+	// Call service layer
+	order, err := h.orderService.GetOrder(int(req.OrderId))
+	if err != nil {
+		logger.WithError(err).WithField("order_id", req.OrderId).Error("Failed to get order")
+		return nil, err
+	}
+
+	return &api.GetOrderResponse{Order: toAPIOrder(order)}, nil
 }
 
 // ListOrders implements the ListOrders gRPC method
-// NOTE: This method is intentionally unimplemented as it's not part of the current scope
 func (h *GRPCHandler) ListOrders(ctx context.Context, req *api.ListOrdersRequest) (*api.ListOrdersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "ListOrders not implemented")
+	logger.WithFields(map[string]interface{}{
+		"user_id":   req.UserId,
+		"page":      req.Page,
+		"page_size": req.PageSize,
+	}).Info("Listing orders via gRPC")
+
+	// Validate request
+	if req.UserId <= 0 {
+		return nil, service.NewInvalidArgumentError("user_id", "user_id must be positive")
+	}
+	if req.Page < 0 {
+		return nil, service.NewInvalidArgumentError("page", "page must not be negative")
+	}
+	if req.PageSize < 0 {
+		return nil, service.NewInvalidArgumentError("page_size", "page_size must not be negative")
+	}
+
+	// Call service layer
+	serviceResp, err := h.orderService.ListOrders(&service.ListOrdersRequest{
+		UserID:    int(req.UserId),
+		Page:      int(req.Page),
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("user_id", req.UserId).Error("Failed to list orders")
+		return nil, err
+	}
+
+	orders := make([]*api.Order, len(serviceResp.Orders))
+	for i := range serviceResp.Orders {
+		orders[i] = toAPIOrder(&serviceResp.Orders[i])
+	}
+
+	return &api.ListOrdersResponse{
+		Orders:        orders,
+		TotalCount:    int32(serviceResp.TotalCount),
+		Page:          int32(serviceResp.Page),
+		PageSize:      int32(serviceResp.PageSize),
+		NextPageToken: serviceResp.NextPageToken,
+	}, nil
+}
+
+// ConfirmOrder implements the ConfirmOrder gRPC method
+func (h *GRPCHandler) ConfirmOrder(ctx context.Context, req *api.ConfirmOrderRequest) (*api.ConfirmOrderResponse, error) {
+	logger.WithField("order_id", req.OrderId).Info("Confirming order via gRPC")
+
+	// Validate request
+	if req.OrderId <= 0 {
+		return nil, service.NewInvalidArgumentError("order_id", "order_id must be positive")
+	}
+
+	// Call service layer
+	order, err := h.orderService.ConfirmOrder(int(req.OrderId))
+	if err != nil {
+		logger.WithError(err).WithField("order_id", req.OrderId).Error("Failed to confirm order")
+		return nil, err
+	}
+
+	logger.WithFields(map[string]interface{}{
+		"order_id": order.ID,
+		"status":   order.Status,
+	}).Info("Order confirmed successfully via gRPC")
+
+	return &api.ConfirmOrderResponse{Order: toAPIOrder(order)}, nil
+}
+
+// CancelOrder implements the CancelOrder gRPC method
+func (h *GRPCHandler) CancelOrder(ctx context.Context, req *api.CancelOrderRequest) (*api.CancelOrderResponse, error) {
+	logger.WithField("order_id", req.OrderId).Info("Cancelling order via gRPC")
+
+	// Validate request
+	if req.OrderId <= 0 {
+		return nil, service.NewInvalidArgumentError("order_id", "order_id must be positive")
+	}
+
+	// Call service layer
+	order, err := h.orderService.CancelOrder(ctx, int(req.OrderId), req.Reason)
+	if err != nil {
+		logger.WithError(err).WithField("order_id", req.OrderId).Error("Failed to cancel order")
+		return nil, err
+	}
+
+	logger.WithFields(map[string]interface{}{
+		"order_id": order.ID,
+		"status":   order.Status,
+	}).Info("Order cancelled successfully via gRPC")
+
+	return &api.CancelOrderResponse{Order: toAPIOrder(order)}, nil
+}
+
+// PayOrder implements the PayOrder gRPC method
+func (h *GRPCHandler) PayOrder(ctx context.Context, req *api.PayOrderRequest) (*api.PayOrderResponse, error) {
+	logger.WithField("order_id", req.OrderId).Info("Paying order via gRPC")
+
+	// Validate request
+	if req.OrderId <= 0 {
+		return nil, service.NewInvalidArgumentError("order_id", "order_id must be positive")
+	}
+	if req.PaymentMethod == "" {
+		return nil, service.NewInvalidArgumentError("payment_method", "payment_method is required")
+	}
+
+	// Call service layer
+	resp, err := h.orderService.PayOrder(ctx, &service.PayOrderRequest{
+		OrderID:       int(req.OrderId),
+		PaymentMethod: req.PaymentMethod,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("order_id", req.OrderId).Error("Failed to pay order")
+		return nil, err
+	}
+
+	logger.WithFields(map[string]interface{}{
+		"order_id": resp.Order.ID,
+		"status":   resp.Order.Status,
+	}).Info("Order paid successfully via gRPC")
+
+	apiResp := &api.PayOrderResponse{Order: toAPIOrder(resp.Order)}
+	if resp.Payment != nil {
+		apiResp.Payment = toAPIPayment(resp.Payment)
+	}
+	return apiResp, nil
+}
+
+// RefundOrder implements the RefundOrder gRPC method
+func (h *GRPCHandler) RefundOrder(ctx context.Context, req *api.RefundOrderRequest) (*api.RefundOrderResponse, error) {
+	logger.WithFields(map[string]interface{}{
+		"order_id":    req.OrderId,
+		"refunded_by": req.RefundedBy,
+	}).Info("Refunding order via gRPC")
+
+	// Validate request
+	if req.OrderId <= 0 {
+		return nil, service.NewInvalidArgumentError("order_id", "order_id must be positive")
+	}
+
+	// Call service layer
+	resp, err := h.orderService.RefundOrder(ctx, &service.RefundOrderRequest{
+		OrderID:    int(req.OrderId),
+		Reason:     req.Reason,
+		RefundedBy: req.RefundedBy,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("order_id", req.OrderId).Error("Failed to refund order")
+		return nil, err
+	}
+
+	logger.WithFields(map[string]interface{}{
+		"order_id":  resp.Order.ID,
+		"refund_id": resp.Refund.ID,
+		"amount":    resp.Refund.Amount.String(),
+	}).Info("Order refunded successfully via gRPC")
+
+	return &api.RefundOrderResponse{
+		Order:  toAPIOrder(resp.Order),
+		Refund: toAPIRefund(resp.Refund),
+	}, nil
+}
+
+// RefundTickets implements the RefundTickets gRPC method
+func (h *GRPCHandler) RefundTickets(ctx context.Context, req *api.RefundTicketsRequest) (*api.RefundTicketsResponse, error) {
+	logger.WithFields(map[string]interface{}{
+		"order_id":    req.OrderId,
+		"tickets":     len(req.TicketIds),
+		"refunded_by": req.RefundedBy,
+	}).Info("Refunding tickets via gRPC")
+
+	// Validate request
+	if req.OrderId <= 0 {
+		return nil, service.NewInvalidArgumentError("order_id", "order_id must be positive")
+	}
+	ticketIDs := make([]uuid.UUID, len(req.TicketIds))
+	for i, id := range req.TicketIds {
+		ticketID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, service.NewInvalidArgumentError("ticket_ids", "ticket_ids must be ticket UUIDs")
+		}
+		ticketIDs[i] = ticketID
+	}
+
+	// Call service layer
+	resp, err := h.orderService.RefundTickets(ctx, &service.RefundTicketsRequest{
+		OrderID:    int(req.OrderId),
+		TicketIDs:  ticketIDs,
+		Reason:     req.Reason,
+		RefundedBy: req.RefundedBy,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("order_id", req.OrderId).Error("Failed to refund tickets")
+		return nil, err
+	}
+
+	logger.WithFields(map[string]interface{}{
+		"order_id":  resp.Order.ID,
+		"refund_id": resp.Refund.ID,
+		"amount":    resp.Refund.Amount.String(),
+	}).Info("Tickets refunded successfully via gRPC")
+
+	return &api.RefundTicketsResponse{
+		Order:  toAPIOrder(resp.Order),
+		Refund: toAPIRefund(resp.Refund),
+	}, nil
 }
 
 // GetConcertSession implements the GetConcertSession gRPC method
-// NOTE: This method is intentionally unimplemented as it's not part of the current scope
 func (h *GRPCHandler) GetConcertSession(ctx context.Context, req *api.GetConcertSessionRequest) (*api.GetConcertSessionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "GetConcertSession not implemented")
+	logger.WithField("session_id", req.SessionId).Info("Getting concert session via gRPC")
+
+	// Validate request
+	if req.SessionId <= 0 {
+		return nil, service.NewInvalidArgumentError("session_id", "session_id must be positive")
+	}
+
+	// Call service layer
+	session, err := h.concertService.GetConcertSession(int(req.SessionId))
+	if err != nil {
+		logger.WithError(err).WithField("session_id", req.SessionId).Error("Failed to get concert session")
+		return nil, err
+	}
+
+	return &api.GetConcertSessionResponse{Session: toAPIConcertSession(session)}, nil
 }
 
 // ListConcertSessions implements the ListConcertSessions gRPC method
-// NOTE: This method is intentionally unimplemented as it's not part of the current scope
 func (h *GRPCHandler) ListConcertSessions(ctx context.Context, req *api.ListConcertSessionsRequest) (*api.ListConcertSessionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "ListConcertSessions not implemented")
+	logger.WithFields(map[string]interface{}{
+		"page":      req.Page,
+		"page_size": req.PageSize,
+	}).Info("Listing concert sessions via gRPC")
+
+	// Validate request
+	if req.Page < 0 {
+		return nil, service.NewInvalidArgumentError("page", "page must not be negative")
+	}
+	if req.PageSize < 0 {
+		return nil, service.NewInvalidArgumentError("page_size", "page_size must not be negative")
+	}
+
+	// Call service layer
+	serviceResp, err := h.concertService.ListConcertSessions(&service.ListConcertSessionsRequest{
+		Page:          int(req.Page),
+		PageSize:      int(req.PageSize),
+		PageToken:     req.PageToken,
+		StartTimeFrom: timestampToMillis(req.StartTimeFrom),
+		StartTimeTo:   timestampToMillis(req.StartTimeTo),
+		Venue:         req.Venue,
+		Location:      req.Location,
+		MinPrice:      decimal.NewFromFloat(req.MinPrice),
+		MaxPrice:      decimal.NewFromFloat(req.MaxPrice),
+		OnlyAvailable: req.OnlyAvailable,
+		OrderBy:       req.OrderBy,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to list concert sessions")
+		return nil, err
+	}
+
+	sessions := make([]*api.ConcertSession, len(serviceResp.Sessions))
+	for i := range serviceResp.Sessions {
+		sessions[i] = toAPIConcertSession(&serviceResp.Sessions[i])
+	}
+
+	return &api.ListConcertSessionsResponse{
+		Sessions:      sessions,
+		TotalCount:    int32(serviceResp.TotalCount),
+		Page:          int32(serviceResp.Page),
+		PageSize:      int32(serviceResp.PageSize),
+		NextPageToken: serviceResp.NextPageToken,
+	}, nil
 }
 
 // GetAvailableTickets implements the GetAvailableTickets gRPC method
-// NOTE: This method is intentionally unimplemented as it's not part of the current scope
 func (h *GRPCHandler) GetAvailableTickets(ctx context.Context, req *api.GetAvailableTicketsRequest) (*api.GetAvailableTicketsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "GetAvailableTickets not implemented")
+	logger.WithFields(map[string]interface{}{
+		"session_id": req.SessionId,
+		"limit":      req.Limit,
+	}).Info("Getting available tickets via gRPC")
+
+	// Validate request
+	if req.SessionId <= 0 {
+		return nil, service.NewInvalidArgumentError("session_id", "session_id must be positive")
+	}
+	if req.Limit < 0 {
+		return nil, service.NewInvalidArgumentError("limit", "limit must not be negative")
+	}
+
+	// Call service layer
+	serviceResp, err := h.concertService.GetAvailableTickets(&service.GetAvailableTicketsRequest{
+		SessionID: int(req.SessionId),
+		Limit:     int(req.Limit),
+	})
+	if err != nil {
+		logger.WithError(err).WithField("session_id", req.SessionId).Error("Failed to get available tickets")
+		return nil, err
+	}
+
+	tickets := make([]*api.Ticket, len(serviceResp.Tickets))
+	for i := range serviceResp.Tickets {
+		tickets[i] = toAPITicket(&serviceResp.Tickets[i])
+	}
+
+	return &api.GetAvailableTicketsResponse{
+		Tickets:        tickets,
+		TotalAvailable: int32(serviceResp.TotalAvailable),
+	}, nil
+}
+
+// GetSeatMap implements the GetSeatMap gRPC method
+func (h *GRPCHandler) GetSeatMap(ctx context.Context, req *api.GetSeatMapRequest) (*api.GetSeatMapResponse, error) {
+	logger.WithFields(map[string]interface{}{
+		"session_id": req.SessionId,
+		"section":    req.Section,
+	}).Info("Getting seat map via gRPC")
+
+	// Validate request
+	if req.SessionId <= 0 {
+		return nil, service.NewInvalidArgumentError("session_id", "session_id must be positive")
+	}
+
+	// Call service layer
+	serviceResp, err := h.concertService.GetSeatMap(&service.GetSeatMapRequest{
+		SessionID: int(req.SessionId),
+		Section:   req.Section,
+	})
+	if err != nil {
+		logger.WithError(err).WithField("session_id", req.SessionId).Error("Failed to get seat map")
+		return nil, err
+	}
+
+	seats := make([]*api.Ticket, len(serviceResp.Seats))
+	for i := range serviceResp.Seats {
+		seats[i] = toAPITicket(&serviceResp.Seats[i])
+	}
+
+	return &api.GetSeatMapResponse{
+		SessionId: int32(serviceResp.SessionID),
+		Seats:     seats,
+		Available: int32(serviceResp.Available),
+	}, nil
+}
+
+// ListTicketTypes implements the ListTicketTypes gRPC method
+func (h *GRPCHandler) ListTicketTypes(ctx context.Context, req *api.ListTicketTypesRequest) (*api.ListTicketTypesResponse, error) {
+	logger.WithField("session_id", req.SessionId).Info("Listing ticket types via gRPC")
+
+	// Validate request
+	if req.SessionId <= 0 {
+		return nil, service.NewInvalidArgumentError("session_id", "session_id must be positive")
+	}
+
+	// Call service layer
+	ticketTypes, err := h.concertService.ListTicketTypes(int(req.SessionId))
+	if err != nil {
+		logger.WithError(err).WithField("session_id", req.SessionId).Error("Failed to list ticket types")
+		return nil, err
+	}
+
+	resp := &api.ListTicketTypesResponse{
+		TicketTypes: make([]*api.TicketType, len(ticketTypes)),
+	}
+	for i := range ticketTypes {
+		resp.TicketTypes[i] = toAPITicketType(&ticketTypes[i])
+	}
+
+	return resp, nil
+}
+
+// idempotencyKeyHeader is the metadata header clients may use instead of CreateOrderRequest.idempotency_key
+const idempotencyKeyHeader = "idempotency-key"
+
+// idempotencyKey returns the request's idempotency key, falling back to the metadata header
+func idempotencyKey(ctx context.Context, req *api.CreateOrderRequest) string {
+	if req.IdempotencyKey != "" {
+		return req.IdempotencyKey
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(idempotencyKeyHeader); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// toAPIOrder converts a domain order and its items to the gRPC message
+func toAPIOrder(order *models.Order) *api.Order {
+	items := make([]*api.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &api.OrderItem{
+			Id:       int32(item.ID),
+			TicketId: item.TicketID.String(),
+			Price:    item.Price.InexactFloat64(),
+
+			TicketTypeId: int32(item.TicketTypeID),
+			RefundId:     int32(item.RefundID),
+		}
+		if item.Ticket != nil {
+			items[i].Ticket = toAPITicket(item.Ticket)
+		}
+	}
+
+	resp := &api.Order{
+		Id:         int32(order.ID),
+		UserId:     int32(order.UserID),
+		Status:     order.Status,
+		TotalPrice: order.TotalPrice.InexactFloat64(),
+		CreatedAt:  millisToTimestamp(order.CreatedAt),
+		Items:      items,
+		Subtotal:   order.Subtotal.InexactFloat64(),
+		Discount:   order.Discount.InexactFloat64(),
+		PromoCode:  order.PromoCode,
+
+		CancellationReason: order.CancellationReason,
+		RefundedAmount:     order.RefundedAmount.InexactFloat64(),
+		ServiceFee:         order.ServiceFee.InexactFloat64(),
+		Tax:                order.Tax.InexactFloat64(),
+		TaxRate:            order.TaxRate.InexactFloat64(),
+		TaxJurisdiction:    order.TaxJurisdiction,
+	}
+	if order.ExpiresAt > 0 {
+		resp.ExpiresAt = millisToTimestamp(order.ExpiresAt)
+	}
+	if order.CancelledAt > 0 {
+		resp.CancelledAt = millisToTimestamp(order.CancelledAt)
+	}
+
+	return resp
+}
+
+// toAPITicket converts a domain ticket, with its seat if it has one, to the gRPC message
+func toAPITicket(ticket *models.Ticket) *api.Ticket {
+	return &api.Ticket{
+		Id:         ticket.ID.String(),
+		SessionId:  int32(ticket.SessionID),
+		Status:     ticket.Status,
+		Section:    ticket.Section,
+		Row:        ticket.Row,
+		SeatNumber: int32(ticket.SeatNumber),
+		TicketType: ticket.TicketType,
+	}
+}
+
+// toAPITicketType converts a domain ticket type to the gRPC message
+func toAPITicketType(ticketType *models.TicketType) *api.TicketType {
+	return &api.TicketType{
+		Id:          int32(ticketType.ID),
+		SessionId:   int32(ticketType.SessionID),
+		Name:        ticketType.Name,
+		Description: ticketType.Description,
+		Price:       ticketType.Price.InexactFloat64(),
+		Quota:       int32(ticketType.Quota),
+		Remaining:   int32(ticketType.Remaining),
+	}
+}
+
+// toAPIConcertSession converts a domain concert session and its concert to the gRPC message
+func toAPIConcertSession(session *models.ConcertSession) *api.ConcertSession {
+	resp := &api.ConcertSession{
+		Id:             int32(session.ID),
+		ConcertId:      int32(session.ConcertID),
+		StartTime:      millisToTimestamp(session.StartTime),
+		EndTime:        millisToTimestamp(session.EndTime),
+		Venue:          session.Venue,
+		NumberOfSeats:  int32(session.NumberOfSeats),
+		Price:          session.Price.InexactFloat64(),
+		RemainingSeats: int32(session.RemainingSeats),
+	}
+	if session.Concert != nil {
+		resp.Concert = toAPIConcert(session.Concert)
+	}
+
+	return resp
+}
+
+// toAPIConcert converts a domain concert to its gRPC representation
+func toAPIConcert(concert *models.Concert) *api.Concert {
+	return &api.Concert{
+		Id:          int32(concert.ID),
+		Name:        concert.Name,
+		Location:    concert.Location,
+		Description: concert.Description,
+		CreatedAt:   millisToTimestamp(concert.CreatedAt),
+	}
+}
+
+// toAPIPromoCode converts a domain promo code to the gRPC message
+func toAPIPromoCode(promoCode *models.PromoCode) *api.PromoCode {
+	resp := &api.PromoCode{
+		Id:                    int32(promoCode.ID),
+		Code:                  promoCode.Code,
+		DiscountType:          promoCode.DiscountType,
+		DiscountValue:         promoCode.DiscountValue.InexactFloat64(),
+		MaxRedemptions:        int32(promoCode.MaxRedemptions),
+		MaxRedemptionsPerUser: int32(promoCode.MaxRedemptionsPerUser),
+		ConcertId:             int32(promoCode.ConcertID),
+		SessionId:             int32(promoCode.SessionID),
+		Redemptions:           int32(promoCode.Redemptions),
+		CreatedAt:             millisToTimestamp(promoCode.CreatedAt),
+	}
+	if promoCode.ValidFrom > 0 {
+		resp.ValidFrom = millisToTimestamp(promoCode.ValidFrom)
+	}
+	if promoCode.ValidUntil > 0 {
+		resp.ValidUntil = millisToTimestamp(promoCode.ValidUntil)
+	}
+
+	return resp
+}
+
+// toAPIPayment converts a domain payment to the gRPC message
+func toAPIPayment(payment *models.Payment) *api.Payment {
+	return &api.Payment{
+		Id:                int32(payment.ID),
+		OrderId:           int32(payment.OrderID),
+		Provider:          payment.Provider,
+		ProviderReference: payment.ProviderReference,
+		Amount:            payment.Amount.InexactFloat64(),
+		Status:            payment.Status,
+		FailureCode:       payment.FailureCode,
+		FailureMessage:    payment.FailureMessage,
+		CreatedAt:         millisToTimestamp(payment.CreatedAt),
+	}
+}
+
+// toAPIRefund converts a domain refund to the gRPC message
+func toAPIRefund(refund *models.Refund) *api.Refund {
+	ticketIDs := make([]string, len(refund.TicketIDs))
+	for i, id := range refund.TicketIDs {
+		ticketIDs[i] = id.String()
+	}
+
+	return &api.Refund{
+		Id:             int32(refund.ID),
+		OrderId:        int32(refund.OrderID),
+		PaymentId:      int32(refund.PaymentID),
+		Amount:         refund.Amount.InexactFloat64(),
+		Reason:         refund.Reason,
+		RefundedBy:     refund.RefundedBy,
+		Status:         refund.Status,
+		FailureMessage: refund.FailureMessage,
+		TicketIds:      ticketIDs,
+		CreatedAt:      millisToTimestamp(refund.CreatedAt),
+	}
+}
+
+// millisToTimestamp converts a Unix millisecond timestamp to a protobuf timestamp
+func millisToTimestamp(ms int64) *timestamppb.Timestamp {
+	return timestamppb.New(time.UnixMilli(ms))
+}
+
+// timestampToMillis converts an optional protobuf timestamp to Unix milliseconds, returning 0 if unset
+func timestampToMillis(ts *timestamppb.Timestamp) int64 {
+	if ts == nil {
+		return 0
+	}
+	return ts.AsTime().UnixMilli()
 }
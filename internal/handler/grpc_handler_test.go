@@ -2,16 +2,22 @@ package handler
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"tickets/api"
+	"tickets/internal/payment"
 	"tickets/internal/repository"
 	"tickets/internal/service"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestNewGRPCHandler(t *testing.T) {
@@ -55,7 +61,7 @@ func TestGRPCHandler_CreateOrder_InvalidUserId(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	testCases := []struct {
 		name        string
@@ -89,7 +95,7 @@ func TestGRPCHandler_CreateOrder_InvalidUserId(t *testing.T) {
 			assert.Nil(t, resp)
 			assert.Error(t, err)
 
-			st, ok := status.FromError(err)
+			st, ok := status.FromError(toStatus(err).Err())
 			require.True(t, ok)
 			assert.Equal(t, tc.expectCode, st.Code())
 			assert.Contains(t, st.Message(), tc.expectError)
@@ -103,7 +109,7 @@ func TestGRPCHandler_CreateOrder_InvalidConcertSessionId(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	testCases := []struct {
 		name        string
@@ -137,7 +143,7 @@ func TestGRPCHandler_CreateOrder_InvalidConcertSessionId(t *testing.T) {
 			assert.Nil(t, resp)
 			assert.Error(t, err)
 
-			st, ok := status.FromError(err)
+			st, ok := status.FromError(toStatus(err).Err())
 			require.True(t, ok)
 			assert.Equal(t, tc.expectCode, st.Code())
 			assert.Contains(t, st.Message(), tc.expectError)
@@ -151,7 +157,7 @@ func TestGRPCHandler_CreateOrder_InvalidNumberOfTickets(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	testCases := []struct {
 		name        string
@@ -191,7 +197,7 @@ func TestGRPCHandler_CreateOrder_InvalidNumberOfTickets(t *testing.T) {
 			assert.Nil(t, resp)
 			assert.Error(t, err)
 
-			st, ok := status.FromError(err)
+			st, ok := status.FromError(toStatus(err).Err())
 			require.True(t, ok)
 			assert.Equal(t, tc.expectCode, st.Code())
 			assert.Contains(t, st.Message(), tc.expectError)
@@ -205,7 +211,7 @@ func TestGRPCHandler_CreateOrder_ConcertSessionNotFound(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	req := &api.CreateOrderRequest{
 		UserId:           1,
@@ -217,7 +223,7 @@ func TestGRPCHandler_CreateOrder_ConcertSessionNotFound(t *testing.T) {
 	assert.Nil(t, resp)
 	assert.Error(t, err)
 
-	st, ok := status.FromError(err)
+	st, ok := status.FromError(toStatus(err).Err())
 	require.True(t, ok)
 	assert.Equal(t, codes.NotFound, st.Code())
 	assert.Contains(t, st.Message(), "concert session not found")
@@ -229,7 +235,7 @@ func TestGRPCHandler_CreateOrder_NoTicketsAvailable(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	req := &api.CreateOrderRequest{
 		UserId:           1,
@@ -257,7 +263,7 @@ func TestGRPCHandler_CreateOrder_ResponseStructure(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	req := &api.CreateOrderRequest{
 		UserId:           1,
@@ -288,7 +294,7 @@ func TestGRPCHandler_CreateOrder_ConcurrentRequests(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	// Test concurrent order creation
 	const numGoroutines = 5
@@ -325,7 +331,7 @@ func TestGRPCHandler_CreateOrder_ErrorHandling(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	// Test with invalid session ID
 	req := &api.CreateOrderRequest{
@@ -338,7 +344,7 @@ func TestGRPCHandler_CreateOrder_ErrorHandling(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, resp)
 
-	st, ok := status.FromError(err)
+	st, ok := status.FromError(toStatus(err).Err())
 	require.True(t, ok)
 	assert.Equal(t, codes.NotFound, st.Code())
 }
@@ -349,7 +355,7 @@ func TestGRPCHandler_CreateOrder_PriceCalculation(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	req := &api.CreateOrderRequest{
 		UserId:           1,
@@ -407,7 +413,7 @@ func TestGRPCHandler_CreateOrder_Logging(t *testing.T) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	req := &api.CreateOrderRequest{
 		UserId:           1,
@@ -430,3 +436,787 @@ func TestGRPCHandler_CreateOrder_Logging(t *testing.T) {
 
 	t.Logf("Order created successfully with ID: %d", resp.OrderId)
 }
+
+func TestGRPCHandler_GetOrder_InvalidOrderId(t *testing.T) {
+	handler, cleanup := SetupTestHandler(t)
+	defer cleanup()
+
+	for _, orderId := range []int32{0, -1} {
+		resp, err := handler.GetOrder(context.Background(), &api.GetOrderRequest{OrderId: orderId})
+		assert.Nil(t, resp)
+		assert.Error(t, err)
+
+		st, ok := status.FromError(toStatus(err).Err())
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Contains(t, st.Message(), "order_id must be positive")
+	}
+}
+
+func TestGRPCHandler_GetOrder_NotFound(t *testing.T) {
+	handler, cleanup := SetupTestHandler(t)
+	defer cleanup()
+
+	resp, err := handler.GetOrder(context.Background(), &api.GetOrderRequest{OrderId: 999999})
+	assert.Nil(t, resp)
+	assert.Error(t, err)
+
+	st, ok := status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestGRPCHandler_GetOrder_ReturnsCreatedOrder(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	created, err := handler.CreateOrder(context.Background(), &api.CreateOrderRequest{
+		UserId:           1,
+		ConcertSessionId: 1,
+		NumberOfTickets:  1,
+	})
+	if err != nil {
+		t.Logf("Expected error due to no test data: %v", err)
+		return
+	}
+
+	resp, err := handler.GetOrder(context.Background(), &api.GetOrderRequest{OrderId: created.OrderId})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Order)
+	assert.Equal(t, created.OrderId, resp.Order.Id)
+	assert.Equal(t, created.Status, resp.Order.Status)
+	assert.Equal(t, created.TotalPrice, resp.Order.TotalPrice)
+	assert.Equal(t, created.ServiceFee, resp.Order.ServiceFee)
+	assert.Equal(t, created.Tax, resp.Order.Tax)
+	assert.Equal(t, created.TaxJurisdiction, resp.Order.TaxJurisdiction)
+	assert.NotNil(t, resp.Order.CreatedAt)
+}
+
+func TestGRPCHandler_ListOrders_InvalidRequest(t *testing.T) {
+	handler, cleanup := SetupTestHandler(t)
+	defer cleanup()
+
+	testCases := []struct {
+		name        string
+		request     *api.ListOrdersRequest
+		expectError string
+	}{
+		{"zero user_id", &api.ListOrdersRequest{UserId: 0}, "user_id must be positive"},
+		{"negative page", &api.ListOrdersRequest{UserId: 1, Page: -1}, "page must not be negative"},
+		{"negative page_size", &api.ListOrdersRequest{UserId: 1, PageSize: -1}, "page_size must not be negative"},
+		{"invalid page_token", &api.ListOrdersRequest{UserId: 1, PageToken: "garbage"}, "page token is invalid"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := handler.ListOrders(context.Background(), tc.request)
+			assert.Nil(t, resp)
+
+			st, ok := status.FromError(toStatus(err).Err())
+			require.True(t, ok)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			assert.Contains(t, st.Message(), tc.expectError)
+		})
+	}
+}
+
+func TestGRPCHandler_ListOrders_ReturnsUserOrders(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	created, err := handler.CreateOrder(context.Background(), &api.CreateOrderRequest{
+		UserId:           987654,
+		ConcertSessionId: 1,
+		NumberOfTickets:  1,
+	})
+	if err != nil {
+		t.Logf("Expected error due to no test data: %v", err)
+		return
+	}
+
+	resp, err := handler.ListOrders(context.Background(), &api.ListOrdersRequest{UserId: 987654})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Orders)
+	assert.Equal(t, created.OrderId, resp.Orders[0].Id)
+	assert.Equal(t, int32(987654), resp.Orders[0].UserId)
+	assert.GreaterOrEqual(t, resp.TotalCount, int32(1))
+	assert.Equal(t, int32(1), resp.Page)
+}
+
+func TestGRPCHandler_ConfirmOrder_InvalidRequest(t *testing.T) {
+	handler, cleanup := SetupTestHandler(t)
+	defer cleanup()
+
+	resp, err := handler.ConfirmOrder(context.Background(), &api.ConfirmOrderRequest{OrderId: 0})
+	assert.Nil(t, resp)
+	st, ok := status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	resp, err = handler.ConfirmOrder(context.Background(), &api.ConfirmOrderRequest{OrderId: 999999})
+	assert.Nil(t, resp)
+	st, ok = status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestGRPCHandler_ConfirmOrder(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	created, err := handler.CreateOrder(context.Background(), &api.CreateOrderRequest{
+		UserId:           1,
+		ConcertSessionId: 1,
+		NumberOfTickets:  1,
+	})
+	if err != nil {
+		t.Logf("Expected error due to no test data: %v", err)
+		return
+	}
+
+	resp, err := handler.ConfirmOrder(context.Background(), &api.ConfirmOrderRequest{OrderId: created.OrderId})
+	require.NoError(t, err)
+	assert.Equal(t, "paid", resp.Order.Status)
+	for _, item := range resp.Order.Items {
+		assert.Equal(t, "sold", item.Ticket.Status)
+	}
+}
+
+func TestGRPCHandler_CancelOrder_InvalidRequest(t *testing.T) {
+	handler, cleanup := SetupTestHandler(t)
+	defer cleanup()
+
+	resp, err := handler.CancelOrder(context.Background(), &api.CancelOrderRequest{OrderId: 0})
+	assert.Nil(t, resp)
+	st, ok := status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	resp, err = handler.CancelOrder(context.Background(), &api.CancelOrderRequest{
+		OrderId: 1,
+		Reason:  strings.Repeat("x", 501),
+	})
+	assert.Nil(t, resp)
+	st, ok = status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	resp, err = handler.CancelOrder(context.Background(), &api.CancelOrderRequest{OrderId: 999999})
+	assert.Nil(t, resp)
+	st, ok = status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestGRPCHandler_CancelOrder(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	created, err := handler.CreateOrder(context.Background(), &api.CreateOrderRequest{
+		UserId:           1,
+		ConcertSessionId: 1,
+		NumberOfTickets:  1,
+	})
+	if err != nil {
+		t.Logf("Expected error due to no test data: %v", err)
+		return
+	}
+
+	resp, err := handler.CancelOrder(context.Background(), &api.CancelOrderRequest{
+		OrderId: created.OrderId,
+		Reason:  "customer request",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", resp.Order.Status)
+	assert.Equal(t, "customer request", resp.Order.CancellationReason)
+	assert.NotNil(t, resp.Order.CancelledAt)
+	for _, item := range resp.Order.Items {
+		assert.Equal(t, "available", item.Ticket.Status)
+	}
+}
+
+func TestGRPCHandler_PayOrder_InvalidRequest(t *testing.T) {
+	// Requests are rejected before any database access
+	handler := NewGRPCHandler(&service.OrderService{}, nil)
+
+	testCases := []struct {
+		name  string
+		req   *api.PayOrderRequest
+		code  codes.Code
+		field string
+	}{
+		{"missing order", &api.PayOrderRequest{PaymentMethod: "card"}, codes.InvalidArgument, "order_id"},
+		{"missing payment method", &api.PayOrderRequest{OrderId: 1}, codes.InvalidArgument, "payment_method"},
+		{"payments not configured", &api.PayOrderRequest{OrderId: 1, PaymentMethod: "card"}, codes.Unavailable, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := handler.PayOrder(context.Background(), tc.req)
+			assert.Nil(t, resp)
+			st := toStatus(err)
+			assert.Equal(t, tc.code, st.Code())
+			if tc.field != "" {
+				require.NotEmpty(t, st.Details())
+				assert.Contains(t, st.Message(), tc.field)
+			}
+		})
+	}
+}
+
+func TestGRPCHandler_PayOrder(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := service.NewBaseService(baseRepo)
+	orderService := service.NewOrderService(baseService)
+	provider, err := payment.NewFakeProvider(payment.FakeConfig{})
+	require.NoError(t, err)
+	orderService.SetPaymentProvider(provider)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
+	admin := NewAdminHandler(service.NewAdminService(baseService))
+
+	concert, err := admin.CreateConcert(ctx, &api.CreateConcertRequest{Name: "Payment Concert", Location: "Payment City"})
+	require.NoError(t, err)
+	start := time.Date(2027, 6, 1, 20, 0, 0, 0, time.UTC)
+	session, err := admin.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{
+		ConcertId:     concert.Concert.Id,
+		StartTime:     timestamppb.New(start),
+		EndTime:       timestamppb.New(start.Add(2 * time.Hour)),
+		Venue:         "Payment Hall",
+		NumberOfSeats: 5,
+		Price:         25,
+	})
+	require.NoError(t, err)
+
+	paid, err := handler.CreateOrder(ctx, &api.CreateOrderRequest{UserId: 1, ConcertSessionId: session.Session.Id, NumberOfTickets: 2})
+	require.NoError(t, err)
+	resp, err := handler.PayOrder(ctx, &api.PayOrderRequest{OrderId: paid.OrderId, PaymentMethod: "card"})
+	require.NoError(t, err)
+	assert.Equal(t, "paid", resp.Order.Status)
+	require.NotNil(t, resp.Payment)
+	assert.Equal(t, "captured", resp.Payment.Status)
+	assert.Equal(t, float64(50), resp.Payment.Amount)
+
+	// A declined payment cancels the order
+	declined, err := handler.CreateOrder(ctx, &api.CreateOrderRequest{UserId: 2, ConcertSessionId: session.Session.Id, NumberOfTickets: 1})
+	require.NoError(t, err)
+	_, err = handler.PayOrder(ctx, &api.PayOrderRequest{OrderId: declined.OrderId, PaymentMethod: payment.FakeMethodDecline})
+	st := toStatus(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Equal(t, "payment was declined (card_declined)", st.Message())
+
+	got, err := handler.GetOrder(ctx, &api.GetOrderRequest{OrderId: declined.OrderId})
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", got.Order.Status)
+
+	// A provider timeout leaves the order pending
+	timedOut, err := handler.CreateOrder(ctx, &api.CreateOrderRequest{UserId: 3, ConcertSessionId: session.Session.Id, NumberOfTickets: 1})
+	require.NoError(t, err)
+	_, err = handler.PayOrder(ctx, &api.PayOrderRequest{OrderId: timedOut.OrderId, PaymentMethod: payment.FakeMethodTimeout})
+	assert.Equal(t, codes.Unavailable, toStatus(err).Code())
+
+	got, err = handler.GetOrder(ctx, &api.GetOrderRequest{OrderId: timedOut.OrderId})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", got.Order.Status)
+}
+
+func TestGRPCHandler_RefundTickets_InvalidRequest(t *testing.T) {
+	// Requests are rejected before any database access
+	handler := NewGRPCHandler(&service.OrderService{}, nil)
+	ctx := context.Background()
+
+	_, err := handler.RefundOrder(ctx, &api.RefundOrderRequest{Reason: "cannot attend", RefundedBy: "support"})
+	assert.Equal(t, codes.InvalidArgument, toStatus(err).Code())
+	assert.Contains(t, toStatus(err).Message(), "order_id")
+
+	_, err = handler.RefundOrder(ctx, &api.RefundOrderRequest{OrderId: 1, RefundedBy: "support"})
+	assert.Equal(t, codes.InvalidArgument, toStatus(err).Code())
+
+	_, err = handler.RefundTickets(ctx, &api.RefundTicketsRequest{
+		OrderId: 1, TicketIds: []string{"not-a-uuid"}, Reason: "cannot attend", RefundedBy: "support",
+	})
+	assert.Equal(t, codes.InvalidArgument, toStatus(err).Code())
+	assert.Contains(t, toStatus(err).Message(), "ticket_ids")
+
+	_, err = handler.RefundTickets(ctx, &api.RefundTicketsRequest{OrderId: 1, Reason: "cannot attend", RefundedBy: "support"})
+	assert.Equal(t, codes.InvalidArgument, toStatus(err).Code())
+}
+
+func TestGRPCHandler_RefundTickets(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := service.NewBaseService(baseRepo)
+	orderService := service.NewOrderService(baseService)
+	provider, err := payment.NewFakeProvider(payment.FakeConfig{})
+	require.NoError(t, err)
+	orderService.SetPaymentProvider(provider)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
+	admin := NewAdminHandler(service.NewAdminService(baseService))
+
+	concert, err := admin.CreateConcert(ctx, &api.CreateConcertRequest{Name: "Refund Concert", Location: "Refund City"})
+	require.NoError(t, err)
+	start := time.Now().Add(30 * 24 * time.Hour)
+	session, err := admin.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{
+		ConcertId:     concert.Concert.Id,
+		StartTime:     timestamppb.New(start),
+		EndTime:       timestamppb.New(start.Add(2 * time.Hour)),
+		Venue:         "Refund Hall",
+		NumberOfSeats: 5,
+		Price:         25,
+	})
+	require.NoError(t, err)
+
+	created, err := handler.CreateOrder(ctx, &api.CreateOrderRequest{UserId: 1, ConcertSessionId: session.Session.Id, NumberOfTickets: 2})
+	require.NoError(t, err)
+	paid, err := handler.PayOrder(ctx, &api.PayOrderRequest{OrderId: created.OrderId, PaymentMethod: "card"})
+	require.NoError(t, err)
+	ticketID := paid.Order.Items[0].TicketId
+
+	resp, err := handler.RefundTickets(ctx, &api.RefundTicketsRequest{
+		OrderId: created.OrderId, TicketIds: []string{ticketID}, Reason: "cannot attend", RefundedBy: "support",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "paid", resp.Order.Status)
+	assert.Equal(t, float64(25), resp.Order.RefundedAmount)
+	assert.Equal(t, "succeeded", resp.Refund.Status)
+	assert.Equal(t, float64(25), resp.Refund.Amount)
+	assert.Equal(t, []string{ticketID}, resp.Refund.TicketIds)
+	assert.Equal(t, "support", resp.Refund.RefundedBy)
+	assert.Equal(t, resp.Refund.Id, resp.Order.Items[0].RefundId)
+	assert.Equal(t, "available", resp.Order.Items[0].Ticket.Status)
+
+	_, err = handler.RefundTickets(ctx, &api.RefundTicketsRequest{
+		OrderId: created.OrderId, TicketIds: []string{ticketID}, Reason: "cannot attend", RefundedBy: "support",
+	})
+	assert.Equal(t, codes.FailedPrecondition, toStatus(err).Code())
+
+	full, err := handler.RefundOrder(ctx, &api.RefundOrderRequest{OrderId: created.OrderId, Reason: "cannot attend", RefundedBy: "support"})
+	require.NoError(t, err)
+	assert.Equal(t, "refunded", full.Order.Status)
+	assert.Equal(t, float64(50), full.Order.RefundedAmount)
+
+	_, err = handler.RefundOrder(ctx, &api.RefundOrderRequest{OrderId: 999999, Reason: "cannot attend", RefundedBy: "support"})
+	assert.Equal(t, codes.NotFound, toStatus(err).Code())
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	req := &api.CreateOrderRequest{}
+	assert.Empty(t, idempotencyKey(context.Background(), req))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("idempotency-key", "from-header"))
+	assert.Equal(t, "from-header", idempotencyKey(ctx, req))
+
+	// The request field takes precedence over the header
+	req.IdempotencyKey = "from-request"
+	assert.Equal(t, "from-request", idempotencyKey(ctx, req))
+}
+
+func TestGRPCHandler_CreateOrder_IdempotencyKey(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	req := &api.CreateOrderRequest{
+		UserId:           1,
+		ConcertSessionId: 1,
+		NumberOfTickets:  1,
+		IdempotencyKey:   "handler-retry",
+	}
+	first, err := handler.CreateOrder(context.Background(), req)
+	if err != nil {
+		t.Logf("Expected error due to no test data: %v", err)
+		return
+	}
+
+	retry, err := handler.CreateOrder(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, first.OrderId, retry.OrderId)
+	assert.Equal(t, first.TicketIds, retry.TicketIds)
+
+	resp, err := handler.CreateOrder(context.Background(), &api.CreateOrderRequest{
+		UserId:           1,
+		ConcertSessionId: 1,
+		NumberOfTickets:  2,
+		IdempotencyKey:   "handler-retry",
+	})
+	assert.Nil(t, resp)
+	st, ok := status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+}
+
+func TestGRPCHandler_GetConcertSession(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	list, err := handler.ListConcertSessions(context.Background(), &api.ListConcertSessionsRequest{PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, list.Sessions)
+
+	resp, err := handler.GetConcertSession(context.Background(), &api.GetConcertSessionRequest{
+		SessionId: list.Sessions[0].Id,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, list.Sessions[0].Id, resp.Session.Id)
+	assert.NotNil(t, resp.Session.StartTime)
+	assert.NotNil(t, resp.Session.Concert)
+	assert.Equal(t, resp.Session.ConcertId, resp.Session.Concert.Id)
+	assert.LessOrEqual(t, resp.Session.RemainingSeats, resp.Session.NumberOfSeats)
+}
+
+func TestGRPCHandler_GetConcertSession_InvalidRequest(t *testing.T) {
+	handler, cleanup := SetupTestHandler(t)
+	defer cleanup()
+
+	resp, err := handler.GetConcertSession(context.Background(), &api.GetConcertSessionRequest{SessionId: 0})
+	assert.Nil(t, resp)
+	st, ok := status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	resp, err = handler.GetConcertSession(context.Background(), &api.GetConcertSessionRequest{SessionId: 999999})
+	assert.Nil(t, resp)
+	st, ok = status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestGRPCHandler_ListConcertSessions(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	resp, err := handler.ListConcertSessions(context.Background(), &api.ListConcertSessionsRequest{})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, resp.TotalCount, int32(1))
+	assert.Equal(t, int32(1), resp.Page)
+	for _, session := range resp.Sessions {
+		assert.NotNil(t, session.Concert)
+	}
+
+	_, err = handler.ListConcertSessions(context.Background(), &api.ListConcertSessionsRequest{Page: -1})
+	st, ok := status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestGRPCHandler_ListConcertSessions_PageToken(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	first, err := handler.ListConcertSessions(context.Background(), &api.ListConcertSessionsRequest{PageSize: 1})
+	require.NoError(t, err)
+	if first.TotalCount < 2 {
+		t.Skip("need at least two concert sessions")
+	}
+	require.Len(t, first.Sessions, 1)
+	require.NotEmpty(t, first.NextPageToken)
+
+	next, err := handler.ListConcertSessions(context.Background(), &api.ListConcertSessionsRequest{
+		PageSize:  1,
+		PageToken: first.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Len(t, next.Sessions, 1)
+	assert.NotEqual(t, first.Sessions[0].Id, next.Sessions[0].Id)
+	assert.Equal(t, int32(0), next.Page)
+
+	_, err = handler.ListConcertSessions(context.Background(), &api.ListConcertSessionsRequest{
+		Page:      2,
+		PageToken: first.NextPageToken,
+	})
+	st, ok := status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestGRPCHandler_GetAvailableTickets(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	list, err := handler.ListConcertSessions(context.Background(), &api.ListConcertSessionsRequest{PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, list.Sessions)
+
+	resp, err := handler.GetAvailableTickets(context.Background(), &api.GetAvailableTicketsRequest{
+		SessionId: list.Sessions[0].Id,
+		Limit:     2,
+	})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(resp.Tickets), 2)
+	assert.Equal(t, list.Sessions[0].RemainingSeats, resp.TotalAvailable)
+	for _, ticket := range resp.Tickets {
+		assert.Equal(t, "available", ticket.Status)
+	}
+}
+
+func TestGRPCHandler_GetAvailableTickets_InvalidRequest(t *testing.T) {
+	handler, cleanup := SetupTestHandler(t)
+	defer cleanup()
+
+	testCases := []struct {
+		name string
+		req  *api.GetAvailableTicketsRequest
+		code codes.Code
+	}{
+		{"zero session_id", &api.GetAvailableTicketsRequest{SessionId: 0}, codes.InvalidArgument},
+		{"negative limit", &api.GetAvailableTicketsRequest{SessionId: 1, Limit: -1}, codes.InvalidArgument},
+		{"unknown session", &api.GetAvailableTicketsRequest{SessionId: 999999}, codes.NotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := handler.GetAvailableTickets(context.Background(), tc.req)
+			assert.Nil(t, resp)
+			st, ok := status.FromError(toStatus(err).Err())
+			require.True(t, ok)
+			assert.Equal(t, tc.code, st.Code())
+		})
+	}
+}
+
+func TestGRPCHandler_ReservedSeating(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := service.NewBaseService(baseRepo)
+	handler := NewGRPCHandler(service.NewOrderService(baseService), service.NewConcertService(baseService))
+	admin := NewAdminHandler(service.NewAdminService(baseService))
+
+	concert, err := admin.CreateConcert(ctx, &api.CreateConcertRequest{Name: "Seated Concert", Location: "Seat City"})
+	require.NoError(t, err)
+	start := time.Date(2027, 4, 1, 20, 0, 0, 0, time.UTC)
+	session, err := admin.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{
+		ConcertId: concert.Concert.Id,
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(start.Add(2 * time.Hour)),
+		Venue:     "Seated Hall",
+		Price:     30,
+		Sections:  []*api.SeatingSection{{Name: "Circle", Rows: 2, SeatsPerRow: 2}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), session.Session.NumberOfSeats)
+
+	seatMap, err := handler.GetSeatMap(ctx, &api.GetSeatMapRequest{SessionId: session.Session.Id})
+	require.NoError(t, err)
+	require.Len(t, seatMap.Seats, 4)
+	assert.Equal(t, int32(4), seatMap.Available)
+	assert.Equal(t, "Circle", seatMap.Seats[3].Section)
+	assert.Equal(t, "B", seatMap.Seats[3].Row)
+	assert.Equal(t, int32(2), seatMap.Seats[3].SeatNumber)
+
+	// Seats are bought by id without a ticket count
+	order, err := handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 1, ConcertSessionId: session.Session.Id, SeatIds: []string{seatMap.Seats[3].Id},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{seatMap.Seats[3].Id}, order.TicketIds)
+
+	got, err := handler.GetOrder(ctx, &api.GetOrderRequest{OrderId: order.OrderId})
+	require.NoError(t, err)
+	require.Len(t, got.Order.Items, 1)
+	assert.Equal(t, "B", got.Order.Items[0].Ticket.Row)
+
+	// Buying it again reports the seat in a PreconditionFailure
+	_, err = handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 2, ConcertSessionId: session.Session.Id, SeatIds: []string{seatMap.Seats[3].Id},
+	})
+	st := toStatus(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	var failure *errdetails.PreconditionFailure
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.PreconditionFailure); ok {
+			failure = d
+		}
+	}
+	require.NotNil(t, failure)
+	require.Len(t, failure.Violations, 1)
+	assert.Equal(t, seatMap.Seats[3].Id, failure.Violations[0].Subject)
+}
+
+func TestGRPCHandler_SeatRequests_Invalid(t *testing.T) {
+	// Requests rejected before reaching the service need no database
+	handler := NewGRPCHandler(nil, nil)
+	ctx := context.Background()
+
+	_, err := handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 1, ConcertSessionId: 1, SeatIds: []string{"not-a-uuid"},
+	})
+	var svcErr *service.Error
+	require.ErrorAs(t, err, &svcErr)
+	assert.Equal(t, "seat_ids", svcErr.Field)
+
+	_, err = handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 1, ConcertSessionId: 1, SeatIds: []string{"a", "b", "c", "d"},
+	})
+	assert.ErrorIs(t, err, service.ErrTicketLimitExceeded)
+
+	_, err = handler.GetSeatMap(ctx, &api.GetSeatMapRequest{SessionId: 0})
+	assert.Equal(t, codes.InvalidArgument, toStatus(err).Code())
+}
+
+func TestGRPCHandler_TicketTypes(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := service.NewBaseService(baseRepo)
+	handler := NewGRPCHandler(service.NewOrderService(baseService), service.NewConcertService(baseService))
+	admin := NewAdminHandler(service.NewAdminService(baseService))
+
+	concert, err := admin.CreateConcert(ctx, &api.CreateConcertRequest{Name: "Tiered Concert", Location: "Tier City"})
+	require.NoError(t, err)
+	start := time.Date(2027, 5, 1, 20, 0, 0, 0, time.UTC)
+	session, err := admin.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{
+		ConcertId:     concert.Concert.Id,
+		StartTime:     timestamppb.New(start),
+		EndTime:       timestamppb.New(start.Add(2 * time.Hour)),
+		Venue:         "Tier Hall",
+		NumberOfSeats: 10,
+		Price:         40,
+	})
+	require.NoError(t, err)
+
+	vip, err := admin.CreateTicketType(ctx, &api.CreateTicketTypeRequest{
+		SessionId: session.Session.Id, Name: "VIP", Price: 100, Quota: 2,
+	})
+	require.NoError(t, err)
+	ga, err := admin.CreateTicketType(ctx, &api.CreateTicketTypeRequest{
+		SessionId: session.Session.Id, Name: "GA", Price: 40, Quota: 8,
+	})
+	require.NoError(t, err)
+
+	list, err := handler.ListTicketTypes(ctx, &api.ListTicketTypesRequest{SessionId: session.Session.Id})
+	require.NoError(t, err)
+	require.Len(t, list.TicketTypes, 2)
+	assert.Equal(t, "GA", list.TicketTypes[0].Name)
+	assert.Equal(t, int32(8), list.TicketTypes[0].Remaining)
+
+	order, err := handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 1, ConcertSessionId: session.Session.Id,
+		TicketTypes: []*api.TicketSelection{
+			{TicketTypeId: vip.TicketType.Id, Quantity: 1},
+			{TicketTypeId: ga.TicketType.Id, Quantity: 1},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, float64(140), order.TotalPrice)
+
+	got, err := handler.GetOrder(ctx, &api.GetOrderRequest{OrderId: order.OrderId})
+	require.NoError(t, err)
+	require.Len(t, got.Order.Items, 2)
+	assert.Equal(t, vip.TicketType.Id, got.Order.Items[0].TicketTypeId)
+	assert.Equal(t, "VIP", got.Order.Items[0].Ticket.TicketType)
+
+	// Sessions with ticket types reject orders without them
+	_, err = handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 1, ConcertSessionId: session.Session.Id, NumberOfTickets: 1,
+	})
+	assert.Equal(t, codes.InvalidArgument, toStatus(err).Code())
+}
+
+func TestGRPCHandler_PromoCode(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := service.NewBaseService(baseRepo)
+	handler := NewGRPCHandler(service.NewOrderService(baseService), service.NewConcertService(baseService))
+	admin := NewAdminHandler(service.NewAdminService(baseService))
+
+	concert, err := admin.CreateConcert(ctx, &api.CreateConcertRequest{Name: "Promo Concert", Location: "Promo City"})
+	require.NoError(t, err)
+	start := time.Date(2027, 6, 1, 20, 0, 0, 0, time.UTC)
+	session, err := admin.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{
+		ConcertId:     concert.Concert.Id,
+		StartTime:     timestamppb.New(start),
+		EndTime:       timestamppb.New(start.Add(2 * time.Hour)),
+		Venue:         "Promo Hall",
+		NumberOfSeats: 5,
+		Price:         25,
+	})
+	require.NoError(t, err)
+
+	promo, err := admin.CreatePromoCode(ctx, &api.CreatePromoCodeRequest{
+		Code: "handler-5off", DiscountType: "fixed", DiscountValue: 5,
+		ValidUntil: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "HANDLER-5OFF", promo.PromoCode.Code)
+	assert.Nil(t, promo.PromoCode.ValidFrom)
+
+	order, err := handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 1, ConcertSessionId: session.Session.Id, NumberOfTickets: 1, PromoCode: "HANDLER-5OFF",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), order.Discount)
+	assert.Equal(t, float64(25), order.Subtotal)
+	assert.Equal(t, float64(20), order.TotalPrice)
+	assert.Equal(t, "HANDLER-5OFF", order.PromoCode)
+
+	got, err := handler.GetOrder(ctx, &api.GetOrderRequest{OrderId: order.OrderId})
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), got.Order.Discount)
+	assert.Equal(t, "HANDLER-5OFF", got.Order.PromoCode)
+
+	fetched, err := admin.GetPromoCode(ctx, &api.GetPromoCodeRequest{Code: "handler-5off"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fetched.PromoCode.Redemptions)
+
+	_, err = handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 1, ConcertSessionId: session.Session.Id, NumberOfTickets: 1, PromoCode: "HANDLER-MISSING",
+	})
+	assert.Equal(t, codes.NotFound, toStatus(err).Code())
+}
+
+func TestGRPCHandler_TicketTypeRequests_Invalid(t *testing.T) {
+	// Requests rejected before reaching the service need no database
+	handler := NewGRPCHandler(nil, nil)
+	ctx := context.Background()
+
+	_, err := handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 1, ConcertSessionId: 1, TicketTypes: []*api.TicketSelection{{TicketTypeId: 1}},
+	})
+	var svcErr *service.Error
+	require.ErrorAs(t, err, &svcErr)
+	assert.Equal(t, "ticket_types", svcErr.Field)
+
+	_, err = handler.CreateOrder(ctx, &api.CreateOrderRequest{
+		UserId: 1, ConcertSessionId: 1, TicketTypes: []*api.TicketSelection{{TicketTypeId: 1, Quantity: 4}},
+	})
+	assert.ErrorIs(t, err, service.ErrTicketLimitExceeded)
+
+	_, err = handler.ListTicketTypes(ctx, &api.ListTicketTypesRequest{SessionId: 0})
+	assert.Equal(t, codes.InvalidArgument, toStatus(err).Code())
+}
+
+func TestTimestampToMillis(t *testing.T) {
+	assert.Equal(t, int64(0), timestampToMillis(nil))
+	assert.Equal(t, int64(1735689600000), timestampToMillis(timestamppb.New(time.UnixMilli(1735689600000))))
+}
+
+func TestGRPCHandler_ListConcertSessions_Filters(t *testing.T) {
+	handler, cleanup := SetupTestHandlerWithData(t)
+	defer cleanup()
+
+	resp, err := handler.ListConcertSessions(context.Background(), &api.ListConcertSessionsRequest{
+		StartTimeFrom: timestamppb.New(time.UnixMilli(1735689600000)),
+		StartTimeTo:   timestamppb.New(time.UnixMilli(1735689600000)),
+		Venue:         "test arena",
+		OnlyAvailable: true,
+		OrderBy:       "price desc",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Sessions)
+	for _, session := range resp.Sessions {
+		assert.Equal(t, "Test Arena", session.Venue)
+		assert.Positive(t, session.RemainingSeats)
+	}
+
+	_, err = handler.ListConcertSessions(context.Background(), &api.ListConcertSessionsRequest{OrderBy: "venue"})
+	st, ok := status.FromError(toStatus(err).Err())
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
@@ -13,7 +13,7 @@ func SetupTestHandler(t *testing.T) (*GRPCHandler, func()) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	return handler, cleanup
 }
@@ -30,7 +30,7 @@ func SetupTestHandlerWithData(t *testing.T) (*GRPCHandler, func()) {
 
 	baseService := service.NewBaseService(baseRepo)
 	orderService := service.NewOrderService(baseService)
-	handler := NewGRPCHandler(orderService)
+	handler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
 
 	return handler, cleanup
 }
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"tickets/internal/logger"
+	"tickets/internal/service"
+)
+
+// PaymentWebhookPath is where payment providers post payment status events
+const PaymentWebhookPath = "/webhooks/payments"
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body, keyed with the webhook
+// secret and prefixed with "sha256="
+const SignatureHeader = "X-Payment-Signature"
+
+// maxWebhookBodyBytes bounds the size of a webhook request body
+const maxWebhookBodyBytes = 64 << 10
+
+// WebhookHandler receives payment provider webhooks over HTTP
+type WebhookHandler struct {
+	orderService *service.OrderService
+	secret       []byte
+}
+
+// NewWebhookHandler creates a new webhook handler verifying signatures with secret. With an
+// empty secret every request is refused.
+func NewWebhookHandler(orderService *service.OrderService, secret []byte) *WebhookHandler {
+	return &WebhookHandler{
+		orderService: orderService,
+		secret:       secret,
+	}
+}
+
+// paymentEvent is the JSON body of a payment webhook
+type paymentEvent struct {
+	ID               string `json:"id"`
+	Type             string `json:"type"`
+	PaymentReference string `json:"payment_reference"`
+	FailureCode      string `json:"failure_code,omitempty"`
+	FailureMessage   string `json:"failure_message,omitempty"`
+}
+
+// webhookResponse is the JSON body of a webhook response. Status is "processed" or
+// "duplicate" on success; Reason and Message describe a rejected event.
+type webhookResponse struct {
+	Status  string `json:"status,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ServeHTTP verifies a payment event's signature and applies it to its payment and order.
+// Events that were already applied are acknowledged without being applied again. Failures
+// that a redelivery may fix answer with a 5xx status so the provider retries.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeWebhookResponse(w, http.StatusMethodNotAllowed, webhookResponse{Message: "method not allowed"})
+		return
+	}
+	if len(h.secret) == 0 {
+		writeWebhookResponse(w, http.StatusServiceUnavailable, webhookResponse{Message: "webhooks are not configured"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		writeWebhookResponse(w, http.StatusRequestEntityTooLarge, webhookResponse{Message: "request body is too large"})
+		return
+	}
+	if !h.validSignature(body, r.Header.Get(SignatureHeader)) {
+		logger.WithField("remote_addr", r.RemoteAddr).Warn("Rejected payment webhook with an invalid signature")
+		writeWebhookResponse(w, http.StatusUnauthorized, webhookResponse{Message: "invalid signature"})
+		return
+	}
+
+	var event paymentEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		writeWebhookResponse(w, http.StatusBadRequest, webhookResponse{Message: "request body is not a valid event"})
+		return
+	}
+
+	fields := map[string]interface{}{
+		"event_id":          event.ID,
+		"event_type":        event.Type,
+		"payment_reference": event.PaymentReference,
+	}
+	logger.WithFields(fields).Info("Handling payment webhook")
+
+	applied, err := h.orderService.HandlePaymentEvent(r.Context(), &service.PaymentEventRequest{
+		EventID:        event.ID,
+		Type:           event.Type,
+		Reference:      event.PaymentReference,
+		FailureCode:    event.FailureCode,
+		FailureMessage: event.FailureMessage,
+	})
+	if err != nil {
+		status, resp := webhookError(err)
+		if status == http.StatusInternalServerError {
+			logger.WithError(err).WithFields(fields).Error("Failed to handle payment webhook")
+		} else {
+			logger.WithError(err).WithFields(fields).Warn("Rejected payment webhook")
+		}
+		writeWebhookResponse(w, status, resp)
+		return
+	}
+
+	if !applied {
+		logger.WithFields(fields).Info("Ignored duplicate payment webhook")
+		writeWebhookResponse(w, http.StatusOK, webhookResponse{Status: "duplicate"})
+		return
+	}
+	writeWebhookResponse(w, http.StatusOK, webhookResponse{Status: "processed"})
+}
+
+// validSignature reports whether signature is "sha256=" followed by the hex HMAC-SHA256 of
+// body, comparing in constant time
+func (h *WebhookHandler) validSignature(body []byte, signature string) bool {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// webhookError maps an error to an HTTP status and response body, as errorCode does for gRPC
+func webhookError(err error) (int, webhookResponse) {
+	var svcErr *service.Error
+	if !errors.As(err, &svcErr) {
+		return http.StatusInternalServerError, webhookResponse{Message: "internal error"}
+	}
+
+	resp := webhookResponse{Reason: svcErr.Reason, Message: svcErr.Message}
+	switch {
+	case errors.Is(svcErr, service.ErrNotFound):
+		return http.StatusNotFound, resp
+	case errors.Is(svcErr, service.ErrInvalidArgument):
+		return http.StatusBadRequest, resp
+	case errors.Is(svcErr, service.ErrConflict), errors.Is(svcErr, service.ErrFailedPrecondition):
+		return http.StatusConflict, resp
+	case errors.Is(svcErr, service.ErrUnavailable):
+		return http.StatusServiceUnavailable, resp
+	default:
+		return http.StatusInternalServerError, webhookResponse{Message: "internal error"}
+	}
+}
+
+// writeWebhookResponse writes a JSON response
+func writeWebhookResponse(w http.ResponseWriter, status int, resp webhookResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger.WithError(err).Warn("Failed to write payment webhook response")
+	}
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"tickets/api"
+	models "tickets/internal/models/domain"
+	"tickets/internal/payment"
+	"tickets/internal/repository"
+	"tickets/internal/service"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testWebhookSecret = "whsec_test"
+
+// signedWebhookRequest builds a payment webhook request signed with secret
+func signedWebhookRequest(secret, body string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	req := httptest.NewRequest(http.MethodPost, PaymentWebhookPath, strings.NewReader(body))
+	req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+// serveWebhook runs a request through the handler and decodes the response
+func serveWebhook(t *testing.T, handler http.Handler, req *http.Request) (int, webhookResponse) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp webhookResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+func TestWebhookHandler_RejectsRequests(t *testing.T) {
+	// Requests are rejected before any database access
+	handler := NewWebhookHandler(&service.OrderService{}, []byte(testWebhookSecret))
+	event := `{"id":"evt_1","type":"payment.captured","payment_reference":"fake_1"}`
+
+	testCases := []struct {
+		name   string
+		req    *http.Request
+		status int
+		reason string
+	}{
+		{"wrong method", httptest.NewRequest(http.MethodGet, PaymentWebhookPath, nil), http.StatusMethodNotAllowed, ""},
+		{"missing signature", httptest.NewRequest(http.MethodPost, PaymentWebhookPath, strings.NewReader(event)), http.StatusUnauthorized, ""},
+		{"wrong secret", signedWebhookRequest("other", event), http.StatusUnauthorized, ""},
+		{"invalid JSON", signedWebhookRequest(testWebhookSecret, "{"), http.StatusBadRequest, ""},
+		{"missing id", signedWebhookRequest(testWebhookSecret, `{"type":"payment.captured","payment_reference":"fake_1"}`),
+			http.StatusBadRequest, "INVALID_PAYMENT_EVENT_ID"},
+		{"unknown type", signedWebhookRequest(testWebhookSecret, `{"id":"evt_1","type":"payment.pending","payment_reference":"fake_1"}`),
+			http.StatusBadRequest, "INVALID_PAYMENT_EVENT_TYPE"},
+		{"payments not configured", signedWebhookRequest(testWebhookSecret, event), http.StatusServiceUnavailable, "PAYMENTS_NOT_CONFIGURED"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, resp := serveWebhook(t, handler, tc.req)
+			assert.Equal(t, tc.status, status)
+			assert.Equal(t, tc.reason, resp.Reason)
+		})
+	}
+
+	// Tampering with a signed body invalidates it
+	req := signedWebhookRequest(testWebhookSecret, event)
+	req.Body = http.NoBody
+	status, _ := serveWebhook(t, handler, req)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// Without a secret nothing is accepted
+	status, _ = serveWebhook(t, NewWebhookHandler(&service.OrderService{}, nil), signedWebhookRequest("", event))
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestWebhookHandler_PaymentEvents(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := service.NewBaseService(baseRepo)
+	orderService := service.NewOrderService(baseService)
+	provider, err := payment.NewFakeProvider(payment.FakeConfig{})
+	require.NoError(t, err)
+	orderService.SetPaymentProvider(provider)
+	grpcHandler := NewGRPCHandler(orderService, service.NewConcertService(baseService))
+	admin := NewAdminHandler(service.NewAdminService(baseService))
+	webhooks := NewWebhookHandler(orderService, []byte(testWebhookSecret))
+
+	concert, err := admin.CreateConcert(ctx, &api.CreateConcertRequest{Name: "Webhook Concert", Location: "Webhook City"})
+	require.NoError(t, err)
+	start := time.Date(2027, 6, 1, 20, 0, 0, 0, time.UTC)
+	session, err := admin.CreateConcertSession(ctx, &api.CreateConcertSessionRequest{
+		ConcertId:     concert.Concert.Id,
+		StartTime:     timestamppb.New(start),
+		EndTime:       timestamppb.New(start.Add(2 * time.Hour)),
+		Venue:         "Webhook Hall",
+		NumberOfSeats: 5,
+		Price:         25,
+	})
+	require.NoError(t, err)
+
+	// Payments the provider authorized but has not yet reported on
+	paymentRepo := repository.NewPaymentRepository(baseRepo)
+	authorize := func(userID int32, reference string) int32 {
+		order, err := grpcHandler.CreateOrder(ctx, &api.CreateOrderRequest{UserId: userID, ConcertSessionId: session.Session.Id, NumberOfTickets: 1})
+		require.NoError(t, err)
+		err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+			_, err := paymentRepo.CreatePayment(tx, &models.Payment{
+				OrderID: int(order.OrderId), Provider: "fake", ProviderReference: reference,
+				Amount: decimal.NewFromInt(25), Status: models.PaymentStatusAuthorized,
+			})
+			return err
+		})
+		require.NoError(t, err)
+		return order.OrderId
+	}
+	capturedOrder := authorize(1, "fake_webhook_1")
+	failedOrder := authorize(2, "fake_webhook_2")
+
+	captured := `{"id":"evt_captured","type":"payment.captured","payment_reference":"fake_webhook_1"}`
+	status, resp := serveWebhook(t, webhooks, signedWebhookRequest(testWebhookSecret, captured))
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "processed", resp.Status)
+
+	got, err := grpcHandler.GetOrder(ctx, &api.GetOrderRequest{OrderId: capturedOrder})
+	require.NoError(t, err)
+	assert.Equal(t, "paid", got.Order.Status)
+
+	// Redelivery is acknowledged without being applied again
+	status, resp = serveWebhook(t, webhooks, signedWebhookRequest(testWebhookSecret, captured))
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "duplicate", resp.Status)
+
+	failed := `{"id":"evt_failed","type":"payment.failed","payment_reference":"fake_webhook_2","failure_code":"expired_card"}`
+	status, resp = serveWebhook(t, webhooks, signedWebhookRequest(testWebhookSecret, failed))
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "processed", resp.Status)
+
+	got, err = grpcHandler.GetOrder(ctx, &api.GetOrderRequest{OrderId: failedOrder})
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", got.Order.Status)
+	assert.Equal(t, "payment declined", got.Order.CancellationReason)
+
+	unknown := `{"id":"evt_unknown","type":"payment.captured","payment_reference":"fake_missing"}`
+	status, resp = serveWebhook(t, webhooks, signedWebhookRequest(testWebhookSecret, unknown))
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "PAYMENT_NOT_FOUND", resp.Reason)
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"database/sql"
 	models "tickets/internal/models/domain"
 
 	"github.com/shopspring/decimal"
@@ -36,11 +37,35 @@ type ConcertSession struct {
 
 func (c *ConcertSession) ToConcertSession() *models.ConcertSession {
 	return &models.ConcertSession{
-		ID:        c.ID,
-		ConcertID: c.ConcertID,
-		StartTime: c.StartTime,
-		EndTime:   c.EndTime,
-		Venue:     c.Venue,
-		Price:     c.Price,
+		ID:            c.ID,
+		ConcertID:     c.ConcertID,
+		StartTime:     c.StartTime,
+		EndTime:       c.EndTime,
+		Venue:         c.Venue,
+		NumberOfSeats: c.NumberOfSeats,
+		Price:         c.Price,
 	}
 }
+
+// ConcertSessionDetails is a concert session joined with its concert and remaining seat count
+type ConcertSessionDetails struct {
+	ConcertSession
+	RemainingSeats     int            `db:"remaining_seats"`
+	ConcertName        string         `db:"concert_name"`
+	ConcertLocation    string         `db:"concert_location"`
+	ConcertDescription sql.NullString `db:"concert_description"`
+	ConcertCreatedAt   int64          `db:"concert_created_at"`
+}
+
+func (d *ConcertSessionDetails) ToConcertSession() *models.ConcertSession {
+	session := d.ConcertSession.ToConcertSession()
+	session.RemainingSeats = d.RemainingSeats
+	session.Concert = &models.Concert{
+		ID:          d.ConcertID,
+		Name:        d.ConcertName,
+		Location:    d.ConcertLocation,
+		Description: d.ConcertDescription.String,
+		CreatedAt:   d.ConcertCreatedAt,
+	}
+	return session
+}
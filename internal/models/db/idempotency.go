@@ -0,0 +1,23 @@
+package db
+
+import (
+	models "tickets/internal/models/domain"
+)
+
+type IdempotencyKey struct {
+	UserID      int    `db:"user_id"`
+	Key         string `db:"idempotency_key"`
+	RequestHash string `db:"request_hash"`
+	Response    []byte `db:"response"`
+	CreatedAt   int64  `db:"created_at"`
+}
+
+func (k *IdempotencyKey) ToIdempotencyKey() *models.IdempotencyKey {
+	return &models.IdempotencyKey{
+		UserID:      k.UserID,
+		Key:         k.Key,
+		RequestHash: k.RequestHash,
+		Response:    k.Response,
+		CreatedAt:   k.CreatedAt,
+	}
+}
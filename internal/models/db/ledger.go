@@ -0,0 +1,48 @@
+package db
+
+import (
+	"database/sql"
+	models "tickets/internal/models/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+// LedgerTransaction is a ledger_transactions row
+type LedgerTransaction struct {
+	ID        int           `db:"id"`
+	Kind      string        `db:"kind"`
+	OrderID   int           `db:"order_id"`
+	PaymentID sql.NullInt64 `db:"payment_id"`
+	RefundID  sql.NullInt64 `db:"refund_id"`
+	CreatedAt int64         `db:"created_at"`
+}
+
+func (t *LedgerTransaction) ToLedgerTransaction() *models.LedgerTransaction {
+	return &models.LedgerTransaction{
+		ID:        t.ID,
+		Kind:      t.Kind,
+		OrderID:   t.OrderID,
+		PaymentID: int(t.PaymentID.Int64),
+		RefundID:  int(t.RefundID.Int64),
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// LedgerEntry is a ledger_entries row
+type LedgerEntry struct {
+	ID            int             `db:"id"`
+	TransactionID int             `db:"transaction_id"`
+	Account       string          `db:"account"`
+	Debit         decimal.Decimal `db:"debit"`
+	Credit        decimal.Decimal `db:"credit"`
+}
+
+func (e *LedgerEntry) ToLedgerEntry() models.LedgerEntry {
+	return models.LedgerEntry{
+		ID:            e.ID,
+		TransactionID: e.TransactionID,
+		Account:       e.Account,
+		Debit:         e.Debit,
+		Credit:        e.Credit,
+	}
+}
@@ -1,10 +1,93 @@
 package db
 
-import "github.com/shopspring/decimal"
+import (
+	"database/sql"
+	models "tickets/internal/models/domain"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
 
 type Order struct {
-	ID         int             `db:"id"`
-	CreatedAt  int64           `db:"created_at"`
-	Status     string          `db:"status"`
-	TotalPrice decimal.Decimal `db:"total_price"`
+	ID                 int                 `db:"id"`
+	UserID             int                 `db:"user_id"`
+	CreatedAt          int64               `db:"created_at"`
+	Status             string              `db:"status"`
+	Subtotal           decimal.NullDecimal `db:"subtotal"`
+	DiscountAmount     decimal.Decimal     `db:"discount_amount"`
+	ServiceFee         decimal.Decimal     `db:"service_fee"`
+	TaxAmount          decimal.Decimal     `db:"tax_amount"`
+	TaxRate            decimal.Decimal     `db:"tax_rate"`
+	TaxJurisdiction    sql.NullString      `db:"tax_jurisdiction"`
+	TotalPrice         decimal.Decimal     `db:"total_price"`
+	PromoCodeID        sql.NullInt64       `db:"promo_code_id"`
+	PromoCode          sql.NullString      `db:"promo_code"`
+	ExpiresAt          sql.NullInt64       `db:"expires_at"`
+	CancelledAt        sql.NullInt64       `db:"cancelled_at"`
+	CancellationReason sql.NullString      `db:"cancellation_reason"`
+	RefundedAmount     decimal.Decimal     `db:"refunded_amount"`
+}
+
+func (o *Order) ToOrder() *models.Order {
+	// Orders without a recorded subtotal were never discounted
+	subtotal := o.TotalPrice
+	if o.Subtotal.Valid {
+		subtotal = o.Subtotal.Decimal
+	}
+
+	return &models.Order{
+		ID:                 o.ID,
+		UserID:             o.UserID,
+		CreatedAt:          o.CreatedAt,
+		Status:             o.Status,
+		Subtotal:           subtotal,
+		Discount:           o.DiscountAmount,
+		ServiceFee:         o.ServiceFee,
+		Tax:                o.TaxAmount,
+		TaxRate:            o.TaxRate,
+		TaxJurisdiction:    o.TaxJurisdiction.String,
+		TotalPrice:         o.TotalPrice,
+		PromoCodeID:        int(o.PromoCodeID.Int64),
+		PromoCode:          o.PromoCode.String,
+		ExpiresAt:          o.ExpiresAt.Int64,
+		CancelledAt:        o.CancelledAt.Int64,
+		CancellationReason: o.CancellationReason.String,
+		RefundedAmount:     o.RefundedAmount,
+	}
+}
+
+// OrderItem is an order_items row joined with its ticket
+type OrderItem struct {
+	ID              int             `db:"id"`
+	OrderID         int             `db:"order_id"`
+	TicketID        uuid.UUID       `db:"ticket_id"`
+	Price           decimal.Decimal `db:"price"`
+	RefundID        sql.NullInt64   `db:"refund_id"`
+	TicketSessionID int             `db:"ticket_session_id"`
+	TicketStatus    string          `db:"ticket_status"`
+	TicketSection   string          `db:"ticket_section"`
+	TicketRow       string          `db:"ticket_seat_row"`
+	TicketSeat      int             `db:"ticket_seat_number"`
+	TicketTypeID    sql.NullInt64   `db:"ticket_type_id"`
+	TicketTypeName  string          `db:"ticket_type_name"`
+}
+
+func (i *OrderItem) ToOrderItem() models.OrderItem {
+	return models.OrderItem{
+		ID:           i.ID,
+		OrderID:      i.OrderID,
+		TicketID:     i.TicketID,
+		TicketTypeID: int(i.TicketTypeID.Int64),
+		Price:        i.Price,
+		RefundID:     int(i.RefundID.Int64),
+		Ticket: &models.Ticket{
+			ID:         i.TicketID,
+			SessionID:  i.TicketSessionID,
+			Status:     i.TicketStatus,
+			Section:    i.TicketSection,
+			Row:        i.TicketRow,
+			SeatNumber: i.TicketSeat,
+			TicketType: i.TicketTypeName,
+		},
+	}
 }
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"database/sql"
+	models "tickets/internal/models/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+// Payment is a payments row
+type Payment struct {
+	ID                int             `db:"id"`
+	OrderID           int             `db:"order_id"`
+	Provider          string          `db:"provider"`
+	ProviderReference sql.NullString  `db:"provider_reference"`
+	Amount            decimal.Decimal `db:"amount"`
+	Status            string          `db:"status"`
+	FailureCode       sql.NullString  `db:"failure_code"`
+	FailureMessage    sql.NullString  `db:"failure_message"`
+	CreatedAt         int64           `db:"created_at"`
+	UpdatedAt         int64           `db:"updated_at"`
+}
+
+func (p *Payment) ToPayment() *models.Payment {
+	return &models.Payment{
+		ID:                p.ID,
+		OrderID:           p.OrderID,
+		Provider:          p.Provider,
+		ProviderReference: p.ProviderReference.String,
+		Amount:            p.Amount,
+		Status:            p.Status,
+		FailureCode:       p.FailureCode.String,
+		FailureMessage:    p.FailureMessage.String,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+	}
+}
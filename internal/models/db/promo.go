@@ -0,0 +1,41 @@
+package db
+
+import (
+	"database/sql"
+	models "tickets/internal/models/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+// PromoCode is a promo_codes row with the number of pending and paid orders that redeemed it
+type PromoCode struct {
+	ID                    int             `db:"id"`
+	Code                  string          `db:"code"`
+	DiscountType          string          `db:"discount_type"`
+	DiscountValue         decimal.Decimal `db:"discount_value"`
+	ValidFrom             sql.NullInt64   `db:"valid_from"`
+	ValidUntil            sql.NullInt64   `db:"valid_until"`
+	MaxRedemptions        sql.NullInt64   `db:"max_redemptions"`
+	MaxRedemptionsPerUser sql.NullInt64   `db:"max_redemptions_per_user"`
+	ConcertID             sql.NullInt64   `db:"concert_id"`
+	SessionID             sql.NullInt64   `db:"session_id"`
+	Redemptions           int             `db:"redemptions"`
+	CreatedAt             int64           `db:"created_at"`
+}
+
+func (p *PromoCode) ToPromoCode() *models.PromoCode {
+	return &models.PromoCode{
+		ID:                    p.ID,
+		Code:                  p.Code,
+		DiscountType:          p.DiscountType,
+		DiscountValue:         p.DiscountValue,
+		ValidFrom:             p.ValidFrom.Int64,
+		ValidUntil:            p.ValidUntil.Int64,
+		MaxRedemptions:        int(p.MaxRedemptions.Int64),
+		MaxRedemptionsPerUser: int(p.MaxRedemptionsPerUser.Int64),
+		ConcertID:             int(p.ConcertID.Int64),
+		SessionID:             int(p.SessionID.Int64),
+		Redemptions:           p.Redemptions,
+		CreatedAt:             p.CreatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"database/sql"
+	models "tickets/internal/models/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+// Refund is a refunds row with the IDs of the tickets it refunded
+type Refund struct {
+	ID             int             `db:"id"`
+	OrderID        int             `db:"order_id"`
+	PaymentID      sql.NullInt64   `db:"payment_id"`
+	Amount         decimal.Decimal `db:"amount"`
+	Reason         string          `db:"reason"`
+	RefundedBy     string          `db:"refunded_by"`
+	Status         string          `db:"status"`
+	FailureMessage sql.NullString  `db:"failure_message"`
+	CreatedAt      int64           `db:"created_at"`
+	TicketIDs      pq.StringArray  `db:"ticket_ids"`
+}
+
+func (r *Refund) ToRefund() *models.Refund {
+	var ticketIDs []uuid.UUID
+	for _, id := range r.TicketIDs {
+		ticketIDs = append(ticketIDs, uuid.MustParse(id))
+	}
+
+	return &models.Refund{
+		ID:             r.ID,
+		OrderID:        r.OrderID,
+		PaymentID:      int(r.PaymentID.Int64),
+		Amount:         r.Amount,
+		Reason:         r.Reason,
+		RefundedBy:     r.RefundedBy,
+		Status:         r.Status,
+		FailureMessage: r.FailureMessage.String,
+		TicketIDs:      ticketIDs,
+		CreatedAt:      r.CreatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	models "tickets/internal/models/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+// TicketType is a ticket_types row with the number of its tickets held by pending and paid orders
+type TicketType struct {
+	ID          int             `db:"id"`
+	SessionID   int             `db:"session_id"`
+	Name        string          `db:"name"`
+	Description string          `db:"description"`
+	Price       decimal.Decimal `db:"price"`
+	Quota       int             `db:"quota"`
+	Allocated   int             `db:"allocated"`
+}
+
+func (t *TicketType) ToTicketType() *models.TicketType {
+	remaining := t.Quota - t.Allocated
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &models.TicketType{
+		ID:          t.ID,
+		SessionID:   t.SessionID,
+		Name:        t.Name,
+		Description: t.Description,
+		Price:       t.Price,
+		Quota:       t.Quota,
+		Remaining:   remaining,
+	}
+}
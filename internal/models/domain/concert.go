@@ -13,11 +13,13 @@ type Concert struct {
 
 // ConcertSession represents a concert session
 type ConcertSession struct {
-	ID        int             `json:"id"`
-	ConcertID int             `json:"concert_id" binding:"required"`
-	StartTime int64           `json:"start_time" binding:"required"`
-	EndTime   int64           `json:"end_time" binding:"required"`
-	Venue     string          `json:"venue" binding:"required"`
-	Price     decimal.Decimal `json:"price"`
-	Concert   *Concert        `json:"concert,omitempty"`
+	ID             int             `json:"id"`
+	ConcertID      int             `json:"concert_id" binding:"required"`
+	StartTime      int64           `json:"start_time" binding:"required"`
+	EndTime        int64           `json:"end_time" binding:"required"`
+	Venue          string          `json:"venue" binding:"required"`
+	NumberOfSeats  int             `json:"number_of_seats"`
+	RemainingSeats int             `json:"remaining_seats"`
+	Price          decimal.Decimal `json:"price"`
+	Concert        *Concert        `json:"concert,omitempty"`
 }
@@ -0,0 +1,12 @@
+package models
+
+import "encoding/json"
+
+// IdempotencyKey records the response returned for a client-supplied idempotency key
+type IdempotencyKey struct {
+	UserID      int             `json:"user_id"`
+	Key         string          `json:"idempotency_key"`
+	RequestHash string          `json:"request_hash"`
+	Response    json.RawMessage `json:"response,omitempty"`
+	CreatedAt   int64           `json:"created_at"`
+}
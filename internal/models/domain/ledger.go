@@ -0,0 +1,153 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// Ledger accounts. Assets and contra-revenue accounts grow with debits; revenue and liability
+// accounts grow with credits.
+const (
+	// AccountCustomerReceivable is what customers owe for orders they have placed
+	AccountCustomerReceivable = "customer_receivable"
+	// AccountCash is what has been collected from customers
+	AccountCash = "cash"
+	// AccountRevenue is ticket sales at their list price
+	AccountRevenue = "revenue"
+	// AccountDiscounts is what promo codes took off ticket sales
+	AccountDiscounts = "discounts"
+	// AccountFees is service fees charged on orders
+	AccountFees = "fees"
+	// AccountTaxPayable is tax collected on orders and owed to their jurisdictions
+	AccountTaxPayable = "tax_payable"
+	// AccountRefunds is what has been given back to customers
+	AccountRefunds = "refunds"
+	// AccountRefundsPayable is refunds granted but not yet paid back
+	AccountRefundsPayable = "refunds_payable"
+)
+
+// LedgerAccounts lists every ledger account
+var LedgerAccounts = []string{
+	AccountCustomerReceivable,
+	AccountCash,
+	AccountRevenue,
+	AccountDiscounts,
+	AccountFees,
+	AccountTaxPayable,
+	AccountRefunds,
+	AccountRefundsPayable,
+}
+
+// debitNormalAccounts are the accounts whose balance is their debits less their credits
+var debitNormalAccounts = map[string]bool{
+	AccountCustomerReceivable: true,
+	AccountCash:               true,
+	AccountDiscounts:          true,
+	AccountRefunds:            true,
+}
+
+// IsLedgerAccount reports whether account is one of LedgerAccounts
+func IsLedgerAccount(account string) bool {
+	for _, a := range LedgerAccounts {
+		if a == account {
+			return true
+		}
+	}
+	return false
+}
+
+// Ledger transaction kinds
+const (
+	// LedgerOrderPlaced books an order's total as owed by the customer
+	LedgerOrderPlaced = "order_placed"
+	// LedgerOrderReleased reverses an order that expired or was cancelled before it was paid
+	LedgerOrderReleased = "order_released"
+	// LedgerOrderPaid books an order's total as collected
+	LedgerOrderPaid = "order_paid"
+	// LedgerRefundIssued books a refund as owed to the customer
+	LedgerRefundIssued = "refund_issued"
+	// LedgerRefundPaid books a refund as paid back
+	LedgerRefundPaid = "refund_paid"
+	// LedgerRefundFailed reverses a refund that could not be paid back
+	LedgerRefundFailed = "refund_failed"
+)
+
+// LedgerTransaction is a balanced set of ledger entries recording one money movement of an
+// order. PaymentID and RefundID are the payment or refund it records, or 0.
+type LedgerTransaction struct {
+	ID        int           `json:"id"`
+	Kind      string        `json:"kind"`
+	OrderID   int           `json:"order_id"`
+	PaymentID int           `json:"payment_id,omitempty"`
+	RefundID  int           `json:"refund_id,omitempty"`
+	CreatedAt int64         `json:"created_at"`
+	Entries   []LedgerEntry `json:"entries"`
+}
+
+// LedgerEntry debits or credits one account. Exactly one of Debit and Credit is positive.
+type LedgerEntry struct {
+	ID            int             `json:"id"`
+	TransactionID int             `json:"transaction_id"`
+	Account       string          `json:"account"`
+	Debit         decimal.Decimal `json:"debit"`
+	Credit        decimal.Decimal `json:"credit"`
+}
+
+// Debit adds an entry debiting account by amount. Zero amounts are skipped.
+func (t *LedgerTransaction) Debit(account string, amount decimal.Decimal) *LedgerTransaction {
+	if !amount.IsZero() {
+		t.Entries = append(t.Entries, LedgerEntry{Account: account, Debit: amount, Credit: decimal.Zero})
+	}
+	return t
+}
+
+// Credit adds an entry crediting account by amount. Zero amounts are skipped.
+func (t *LedgerTransaction) Credit(account string, amount decimal.Decimal) *LedgerTransaction {
+	if !amount.IsZero() {
+		t.Entries = append(t.Entries, LedgerEntry{Account: account, Debit: decimal.Zero, Credit: amount})
+	}
+	return t
+}
+
+// Balanced reports whether every entry debits or credits a known account by a positive amount
+// and the debits equal the credits
+func (t *LedgerTransaction) Balanced() bool {
+	debits, credits := decimal.Zero, decimal.Zero
+	for _, e := range t.Entries {
+		if !IsLedgerAccount(e.Account) || e.Debit.IsNegative() || e.Credit.IsNegative() ||
+			e.Debit.IsPositive() == e.Credit.IsPositive() {
+			return false
+		}
+		debits = debits.Add(e.Debit)
+		credits = credits.Add(e.Credit)
+	}
+	return debits.Equal(credits)
+}
+
+// AccountBalance totals the entries of a ledger account. Balance is Debits less Credits for
+// asset and contra-revenue accounts and Credits less Debits for the others.
+type AccountBalance struct {
+	Account string          `json:"account"`
+	Debits  decimal.Decimal `json:"debits"`
+	Credits decimal.Decimal `json:"credits"`
+	Balance decimal.Decimal `json:"balance"`
+}
+
+// NewAccountBalance returns the balance of account from its total debits and credits
+func NewAccountBalance(account string, debits, credits decimal.Decimal) AccountBalance {
+	balance := credits.Sub(debits)
+	if debitNormalAccounts[account] {
+		balance = debits.Sub(credits)
+	}
+	return AccountBalance{Account: account, Debits: debits, Credits: credits, Balance: balance}
+}
+
+// LedgerCheck is the result of checking that the ledger balances. UnbalancedTransactionIDs
+// lists the transactions whose debits differ from their credits.
+type LedgerCheck struct {
+	TotalDebits              decimal.Decimal `json:"total_debits"`
+	TotalCredits             decimal.Decimal `json:"total_credits"`
+	UnbalancedTransactionIDs []int           `json:"unbalanced_transaction_ids,omitempty"`
+}
+
+// Balanced reports whether total debits equal total credits and every transaction balances
+func (c *LedgerCheck) Balanced() bool {
+	return c.TotalDebits.Equal(c.TotalCredits) && len(c.UnbalancedTransactionIDs) == 0
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerTransaction_Balanced(t *testing.T) {
+	placed := (&LedgerTransaction{Kind: LedgerOrderPlaced}).
+		Debit(AccountCustomerReceivable, decimal.RequireFromString("90.00")).
+		Debit(AccountDiscounts, decimal.RequireFromString("10.00")).
+		Credit(AccountRevenue, decimal.RequireFromString("100.00"))
+	assert.True(t, placed.Balanced())
+	assert.Len(t, placed.Entries, 3)
+
+	// Zero amounts are left out
+	undiscounted := (&LedgerTransaction{}).
+		Debit(AccountCustomerReceivable, decimal.NewFromInt(50)).
+		Debit(AccountDiscounts, decimal.Zero).
+		Credit(AccountRevenue, decimal.NewFromInt(50))
+	assert.Len(t, undiscounted.Entries, 2)
+	assert.True(t, undiscounted.Balanced())
+
+	unequal := (&LedgerTransaction{}).
+		Debit(AccountCash, decimal.NewFromInt(50)).
+		Credit(AccountCustomerReceivable, decimal.NewFromInt(40))
+	assert.False(t, unequal.Balanced())
+
+	unknown := (&LedgerTransaction{}).
+		Debit("petty_cash", decimal.NewFromInt(50)).
+		Credit(AccountCustomerReceivable, decimal.NewFromInt(50))
+	assert.False(t, unknown.Balanced())
+
+	twoSided := &LedgerTransaction{Entries: []LedgerEntry{
+		{Account: AccountCash, Debit: decimal.NewFromInt(5), Credit: decimal.NewFromInt(5)},
+	}}
+	assert.False(t, twoSided.Balanced())
+}
+
+func TestNewAccountBalance(t *testing.T) {
+	debits, credits := decimal.NewFromInt(100), decimal.NewFromInt(30)
+
+	cash := NewAccountBalance(AccountCash, debits, credits)
+	assert.True(t, decimal.NewFromInt(70).Equal(cash.Balance))
+
+	revenue := NewAccountBalance(AccountRevenue, credits, debits)
+	assert.True(t, decimal.NewFromInt(70).Equal(revenue.Balance))
+}
+
+func TestLedgerCheck_Balanced(t *testing.T) {
+	check := LedgerCheck{TotalDebits: decimal.NewFromInt(10), TotalCredits: decimal.NewFromInt(10)}
+	assert.True(t, check.Balanced())
+
+	check.UnbalancedTransactionIDs = []int{3}
+	assert.False(t, check.Balanced())
+
+	assert.False(t, (&LedgerCheck{TotalDebits: decimal.NewFromInt(10), TotalCredits: decimal.Zero}).Balanced())
+}
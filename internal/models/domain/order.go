@@ -5,20 +5,84 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// Order represents an order in the system
+// Order represents an order in the system. TotalPrice is Subtotal, the sum of the item prices,
+// less the Discount of the promo code applied at checkout, if any, plus the ServiceFee and Tax.
+// Tax was charged at TaxRate percent in TaxJurisdiction, which is empty for untaxed orders.
+// RefundedAmount is the sum of the order's succeeded refunds.
 type Order struct {
-	ID         int             `json:"id"`
-	CreatedAt  int64           `json:"created_at"`
-	Status     string          `json:"status"`
-	TotalPrice decimal.Decimal `json:"total_price"`
-	Items      []OrderItem     `json:"items,omitempty"`
+	ID                 int             `json:"id"`
+	UserID             int             `json:"user_id"`
+	CreatedAt          int64           `json:"created_at"`
+	Status             string          `json:"status"`
+	Subtotal           decimal.Decimal `json:"subtotal"`
+	Discount           decimal.Decimal `json:"discount"`
+	ServiceFee         decimal.Decimal `json:"service_fee"`
+	Tax                decimal.Decimal `json:"tax"`
+	TaxRate            decimal.Decimal `json:"tax_rate"`
+	TaxJurisdiction    string          `json:"tax_jurisdiction,omitempty"`
+	TotalPrice         decimal.Decimal `json:"total_price"`
+	PromoCodeID        int             `json:"promo_code_id,omitempty"`
+	PromoCode          string          `json:"promo_code,omitempty"`
+	ExpiresAt          int64           `json:"expires_at,omitempty"`
+	CancelledAt        int64           `json:"cancelled_at,omitempty"`
+	CancellationReason string          `json:"cancellation_reason,omitempty"`
+	RefundedAmount     decimal.Decimal `json:"refunded_amount"`
+	Items              []OrderItem     `json:"items,omitempty"`
 }
 
-// OrderItem represents an order item
+// OrderItem represents an order item. TicketTypeID is 0 for tickets sold at the session price.
+// RefundID is the refund that refunded the item, or 0.
 type OrderItem struct {
-	ID       int             `json:"id"`
-	OrderID  int             `json:"order_id" binding:"required"`
-	TicketID uuid.UUID       `json:"ticket_id" binding:"required"`
-	Price    decimal.Decimal `json:"price" binding:"required"`
-	Ticket   *Ticket         `json:"ticket,omitempty"`
+	ID           int             `json:"id"`
+	OrderID      int             `json:"order_id" binding:"required"`
+	TicketID     uuid.UUID       `json:"ticket_id" binding:"required"`
+	TicketTypeID int             `json:"ticket_type_id,omitempty"`
+	Price        decimal.Decimal `json:"price" binding:"required"`
+	RefundID     int             `json:"refund_id,omitempty"`
+	Ticket       *Ticket         `json:"ticket,omitempty"`
+}
+
+// RefundAmount returns what refunding items of the order gives back: their share of the total,
+// in proportion to their prices, rounded to cents. The share covers the discount, service fee
+// and tax alike. Free tickets share the total, such as a service fee, evenly. Refunding every
+// item that is not yet refunded gives back the rest of the total, so rounding never leaves part
+// of it unrefunded.
+func (o *Order) RefundAmount(items []OrderItem) decimal.Decimal {
+	remaining := 0
+	for _, item := range o.Items {
+		if item.RefundID == 0 {
+			remaining++
+		}
+	}
+	if len(items) == remaining {
+		return o.TotalPrice.Sub(o.RefundedAmount)
+	}
+	if !o.Subtotal.IsPositive() {
+		return o.TotalPrice.Mul(decimal.NewFromInt(int64(len(items)))).
+			Div(decimal.NewFromInt(int64(len(o.Items)))).Round(2)
+	}
+
+	prices := decimal.Zero
+	for _, item := range items {
+		prices = prices.Add(item.Price)
+	}
+	return prices.Mul(o.TotalPrice).Div(o.Subtotal).Round(2)
+}
+
+// RefundShares splits a refund of amount from the order into the parts that give back its
+// service fee and tax. Each is the share of everything refunded so far, this refund included,
+// less the share of what RefundedAmount already gave back, both in proportion to the total and
+// rounded to cents. The refund that completes the order so takes back exactly the fee and tax
+// that are left. The rest of the amount gives back the ticket prices.
+func (o *Order) RefundShares(amount decimal.Decimal) (fee, tax decimal.Decimal) {
+	if !o.TotalPrice.IsPositive() {
+		return decimal.Zero, decimal.Zero
+	}
+	share := func(part, refunded decimal.Decimal) decimal.Decimal {
+		return refunded.Mul(part).Div(o.TotalPrice).Round(2)
+	}
+	after := o.RefundedAmount.Add(amount)
+	fee = share(o.ServiceFee, after).Sub(share(o.ServiceFee, o.RefundedAmount))
+	tax = share(o.Tax, after).Sub(share(o.Tax, o.RefundedAmount))
+	return fee, tax
 }
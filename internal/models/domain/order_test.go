@@ -373,3 +373,107 @@ func TestOrderItem_PriceHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestOrder_RefundAmount(t *testing.T) {
+	items := []OrderItem{
+		{ID: 1, Price: decimal.RequireFromString("50.00")},
+		{ID: 2, Price: decimal.RequireFromString("50.00")},
+		{ID: 3, Price: decimal.RequireFromString("50.00")},
+	}
+	order := Order{
+		Subtotal:   decimal.RequireFromString("150.00"),
+		Discount:   decimal.RequireFromString("10.00"),
+		TotalPrice: decimal.RequireFromString("140.00"),
+		Items:      append([]OrderItem(nil), items...),
+	}
+
+	// Each item carries a third of the discount, rounded to cents
+	first := order.RefundAmount(items[:1])
+	assert.True(t, decimal.RequireFromString("46.67").Equal(first), "got %s", first)
+
+	// The last items refunded get the rest of the total
+	order.Items[0].RefundID = 1
+	order.RefundedAmount = first
+	rest := order.RefundAmount(items[1:])
+	assert.True(t, decimal.RequireFromString("93.33").Equal(rest), "got %s", rest)
+
+	full := Order{Subtotal: order.Subtotal, TotalPrice: order.TotalPrice, Items: items[:2]}
+	assert.True(t, decimal.RequireFromString("140.00").Equal(full.RefundAmount(items[:2])))
+
+	free := Order{Subtotal: decimal.Zero, TotalPrice: decimal.Zero, Items: items}
+	assert.True(t, free.RefundAmount(items[:1]).IsZero())
+
+	// Free tickets with a fee each get an even share of it
+	freeItems := []OrderItem{{ID: 1, Price: decimal.Zero}, {ID: 2, Price: decimal.Zero}, {ID: 3, Price: decimal.Zero}}
+	feeOnly := Order{
+		Subtotal:   decimal.Zero,
+		ServiceFee: decimal.RequireFromString("5.00"),
+		TotalPrice: decimal.RequireFromString("5.00"),
+		Items:      append([]OrderItem(nil), freeItems...),
+	}
+	share := feeOnly.RefundAmount(freeItems[:1])
+	assert.True(t, decimal.RequireFromString("1.67").Equal(share), "got %s", share)
+	feeOnly.Items[0].RefundID = 1
+	feeOnly.RefundedAmount = share
+	rest = feeOnly.RefundAmount(freeItems[1:])
+	assert.True(t, decimal.RequireFromString("3.33").Equal(rest), "got %s", rest)
+}
+
+func TestOrder_RefundShares(t *testing.T) {
+	// 100.00 of tickets with a 6.50 fee and 21.30 tax
+	order := Order{
+		Subtotal:   decimal.RequireFromString("100.00"),
+		ServiceFee: decimal.RequireFromString("6.50"),
+		Tax:        decimal.RequireFromString("21.30"),
+		TotalPrice: decimal.RequireFromString("127.80"),
+	}
+
+	fee, tax := order.RefundShares(order.TotalPrice)
+	assert.True(t, order.ServiceFee.Equal(fee), "fee %s", fee)
+	assert.True(t, order.Tax.Equal(tax), "tax %s", tax)
+
+	// A third of the total: 2.1666… of fee and 7.10 of tax
+	fee, tax = order.RefundShares(decimal.RequireFromString("42.60"))
+	assert.True(t, decimal.RequireFromString("2.17").Equal(fee), "fee %s", fee)
+	assert.True(t, decimal.RequireFromString("7.10").Equal(tax), "tax %s", tax)
+
+	free := Order{}
+	fee, tax = free.RefundShares(decimal.Zero)
+	assert.True(t, fee.IsZero())
+	assert.True(t, tax.IsZero())
+}
+
+func TestOrder_RefundShares_OneItemAtATime(t *testing.T) {
+	// 57.61 + 13.19 + 20.22 = 91.02 of tickets, 3 * 1.50 + 2.00 = 6.50 of fees and 8.875% tax
+	// of 97.52, 8.6549, rounded to 8.65. Rounding each refund's shares on its own would give
+	// back 6.49 and 8.64.
+	items := []OrderItem{
+		{ID: 1, Price: decimal.RequireFromString("57.61")},
+		{ID: 2, Price: decimal.RequireFromString("13.19")},
+		{ID: 3, Price: decimal.RequireFromString("20.22")},
+	}
+	order := Order{
+		Subtotal:   decimal.RequireFromString("91.02"),
+		ServiceFee: decimal.RequireFromString("6.50"),
+		Tax:        decimal.RequireFromString("8.65"),
+		TotalPrice: decimal.RequireFromString("106.17"),
+		Items:      append([]OrderItem(nil), items...),
+	}
+
+	fees, taxes, prices := decimal.Zero, decimal.Zero, decimal.Zero
+	for i := range items {
+		amount := order.RefundAmount(items[i : i+1])
+		fee, tax := order.RefundShares(amount)
+		assert.False(t, fee.IsNegative() || tax.IsNegative(), "refund %d: fee %s, tax %s", i, fee, tax)
+		fees, taxes, prices = fees.Add(fee), taxes.Add(tax), prices.Add(amount.Sub(fee).Sub(tax))
+
+		order.Items[i].RefundID = i + 1
+		order.RefundedAmount = order.RefundedAmount.Add(amount)
+	}
+
+	// Once every item is refunded, exactly the fee and tax were given back
+	assert.True(t, order.TotalPrice.Equal(order.RefundedAmount), "refunded %s", order.RefundedAmount)
+	assert.True(t, order.ServiceFee.Equal(fees), "fees %s", fees)
+	assert.True(t, order.Tax.Equal(taxes), "taxes %s", taxes)
+	assert.True(t, order.Subtotal.Equal(prices), "prices %s", prices)
+}
@@ -0,0 +1,40 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// Payment statuses, matching the payments.status CHECK constraint
+const (
+	PaymentStatusPending    = "pending"
+	PaymentStatusAuthorized = "authorized"
+	PaymentStatusCaptured   = "captured"
+	PaymentStatusDeclined   = "declined"
+	PaymentStatusFailed     = "failed"
+	PaymentStatusVoided     = "voided"
+	PaymentStatusRefunded   = "refunded"
+)
+
+// Payment is one attempt to pay an order with a payment provider. ProviderReference is the
+// provider's id for the payment, empty until the provider has authorized it. FailureCode and
+// FailureMessage explain a declined or failed payment.
+type Payment struct {
+	ID                int             `json:"id"`
+	OrderID           int             `json:"order_id"`
+	Provider          string          `json:"provider"`
+	ProviderReference string          `json:"provider_reference,omitempty"`
+	Amount            decimal.Decimal `json:"amount"`
+	Status            string          `json:"status"`
+	FailureCode       string          `json:"failure_code,omitempty"`
+	FailureMessage    string          `json:"failure_message,omitempty"`
+	CreatedAt         int64           `json:"created_at"`
+	UpdatedAt         int64           `json:"updated_at"`
+}
+
+// PaymentEvent records a payment provider webhook event that has been applied to a payment.
+// EventID is the provider's id for the event, unique per provider.
+type PaymentEvent struct {
+	Provider   string `json:"provider"`
+	EventID    string `json:"event_id"`
+	PaymentID  int    `json:"payment_id"`
+	Type       string `json:"type"`
+	ReceivedAt int64  `json:"received_at"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// Promo code discount types, matching the promo_codes.discount_type CHECK constraint
+const (
+	DiscountTypePercentage = "percentage"
+	DiscountTypeFixed      = "fixed"
+)
+
+// PromoCode discounts an order by DiscountValue percent of its subtotal or by a fixed
+// DiscountValue. Zero-valued limits and scopes do not apply: a code without ValidFrom,
+// ValidUntil, MaxRedemptions, MaxRedemptionsPerUser, ConcertID or SessionID is valid for any
+// number of orders of any session at any time.
+type PromoCode struct {
+	ID                    int             `json:"id"`
+	Code                  string          `json:"code" binding:"required"`
+	DiscountType          string          `json:"discount_type" binding:"required"`
+	DiscountValue         decimal.Decimal `json:"discount_value" binding:"required"`
+	ValidFrom             int64           `json:"valid_from,omitempty"`
+	ValidUntil            int64           `json:"valid_until,omitempty"`
+	MaxRedemptions        int             `json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser int             `json:"max_redemptions_per_user,omitempty"`
+	ConcertID             int             `json:"concert_id,omitempty"`
+	SessionID             int             `json:"session_id,omitempty"`
+	Redemptions           int             `json:"redemptions"`
+	CreatedAt             int64           `json:"created_at"`
+}
+
+// ActiveAt reports whether the code is within its validity window at now (Unix milliseconds).
+// ValidUntil is exclusive.
+func (p *PromoCode) ActiveAt(now int64) bool {
+	return (p.ValidFrom == 0 || now >= p.ValidFrom) && (p.ValidUntil == 0 || now < p.ValidUntil)
+}
+
+// AppliesTo reports whether the code may be used for tickets of a session
+func (p *PromoCode) AppliesTo(session *ConcertSession) bool {
+	return (p.ConcertID == 0 || p.ConcertID == session.ConcertID) &&
+		(p.SessionID == 0 || p.SessionID == session.ID)
+}
+
+// Discount returns the amount the code takes off subtotal, rounded to cents and never more
+// than subtotal
+func (p *PromoCode) Discount(subtotal decimal.Decimal) decimal.Decimal {
+	discount := p.DiscountValue
+	if p.DiscountType == DiscountTypePercentage {
+		discount = subtotal.Mul(p.DiscountValue).Div(decimal.NewFromInt(100)).Round(2)
+	}
+	if discount.GreaterThan(subtotal) {
+		return subtotal
+	}
+	return discount
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoCode_Discount(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     PromoCode
+		subtotal string
+		discount string
+	}{
+		{"percentage", PromoCode{DiscountType: DiscountTypePercentage, DiscountValue: decimal.NewFromInt(10)}, "150.00", "15.00"},
+		{"percentage rounds to cents", PromoCode{DiscountType: DiscountTypePercentage, DiscountValue: decimal.NewFromInt(15)}, "33.33", "5.00"},
+		{"full percentage", PromoCode{DiscountType: DiscountTypePercentage, DiscountValue: decimal.NewFromInt(100)}, "80.00", "80.00"},
+		{"fixed", PromoCode{DiscountType: DiscountTypeFixed, DiscountValue: decimal.NewFromInt(20)}, "150.00", "20.00"},
+		{"fixed capped at subtotal", PromoCode{DiscountType: DiscountTypeFixed, DiscountValue: decimal.NewFromInt(200)}, "150.00", "150.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discount := tt.code.Discount(decimal.RequireFromString(tt.subtotal))
+			assert.True(t, decimal.RequireFromString(tt.discount).Equal(discount), "got %s", discount)
+		})
+	}
+}
+
+func TestPromoCode_ActiveAt(t *testing.T) {
+	unbounded := PromoCode{}
+	assert.True(t, unbounded.ActiveAt(1))
+
+	window := PromoCode{ValidFrom: 1000, ValidUntil: 2000}
+	assert.False(t, window.ActiveAt(999))
+	assert.True(t, window.ActiveAt(1000))
+	assert.True(t, window.ActiveAt(1999))
+	assert.False(t, window.ActiveAt(2000))
+}
+
+func TestPromoCode_AppliesTo(t *testing.T) {
+	session := &ConcertSession{ID: 7, ConcertID: 3}
+
+	assert.True(t, (&PromoCode{}).AppliesTo(session))
+	assert.True(t, (&PromoCode{ConcertID: 3}).AppliesTo(session))
+	assert.False(t, (&PromoCode{ConcertID: 4}).AppliesTo(session))
+	assert.True(t, (&PromoCode{SessionID: 7}).AppliesTo(session))
+	assert.False(t, (&PromoCode{SessionID: 8}).AppliesTo(session))
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Refund statuses, matching the refunds.status CHECK constraint
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+// Refund returns money for some or all tickets of a paid order. PaymentID is the captured
+// payment refunded with the payment provider, or 0 for an order that was confirmed without
+// one. RefundedBy identifies who issued the refund; FailureMessage explains a failed refund.
+type Refund struct {
+	ID             int             `json:"id"`
+	OrderID        int             `json:"order_id"`
+	PaymentID      int             `json:"payment_id,omitempty"`
+	Amount         decimal.Decimal `json:"amount"`
+	Reason         string          `json:"reason"`
+	RefundedBy     string          `json:"refunded_by"`
+	Status         string          `json:"status"`
+	FailureMessage string          `json:"failure_message,omitempty"`
+	TicketIDs      []uuid.UUID     `json:"ticket_ids,omitempty"`
+	CreatedAt      int64           `json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+// SectionLayout describes a block of reserved seats: Rows rows labelled A, B, ... Z, AA, AB, ...
+// each holding SeatsPerRow seats numbered from 1
+type SectionLayout struct {
+	Name        string `json:"name" binding:"required"`
+	Rows        int    `json:"rows" binding:"required"`
+	SeatsPerRow int    `json:"seats_per_row" binding:"required"`
+}
+
+// Capacity returns the number of seats in the section
+func (l SectionLayout) Capacity() int {
+	return l.Rows * l.SeatsPerRow
+}
+
+// RowLabels returns the labels of the section's rows, front to back
+func (l SectionLayout) RowLabels() []string {
+	labels := make([]string, l.Rows)
+	for i := range labels {
+		labels[i] = RowLabel(i)
+	}
+	return labels
+}
+
+// RowLabel returns the spreadsheet-style label of the row at a zero-based index: 0 is "A",
+// 25 is "Z", 26 is "AA"
+func RowLabel(index int) string {
+	label := ""
+	for index >= 0 {
+		label = string(rune('A'+index%26)) + label
+		index = index/26 - 1
+	}
+	return label
+}
+
+// SeatBlock is a run of Length adjacent seats in one row, numbered from FirstSeat
+type SeatBlock struct {
+	Section   string `json:"section" db:"section"`
+	Row       string `json:"row" db:"seat_row"`
+	FirstSeat int    `json:"first_seat" db:"first_seat"`
+	Length    int    `json:"length" db:"length"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowLabel(t *testing.T) {
+	tests := map[int]string{0: "A", 1: "B", 25: "Z", 26: "AA", 27: "AB", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for index, label := range tests {
+		assert.Equal(t, label, RowLabel(index), "index %d", index)
+	}
+}
+
+func TestSectionLayout(t *testing.T) {
+	layout := SectionLayout{Name: "Stalls", Rows: 3, SeatsPerRow: 10}
+	assert.Equal(t, 30, layout.Capacity())
+	assert.Equal(t, []string{"A", "B", "C"}, layout.RowLabels())
+}
+
+func TestTicket_SeatLabel(t *testing.T) {
+	seated := Ticket{ID: uuid.New(), Section: "Stalls", Row: "C", SeatNumber: 12}
+	assert.True(t, seated.HasSeat())
+	assert.Equal(t, "Stalls C12", seated.SeatLabel())
+
+	general := Ticket{ID: uuid.New()}
+	assert.False(t, general.HasSeat())
+	assert.Equal(t, general.ID.String(), general.SeatLabel())
+}
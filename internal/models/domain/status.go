@@ -0,0 +1,68 @@
+package models
+
+import "fmt"
+
+// Order statuses
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusExpired   = "expired"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusRefunded  = "refunded"
+)
+
+// Ticket statuses, matching the tickets.status CHECK constraint
+const (
+	TicketStatusAvailable = "available"
+	TicketStatusPending   = "pending"
+	TicketStatusSold      = "sold"
+	TicketStatusVoid      = "void"
+)
+
+// orderTransitions lists the statuses each order status may move to
+var orderTransitions = map[string][]string{
+	OrderStatusPending: {OrderStatusPaid, OrderStatusExpired, OrderStatusCancelled},
+	OrderStatusPaid:    {OrderStatusCancelled, OrderStatusRefunded},
+}
+
+// ticketTransitions lists the statuses each ticket status may move to
+var ticketTransitions = map[string][]string{
+	TicketStatusAvailable: {TicketStatusPending},
+	TicketStatusPending:   {TicketStatusSold, TicketStatusAvailable},
+	TicketStatusSold:      {TicketStatusAvailable, TicketStatusVoid},
+}
+
+// CanTransitionOrder reports whether an order may move from one status to another
+func CanTransitionOrder(from, to string) bool {
+	return canTransition(orderTransitions, from, to)
+}
+
+// CanTransitionTicket reports whether a ticket may move from one status to another
+func CanTransitionTicket(from, to string) bool {
+	return canTransition(ticketTransitions, from, to)
+}
+
+// ValidateOrderTransition returns an error if an order may not move from one status to another
+func ValidateOrderTransition(from, to string) error {
+	if !CanTransitionOrder(from, to) {
+		return fmt.Errorf("invalid order status transition from %q to %q", from, to)
+	}
+	return nil
+}
+
+// ValidateTicketTransition returns an error if a ticket may not move from one status to another
+func ValidateTicketTransition(from, to string) error {
+	if !CanTransitionTicket(from, to) {
+		return fmt.Errorf("invalid ticket status transition from %q to %q", from, to)
+	}
+	return nil
+}
+
+func canTransition(transitions map[string][]string, from, to string) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransitionOrder(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		allowed bool
+	}{
+		{OrderStatusPending, OrderStatusPaid, true},
+		{OrderStatusPending, OrderStatusExpired, true},
+		{OrderStatusPending, OrderStatusCancelled, true},
+		{OrderStatusPaid, OrderStatusCancelled, true},
+		{OrderStatusPaid, OrderStatusRefunded, true},
+		{OrderStatusPending, OrderStatusRefunded, false},
+		{OrderStatusRefunded, OrderStatusPaid, false},
+		{OrderStatusRefunded, OrderStatusCancelled, false},
+		{OrderStatusPaid, OrderStatusPending, false},
+		{OrderStatusPaid, OrderStatusExpired, false},
+		{OrderStatusExpired, OrderStatusPaid, false},
+		{OrderStatusExpired, OrderStatusPending, false},
+		{OrderStatusExpired, OrderStatusCancelled, false},
+		{OrderStatusCancelled, OrderStatusPending, false},
+		{OrderStatusCancelled, OrderStatusPaid, false},
+		{OrderStatusPending, OrderStatusPending, false},
+		{"unknown", OrderStatusPaid, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"_to_"+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, CanTransitionOrder(tt.from, tt.to))
+
+			err := ValidateOrderTransition(tt.from, tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCanTransitionTicket(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		allowed bool
+	}{
+		{TicketStatusAvailable, TicketStatusPending, true},
+		{TicketStatusPending, TicketStatusSold, true},
+		{TicketStatusPending, TicketStatusAvailable, true},
+		{TicketStatusAvailable, TicketStatusSold, false},
+		{TicketStatusSold, TicketStatusAvailable, true},
+		{TicketStatusSold, TicketStatusPending, false},
+		{TicketStatusSold, TicketStatusVoid, true},
+		{TicketStatusAvailable, TicketStatusVoid, false},
+		{TicketStatusVoid, TicketStatusAvailable, false},
+		{TicketStatusAvailable, TicketStatusAvailable, false},
+		{"unknown", TicketStatusSold, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"_to_"+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, CanTransitionTicket(tt.from, tt.to))
+
+			err := ValidateTicketTransition(tt.from, tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -1,21 +1,49 @@
 package models
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
-// TicketType represents a ticket type
+// TicketType is a priced tier of a session's tickets, such as VIP or student. Quota caps how
+// many tickets of the type pending and paid orders may hold; Remaining is what is left of it.
 type TicketType struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
+	ID          int             `json:"id"`
+	SessionID   int             `json:"session_id"`
+	Name        string          `json:"name" binding:"required"`
+	Description string          `json:"description"`
+	Price       decimal.Decimal `json:"price"`
+	Quota       int             `json:"quota"`
+	Remaining   int             `json:"remaining"`
 }
 
-// Ticket represents a ticket in the system
+// Ticket represents a ticket in the system. Section, Row and SeatNumber locate a reserved
+// seat and are empty for general admission tickets. TicketType names the type the ticket was
+// ordered as and is only set on tickets loaded with their order.
 type Ticket struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	SessionID int       `json:"session_id" db:"session_id"`
-	Status    string    `json:"status" db:"status"`
+	ID         uuid.UUID `json:"id" db:"id"`
+	SessionID  int       `json:"session_id" db:"session_id"`
+	Status     string    `json:"status" db:"status"`
+	Section    string    `json:"section,omitempty" db:"section"`
+	Row        string    `json:"row,omitempty" db:"seat_row"`
+	SeatNumber int       `json:"seat_number,omitempty" db:"seat_number"`
+	TicketType string    `json:"ticket_type,omitempty" db:"-"`
+}
+
+// HasSeat reports whether the ticket is for a reserved seat
+func (t *Ticket) HasSeat() bool {
+	return t.SeatNumber > 0
+}
+
+// SeatLabel returns a human-readable seat location such as "Stalls C12", or the ticket ID
+// for general admission tickets
+func (t *Ticket) SeatLabel() string {
+	if !t.HasSeat() {
+		return t.ID.String()
+	}
+	return fmt.Sprintf("%s %s%d", t.Section, t.Row, t.SeatNumber)
 }
 
 // CreateTicketRequest represents the request structure for creating a ticket
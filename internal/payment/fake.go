@@ -0,0 +1,157 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Fake provider behaviours
+const (
+	// FakeApprove approves every payment
+	FakeApprove = "approve"
+	// FakeDecline declines every authorization with the card_declined code
+	FakeDecline = "decline"
+	// FakeTimeout fails every authorization with ErrTimeout
+	FakeTimeout = "timeout"
+)
+
+// Payment methods that make the fake provider decline or time out regardless of its behaviour,
+// so tests and local clients can exercise each outcome
+const (
+	FakeMethodDecline           = "fake_decline"
+	FakeMethodInsufficientFunds = "fake_insufficient_funds"
+	FakeMethodTimeout           = "fake_timeout"
+)
+
+// FakeConfig configures the fake provider. Behavior is one of FakeApprove (the default),
+// FakeDecline or FakeTimeout.
+type FakeConfig struct {
+	Behavior string
+}
+
+// FakeProvider is a deterministic in-process Provider for tests and local runs. It keeps
+// payments in memory, gives them random references so they stay unique across restarts, and
+// never calls the network.
+type FakeProvider struct {
+	behavior string
+
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+}
+
+// fakePayment tracks what has happened to an authorization
+type fakePayment struct {
+	authorized decimal.Decimal
+	captured   decimal.Decimal
+	refunded   decimal.Decimal
+	voided     bool
+}
+
+// NewFakeProvider creates a fake provider
+func NewFakeProvider(cfg FakeConfig) (*FakeProvider, error) {
+	behavior := cfg.Behavior
+	switch behavior {
+	case "":
+		behavior = FakeApprove
+	case FakeApprove, FakeDecline, FakeTimeout:
+	default:
+		return nil, fmt.Errorf("unknown fake payment behavior %q", cfg.Behavior)
+	}
+
+	return &FakeProvider{
+		behavior: behavior,
+		payments: make(map[string]*fakePayment),
+	}, nil
+}
+
+// Name returns "fake"
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+// Authorize approves, declines or times out according to the payment method and the
+// provider's behaviour
+func (p *FakeProvider) Authorize(ctx context.Context, req AuthorizeRequest) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case req.PaymentMethod == FakeMethodTimeout || p.behavior == FakeTimeout:
+		return nil, ErrTimeout
+	case req.PaymentMethod == FakeMethodInsufficientFunds:
+		return nil, &DeclineError{Code: "insufficient_funds", Message: "the card has insufficient funds"}
+	case req.PaymentMethod == FakeMethodDecline || p.behavior == FakeDecline:
+		return nil, &DeclineError{Code: "card_declined", Message: "the card was declined"}
+	}
+	if req.Amount.IsNegative() {
+		return nil, fmt.Errorf("%w: negative amount", ErrInvalidTransaction)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	reference := "fake_" + uuid.NewString()
+	p.payments[reference] = &fakePayment{authorized: req.Amount}
+
+	return &Result{Reference: reference, Amount: req.Amount}, nil
+}
+
+// Capture collects up to the authorized amount once
+func (p *FakeProvider) Capture(ctx context.Context, reference string, amount decimal.Decimal) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[reference]
+	if !ok || payment.voided || payment.captured.IsPositive() ||
+		amount.IsNegative() || amount.GreaterThan(payment.authorized) {
+		return nil, ErrInvalidTransaction
+	}
+	payment.captured = amount
+
+	return &Result{Reference: reference, Amount: amount}, nil
+}
+
+// Refund returns up to the captured amount not yet refunded
+func (p *FakeProvider) Refund(ctx context.Context, reference string, amount decimal.Decimal) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[reference]
+	if !ok || !amount.IsPositive() || amount.GreaterThan(payment.captured.Sub(payment.refunded)) {
+		return nil, ErrInvalidTransaction
+	}
+	payment.refunded = payment.refunded.Add(amount)
+
+	return &Result{Reference: reference, Amount: amount}, nil
+}
+
+// Void releases an authorization that was not captured
+func (p *FakeProvider) Void(ctx context.Context, reference string) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[reference]
+	if !ok || payment.captured.IsPositive() {
+		return nil, ErrInvalidTransaction
+	}
+	payment.voided = true
+
+	return &Result{Reference: reference, Amount: payment.authorized}, nil
+}
@@ -0,0 +1,122 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(ProviderFake, FakeConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, ProviderFake, provider.Name())
+
+	_, err = NewProvider("stripe", FakeConfig{})
+	assert.Error(t, err)
+
+	_, err = NewProvider(ProviderFake, FakeConfig{Behavior: "flaky"})
+	assert.Error(t, err)
+}
+
+func TestFakeProvider_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	provider, err := NewFakeProvider(FakeConfig{})
+	require.NoError(t, err)
+
+	amount := decimal.RequireFromString("150.00")
+	auth, err := provider.Authorize(ctx, AuthorizeRequest{OrderID: 1, Amount: amount, PaymentMethod: "card"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(auth.Reference, "fake_"), "got %s", auth.Reference)
+	first := auth.Reference
+
+	// More than the authorized amount cannot be captured
+	_, err = provider.Capture(ctx, auth.Reference, amount.Add(decimal.NewFromInt(1)))
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+
+	_, err = provider.Capture(ctx, auth.Reference, amount)
+	require.NoError(t, err)
+
+	// A captured payment can be refunded but not captured again or voided
+	_, err = provider.Capture(ctx, auth.Reference, amount)
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+	_, err = provider.Void(ctx, auth.Reference)
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+
+	_, err = provider.Refund(ctx, auth.Reference, decimal.RequireFromString("100.00"))
+	require.NoError(t, err)
+	_, err = provider.Refund(ctx, auth.Reference, decimal.RequireFromString("50.01"))
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+	_, err = provider.Refund(ctx, auth.Reference, decimal.RequireFromString("50.00"))
+	require.NoError(t, err)
+
+	// A voided authorization cannot be captured
+	auth, err = provider.Authorize(ctx, AuthorizeRequest{OrderID: 2, Amount: amount, PaymentMethod: "card"})
+	require.NoError(t, err)
+	assert.NotEqual(t, first, auth.Reference)
+	_, err = provider.Void(ctx, auth.Reference)
+	require.NoError(t, err)
+	_, err = provider.Capture(ctx, auth.Reference, amount)
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+
+	_, err = provider.Capture(ctx, "unknown", amount)
+	assert.ErrorIs(t, err, ErrInvalidTransaction)
+
+	// A restarted provider does not hand out references already stored
+	restarted, err := NewFakeProvider(FakeConfig{})
+	require.NoError(t, err)
+	again, err := restarted.Authorize(ctx, AuthorizeRequest{OrderID: 3, Amount: amount, PaymentMethod: "card"})
+	require.NoError(t, err)
+	assert.NotEqual(t, first, again.Reference)
+}
+
+func TestFakeProvider_Failures(t *testing.T) {
+	ctx := context.Background()
+	amount := decimal.NewFromInt(40)
+
+	approving, err := NewFakeProvider(FakeConfig{Behavior: FakeApprove})
+	require.NoError(t, err)
+	declining, err := NewFakeProvider(FakeConfig{Behavior: FakeDecline})
+	require.NoError(t, err)
+	timingOut, err := NewFakeProvider(FakeConfig{Behavior: FakeTimeout})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		provider *FakeProvider
+		method   string
+		wantErr  error
+		wantCode string
+	}{
+		{"decline behavior", declining, "card", ErrDeclined, "card_declined"},
+		{"timeout behavior", timingOut, "card", ErrTimeout, ""},
+		{"decline method", approving, FakeMethodDecline, ErrDeclined, "card_declined"},
+		{"insufficient funds method", approving, FakeMethodInsufficientFunds, ErrDeclined, "insufficient_funds"},
+		{"timeout method", approving, FakeMethodTimeout, ErrTimeout, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.provider.Authorize(ctx, AuthorizeRequest{OrderID: 1, Amount: amount, PaymentMethod: tt.method})
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var decline *DeclineError
+			if tt.wantCode != "" {
+				require.True(t, errors.As(err, &decline))
+				assert.Equal(t, tt.wantCode, decline.Code)
+			} else {
+				assert.False(t, errors.As(err, &decline))
+			}
+		})
+	}
+
+	// A cancelled context is honoured before anything else
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = approving.Authorize(cancelled, AuthorizeRequest{OrderID: 1, Amount: amount})
+	assert.ErrorIs(t, err, context.Canceled)
+}
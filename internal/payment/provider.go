@@ -0,0 +1,85 @@
+// Package payment defines the interface to payment gateways and an in-process fake gateway.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Provider authorizes, captures, refunds and voids card payments with a payment gateway.
+// Authorize reserves funds and returns the gateway's reference for the payment, which the
+// other methods take. Implementations must be safe for concurrent use.
+type Provider interface {
+	// Name identifies the provider in stored payments
+	Name() string
+	// Authorize reserves amount on the payment method
+	Authorize(ctx context.Context, req AuthorizeRequest) (*Result, error)
+	// Capture collects amount of an authorized payment
+	Capture(ctx context.Context, reference string, amount decimal.Decimal) (*Result, error)
+	// Refund returns amount of a captured payment
+	Refund(ctx context.Context, reference string, amount decimal.Decimal) (*Result, error)
+	// Void releases an authorized payment that was not captured
+	Void(ctx context.Context, reference string) (*Result, error)
+}
+
+// AuthorizeRequest describes a payment to authorize. IdempotencyKey lets gateways that
+// support it recognise retries of the same authorization.
+type AuthorizeRequest struct {
+	OrderID        int
+	Amount         decimal.Decimal
+	Currency       string
+	PaymentMethod  string
+	IdempotencyKey string
+}
+
+// Result is a gateway's answer to a successful call
+type Result struct {
+	Reference string
+	Amount    decimal.Decimal
+}
+
+var (
+	// ErrDeclined is matched by every DeclineError
+	ErrDeclined = errors.New("payment declined")
+	// ErrTimeout means the gateway did not answer in time; the outcome of the call is unknown
+	ErrTimeout = errors.New("payment provider timed out")
+	// ErrInvalidTransaction means the reference is unknown or the payment is in the wrong state
+	// for the call, e.g. capturing a voided authorization
+	ErrInvalidTransaction = errors.New("payment transaction not found or in the wrong state")
+)
+
+// DeclineError is returned when the gateway refuses a payment. Code is the gateway's decline
+// code, such as "card_declined" or "insufficient_funds".
+type DeclineError struct {
+	Code    string
+	Message string
+}
+
+// Error returns the decline code and message
+func (e *DeclineError) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", ErrDeclined, e.Message, e.Code)
+}
+
+// Unwrap returns ErrDeclined so errors.Is matches it
+func (e *DeclineError) Unwrap() error {
+	return ErrDeclined
+}
+
+// Provider names accepted by NewProvider
+const (
+	ProviderFake = "fake"
+)
+
+// NewProvider creates the provider configured by name. The fake provider behaves as fake
+// configures it.
+func NewProvider(name string, fake FakeConfig) (Provider, error) {
+	switch name {
+	case ProviderFake, "":
+		return NewFakeProvider(fake)
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
@@ -0,0 +1,142 @@
+// Package pricing computes the service fees and tax charged on top of an order's ticket prices.
+package pricing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Config configures service fees and tax rates. Amounts and rates are decimal strings; rates
+// are percentages. TaxRates maps each jurisdiction to its rate and Venues maps a venue to the
+// jurisdiction it is in. Venues and jurisdictions are matched case-insensitively. Venues that
+// are not listed are in DefaultJurisdiction, or untaxed when it is empty.
+type Config struct {
+	// FeePerTicket is charged for every ticket of an order
+	FeePerTicket string `mapstructure:"fee_per_ticket"`
+	// FeePerOrder is charged once per order
+	FeePerOrder         string            `mapstructure:"fee_per_order"`
+	TaxRates            map[string]string `mapstructure:"tax_rates"`
+	Venues              map[string]string
+	DefaultJurisdiction string `mapstructure:"default_jurisdiction"`
+}
+
+// Rules prices orders. The zero Rules charge no fees or tax.
+type Rules struct {
+	feePerTicket        decimal.Decimal
+	feePerOrder         decimal.Decimal
+	taxRates            map[string]decimal.Decimal
+	venues              map[string]string
+	defaultJurisdiction string
+}
+
+// Breakdown is the price of an order. Total is Subtotal less Discount plus ServiceFee and Tax.
+// Tax is TaxRate percent of everything else, charged in Jurisdiction; both are empty or zero
+// for untaxed venues.
+type Breakdown struct {
+	Subtotal     decimal.Decimal
+	Discount     decimal.Decimal
+	ServiceFee   decimal.Decimal
+	TaxRate      decimal.Decimal
+	Tax          decimal.Decimal
+	Total        decimal.Decimal
+	Jurisdiction string
+}
+
+// maxTaxRateDecimals is how many decimal places a tax rate may have, such as 8.875%
+const maxTaxRateDecimals = 3
+
+// New creates the rules cfg configures. Fees must be non-negative whole cents, rates between
+// 0 and 100 with at most three decimal places, and every jurisdiction a venue names must have
+// a rate.
+func New(cfg Config) (*Rules, error) {
+	r := &Rules{
+		taxRates:            make(map[string]decimal.Decimal, len(cfg.TaxRates)),
+		venues:              make(map[string]string, len(cfg.Venues)),
+		defaultJurisdiction: normalize(cfg.DefaultJurisdiction),
+	}
+
+	var err error
+	if r.feePerTicket, err = parseFee("fee_per_ticket", cfg.FeePerTicket); err != nil {
+		return nil, err
+	}
+	if r.feePerOrder, err = parseFee("fee_per_order", cfg.FeePerOrder); err != nil {
+		return nil, err
+	}
+
+	for jurisdiction, value := range cfg.TaxRates {
+		rate, err := decimal.NewFromString(value)
+		if err != nil || rate.IsNegative() || rate.GreaterThan(decimal.NewFromInt(100)) ||
+			!rate.Equal(rate.Round(maxTaxRateDecimals)) {
+			return nil, fmt.Errorf("invalid tax rate %q for %q: want a percentage between 0 and 100 with at most %d decimal places",
+				value, jurisdiction, maxTaxRateDecimals)
+		}
+		r.taxRates[normalize(jurisdiction)] = rate
+	}
+
+	for venue, jurisdiction := range cfg.Venues {
+		jurisdiction = normalize(jurisdiction)
+		if _, ok := r.taxRates[jurisdiction]; !ok {
+			return nil, fmt.Errorf("venue %q is in jurisdiction %q, which has no tax rate", venue, jurisdiction)
+		}
+		r.venues[normalize(venue)] = jurisdiction
+	}
+	if _, ok := r.taxRates[r.defaultJurisdiction]; r.defaultJurisdiction != "" && !ok {
+		return nil, fmt.Errorf("default jurisdiction %q has no tax rate", r.defaultJurisdiction)
+	}
+
+	return r, nil
+}
+
+// parseFee parses a fee amount, which an empty value leaves at zero
+func parseFee(name, value string) (decimal.Decimal, error) {
+	if strings.TrimSpace(value) == "" {
+		return decimal.Zero, nil
+	}
+	fee, err := decimal.NewFromString(strings.TrimSpace(value))
+	if err != nil || fee.IsNegative() || !fee.Equal(fee.Round(2)) {
+		return decimal.Zero, fmt.Errorf("invalid %s %q: want a non-negative amount in whole cents", name, value)
+	}
+	return fee, nil
+}
+
+// normalize folds a venue or jurisdiction name for case-insensitive matching
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Jurisdiction returns the tax jurisdiction of venue and its rate in percent, or "" and zero
+// if the venue is untaxed
+func (r *Rules) Jurisdiction(venue string) (string, decimal.Decimal) {
+	jurisdiction, ok := r.venues[normalize(venue)]
+	if !ok {
+		jurisdiction = r.defaultJurisdiction
+	}
+	if jurisdiction == "" {
+		return "", decimal.Zero
+	}
+	return jurisdiction, r.taxRates[jurisdiction]
+}
+
+// Price prices an order of tickets at venue whose ticket prices add up to subtotal, less
+// discount. Fees are added after the discount, and tax is charged on the discounted subtotal
+// plus fees. Tax is computed once for the whole order and rounded half up to cents, so it never
+// drifts from the rate by more than half a cent.
+func (r *Rules) Price(venue string, tickets int, subtotal, discount decimal.Decimal) Breakdown {
+	b := Breakdown{
+		Subtotal:   subtotal,
+		Discount:   discount,
+		ServiceFee: r.feePerTicket.Mul(decimal.NewFromInt(int64(tickets))).Add(r.feePerOrder),
+		TaxRate:    decimal.Zero,
+		Tax:        decimal.Zero,
+	}
+
+	taxable := subtotal.Sub(discount).Add(b.ServiceFee)
+	b.Jurisdiction, b.TaxRate = r.Jurisdiction(venue)
+	if b.TaxRate.IsPositive() {
+		b.Tax = taxable.Mul(b.TaxRate).Div(decimal.NewFromInt(100)).Round(2)
+	}
+	b.Total = taxable.Add(b.Tax)
+	return b
+}
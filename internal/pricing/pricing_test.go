@@ -0,0 +1,90 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"fee not a number", Config{FeePerTicket: "one"}},
+		{"negative fee", Config{FeePerOrder: "-1.00"}},
+		{"fee in fractions of a cent", Config{FeePerTicket: "0.505"}},
+		{"rate over 100", Config{TaxRates: map[string]string{"ny": "100.5"}}},
+		{"negative rate", Config{TaxRates: map[string]string{"ny": "-1"}}},
+		{"rate too precise", Config{TaxRates: map[string]string{"ny": "8.8755"}}},
+		{"venue in unknown jurisdiction", Config{Venues: map[string]string{"Hall": "ny"}}},
+		{"unknown default jurisdiction", Config{DefaultJurisdiction: "ny"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRules_Price(t *testing.T) {
+	rules, err := New(Config{
+		FeePerTicket:        "1.50",
+		FeePerOrder:         "2.00",
+		TaxRates:            map[string]string{"NY": "8.875", "uk": "20", "exempt": "0"},
+		Venues:              map[string]string{"madison square garden": "ny", "Charity Hall": "exempt"},
+		DefaultJurisdiction: "uk",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		venue        string
+		tickets      int
+		subtotal     string
+		discount     string
+		fee          string
+		jurisdiction string
+		tax          string
+		total        string
+	}{
+		// 100.00 + 3 * 1.50 + 2.00 = 106.50; 8.875% of it is 9.451875
+		{"venues match case-insensitively", "Madison Square Garden", 3, "100.00", "0", "6.50", "ny", "9.45", "115.95"},
+		// 33.33 - 5.00 + 1.50 + 2.00 = 31.83; 20% of it is 6.366
+		{"tax on the discounted price", "Wembley", 1, "33.33", "5.00", "3.50", "uk", "6.37", "38.20"},
+		{"zero rate", "Charity Hall", 2, "40.00", "0", "5.00", "exempt", "0", "45.00"},
+		// A fully discounted order still pays its fees and their tax
+		{"fees on a free order", "Wembley", 1, "10.00", "10.00", "3.50", "uk", "0.70", "4.20"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := rules.Price(tt.venue, tt.tickets, decimal.RequireFromString(tt.subtotal), decimal.RequireFromString(tt.discount))
+			assert.True(t, decimal.RequireFromString(tt.fee).Equal(b.ServiceFee), "fee %s", b.ServiceFee)
+			assert.Equal(t, tt.jurisdiction, b.Jurisdiction)
+			assert.True(t, decimal.RequireFromString(tt.tax).Equal(b.Tax), "tax %s", b.Tax)
+			assert.True(t, decimal.RequireFromString(tt.total).Equal(b.Total), "total %s", b.Total)
+			assert.True(t, b.Subtotal.Sub(b.Discount).Add(b.ServiceFee).Add(b.Tax).Equal(b.Total))
+		})
+	}
+}
+
+func TestRules_Price_RoundsHalfUp(t *testing.T) {
+	rules, err := New(Config{TaxRates: map[string]string{"half": "50"}, DefaultJurisdiction: "half"})
+	require.NoError(t, err)
+
+	// Half of 0.05 is 0.025, which rounds up to 0.03
+	b := rules.Price("Anywhere", 1, decimal.RequireFromString("0.05"), decimal.Zero)
+	assert.True(t, decimal.RequireFromString("0.03").Equal(b.Tax), "tax %s", b.Tax)
+}
+
+func TestRules_Zero(t *testing.T) {
+	var rules Rules
+	b := rules.Price("Hall", 2, decimal.RequireFromString("80.00"), decimal.RequireFromString("8.00"))
+	assert.True(t, b.ServiceFee.IsZero())
+	assert.True(t, b.Tax.IsZero())
+	assert.Empty(t, b.Jurisdiction)
+	assert.True(t, decimal.RequireFromString("72.00").Equal(b.Total))
+}
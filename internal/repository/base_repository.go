@@ -7,12 +7,13 @@ import (
 
 // BaseRepository provides common database operations
 type BaseRepository struct {
-	db *sqlx.DB
+	db         *sqlx.DB
+	pageTokens *PageTokenCodec
 }
 
 // NewBaseRepository creates a new base repository
 func NewBaseRepository(db *sqlx.DB) *BaseRepository {
-	return &BaseRepository{db: db}
+	return &BaseRepository{db: db, pageTokens: NewPageTokenCodec(nil)}
 }
 
 // GetDB returns the database connection
@@ -20,6 +21,17 @@ func (r *BaseRepository) GetDB() *sqlx.DB {
 	return r.db
 }
 
+// SetPageTokenSecret sets the key used to sign page tokens, so tokens stay valid across
+// restarts and server instances
+func (r *BaseRepository) SetPageTokenSecret(secret []byte) {
+	r.pageTokens = NewPageTokenCodec(secret)
+}
+
+// PageTokens returns the codec for list page tokens
+func (r *BaseRepository) PageTokens() *PageTokenCodec {
+	return r.pageTokens
+}
+
 // WithTransaction executes a function within a database transaction
 func (r *BaseRepository) WithTransaction(fn func(*sqlx.Tx) error) error {
 	tx, err := r.db.Beginx()
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"database/sql"
+	"tickets/internal/models/db"
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ConcertRepository handles concert-related database operations
+type ConcertRepository struct {
+	*BaseRepository
+}
+
+// NewConcertRepository creates a new concert repository
+func NewConcertRepository(base *BaseRepository) *ConcertRepository {
+	return &ConcertRepository{BaseRepository: base}
+}
+
+// CreateConcert inserts a concert, filling in its ID and creation time
+func (r *ConcertRepository) CreateConcert(tx *sqlx.Tx, concert *models.Concert) error {
+	query := `
+	INSERT INTO concerts (name, location, description)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at`
+
+	return tx.QueryRow(query, concert.Name, concert.Location, concert.Description).Scan(&concert.ID, &concert.CreatedAt)
+}
+
+// GetConcertByID retrieves a concert by ID
+func (r *ConcertRepository) GetConcertByID(id int) (*models.Concert, error) {
+	query := `SELECT id, name, location, COALESCE(description, '') AS description, created_at FROM concerts WHERE id = $1`
+
+	var dbConcert db.Concert
+	err := r.db.Get(&dbConcert, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbConcert.ToConcert(), nil
+}
+
+// GetConcertForUpdate locks a concert within tx, returning nil if it does not exist
+func (r *ConcertRepository) GetConcertForUpdate(tx *sqlx.Tx, id int) (*models.Concert, error) {
+	query := `SELECT id, name, location, COALESCE(description, '') AS description, created_at FROM concerts WHERE id = $1 FOR UPDATE`
+
+	var dbConcert db.Concert
+	err := tx.Get(&dbConcert, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbConcert.ToConcert(), nil
+}
+
+// UpdateConcert updates a concert's name, location and description
+func (r *ConcertRepository) UpdateConcert(tx *sqlx.Tx, concert *models.Concert) error {
+	query := `UPDATE concerts SET name = $1, location = $2, description = $3 WHERE id = $4`
+
+	_, err := tx.Exec(query, concert.Name, concert.Location, concert.Description, concert.ID)
+	return err
+}
+
+// DeleteConcert deletes a concert
+func (r *ConcertRepository) DeleteConcert(tx *sqlx.Tx, id int) error {
+	_, err := tx.Exec(`DELETE FROM concerts WHERE id = $1`, id)
+	return err
+}
+
+// CountSessionsByConcertID returns the number of sessions scheduled for a concert
+func (r *ConcertRepository) CountSessionsByConcertID(tx *sqlx.Tx, concertID int) (int, error) {
+	var count int
+	err := tx.Get(&count, `SELECT COUNT(*) FROM concert_sessions WHERE concert_id = $1`, concertID)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
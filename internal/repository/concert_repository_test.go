@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"testing"
+
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConcertRepository(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewConcertRepository(baseRepo)
+	assert.NotNil(t, repo)
+	assert.Equal(t, baseRepo, repo.BaseRepository)
+}
+
+func TestConcertRepository_CRUD(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewConcertRepository(baseRepo)
+
+	concert := &models.Concert{Name: "Repo Concert", Location: "Repo City", Description: "Repo Description"}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		return repo.CreateConcert(tx, concert)
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, concert.ID)
+	assert.NotZero(t, concert.CreatedAt)
+
+	fetched, err := repo.GetConcertByID(concert.ID)
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	assert.Equal(t, *concert, *fetched)
+
+	concert.Name = "Renamed Concert"
+	concert.Description = ""
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		return repo.UpdateConcert(tx, concert)
+	})
+	require.NoError(t, err)
+
+	fetched, err = repo.GetConcertByID(concert.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed Concert", fetched.Name)
+	assert.Empty(t, fetched.Description)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		count, err := repo.CountSessionsByConcertID(tx, concert.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+		return repo.DeleteConcert(tx, concert.ID)
+	})
+	require.NoError(t, err)
+
+	fetched, err = repo.GetConcertByID(concert.ID)
+	require.NoError(t, err)
+	assert.Nil(t, fetched)
+}
+
+func TestConcertRepository_GetConcertByID_NullDescription(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewConcertRepository(baseRepo)
+
+	var concertID int
+	err := baseRepo.db.QueryRow(`
+		INSERT INTO concerts (name, location) VALUES ($1, $2) RETURNING id`,
+		"No Description", "Nowhere").Scan(&concertID)
+	require.NoError(t, err)
+
+	concert, err := repo.GetConcertByID(concertID)
+	require.NoError(t, err)
+	require.NotNil(t, concert)
+	assert.Empty(t, concert.Description)
+}
@@ -1,9 +1,17 @@
 package repository
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
 	"tickets/internal/models/db"
 	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
 )
 
 // ConcertSessionRepository handles concert session-related database operations
@@ -31,3 +39,231 @@ func (r *ConcertSessionRepository) GetConcertSessionByID(id int) (*models.Concer
 
 	return dbSession.ToConcertSession(), nil
 }
+
+// GetConcertSessionForUpdate locks a concert session within tx, returning nil if it does not exist
+func (r *ConcertSessionRepository) GetConcertSessionForUpdate(tx *sqlx.Tx, id int) (*models.ConcertSession, error) {
+	query := `SELECT id, concert_id, start_time, end_time, venue, number_of_seats, price FROM concert_sessions WHERE id = $1 FOR UPDATE`
+
+	var dbSession db.ConcertSession
+	err := tx.Get(&dbSession, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbSession.ToConcertSession(), nil
+}
+
+// CreateConcertSession inserts a concert session, filling in its ID. Tickets are created
+// separately with TicketRepository.CreateTickets.
+func (r *ConcertSessionRepository) CreateConcertSession(tx *sqlx.Tx, session *models.ConcertSession) error {
+	query := `
+	INSERT INTO concert_sessions (concert_id, start_time, end_time, venue, number_of_seats, price)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id`
+
+	return tx.QueryRow(query, session.ConcertID, session.StartTime, session.EndTime, session.Venue,
+		session.NumberOfSeats, session.Price).Scan(&session.ID)
+}
+
+// UpdateConcertSession updates a session's schedule, venue and price
+func (r *ConcertSessionRepository) UpdateConcertSession(tx *sqlx.Tx, session *models.ConcertSession) error {
+	query := `
+	UPDATE concert_sessions
+	SET start_time = $1, end_time = $2, venue = $3, price = $4
+	WHERE id = $5`
+
+	_, err := tx.Exec(query, session.StartTime, session.EndTime, session.Venue, session.Price, session.ID)
+	return err
+}
+
+// UpdateNumberOfSeats sets a session's capacity. Callers keep the session's tickets in step.
+func (r *ConcertSessionRepository) UpdateNumberOfSeats(tx *sqlx.Tx, sessionID int, numberOfSeats int) error {
+	_, err := tx.Exec(`UPDATE concert_sessions SET number_of_seats = $1 WHERE id = $2`, numberOfSeats, sessionID)
+	return err
+}
+
+// DeleteConcertSession deletes a concert session together with its tickets
+func (r *ConcertSessionRepository) DeleteConcertSession(tx *sqlx.Tx, id int) error {
+	_, err := tx.Exec(`DELETE FROM concert_sessions WHERE id = $1`, id)
+	return err
+}
+
+// HasOrders reports whether any order, in any status, includes a ticket of the session
+func (r *ConcertSessionRepository) HasOrders(tx *sqlx.Tx, sessionID int) (bool, error) {
+	query := `
+	SELECT EXISTS (
+		SELECT 1 FROM order_items oi
+		JOIN tickets t ON t.id = oi.ticket_id
+		WHERE t.session_id = $1
+	)`
+
+	var exists bool
+	err := tx.Get(&exists, query, sessionID)
+	return exists, err
+}
+
+// concertSessionDetailsQuery selects sessions joined with their concert and the number of
+// tickets still available
+const concertSessionDetailsQuery = `
+	SELECT cs.id, cs.concert_id, cs.start_time, cs.end_time, cs.venue, cs.number_of_seats, cs.price,
+		(SELECT COUNT(*) FROM tickets t WHERE t.session_id = cs.id AND t.status = 'available') AS remaining_seats,
+		c.name AS concert_name, c.location AS concert_location,
+		c.description AS concert_description, c.created_at AS concert_created_at
+	FROM concert_sessions cs
+	JOIN concerts c ON c.id = cs.concert_id`
+
+// GetConcertSessionDetailsByID retrieves a concert session with its concert and remaining seats
+func (r *ConcertSessionRepository) GetConcertSessionDetailsByID(id int) (*models.ConcertSession, error) {
+	query := concertSessionDetailsQuery + ` WHERE cs.id = $1`
+
+	var dbSession db.ConcertSessionDetails
+	err := r.db.Get(&dbSession, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbSession.ToConcertSession(), nil
+}
+
+// Concert session sort fields accepted by ConcertSessionFilter
+const (
+	SortSessionsByStartTime = "start_time"
+	SortSessionsByPrice     = "price"
+)
+
+// ConcertSessionFilter narrows and orders ListConcertSessions. Zero values leave a
+// criterion unset.
+type ConcertSessionFilter struct {
+	StartTimeFrom int64
+	StartTimeTo   int64
+	// Venue and Location match case-insensitively
+	Venue         string
+	Location      string
+	MinPrice      decimal.Decimal
+	MaxPrice      decimal.Decimal
+	OnlyAvailable bool
+	SortBy        string
+	Descending    bool
+}
+
+// where builds the WHERE clause and its arguments for the filter
+func (f ConcertSessionFilter) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if f.StartTimeFrom > 0 {
+		add("cs.start_time >= $%d", f.StartTimeFrom)
+	}
+	if f.StartTimeTo > 0 {
+		add("cs.start_time <= $%d", f.StartTimeTo)
+	}
+	if f.Venue != "" {
+		add("LOWER(cs.venue) = LOWER($%d)", f.Venue)
+	}
+	if f.Location != "" {
+		add("LOWER(c.location) = LOWER($%d)", f.Location)
+	}
+	if f.MinPrice.IsPositive() {
+		add("cs.price >= $%d", f.MinPrice)
+	}
+	if f.MaxPrice.IsPositive() {
+		add("cs.price <= $%d", f.MaxPrice)
+	}
+	if f.OnlyAvailable {
+		conditions = append(conditions,
+			"EXISTS (SELECT 1 FROM tickets t WHERE t.session_id = cs.id AND t.status = 'available')")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// sortColumn returns the column the filter sorts by
+func (f ConcertSessionFilter) sortColumn() string {
+	if f.SortBy == SortSessionsByPrice {
+		return "cs.price"
+	}
+	return "cs.start_time"
+}
+
+// orderBy builds the ORDER BY clause for the filter, breaking ties by id
+func (f ConcertSessionFilter) orderBy() string {
+	direction := "ASC"
+	if f.Descending {
+		direction = "DESC"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, cs.id %s", f.sortColumn(), direction, direction)
+}
+
+// SortKey returns the value of the filter's sort column for session, for use in a Cursor
+func (f ConcertSessionFilter) SortKey(session *models.ConcertSession) string {
+	if f.SortBy == SortSessionsByPrice {
+		return session.Price.String()
+	}
+	return strconv.FormatInt(session.StartTime, 10)
+}
+
+// Fingerprint identifies the filter and ordering, so a cursor issued for one listing is not
+// applied to another
+func (f ConcertSessionFilter) Fingerprint() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%d|%s|%s|%s|%s|%t|%s|%t",
+		f.StartTimeFrom, f.StartTimeTo, strings.ToLower(f.Venue), strings.ToLower(f.Location),
+		f.MinPrice.String(), f.MaxPrice.String(), f.OnlyAvailable, f.sortColumn(), f.Descending)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ListConcertSessions retrieves a page of concert sessions matching filter with their
+// concerts and remaining seats
+func (r *ConcertSessionRepository) ListConcertSessions(filter ConcertSessionFilter, page Page) ([]models.ConcertSession, error) {
+	where, args := filter.where()
+	if page.After != nil {
+		if where == "" {
+			where = " WHERE "
+		} else {
+			where += " AND "
+		}
+		where += keysetCondition(filter.sortColumn(), "cs.id", filter.Descending, len(args)+1)
+		args = append(args, page.After.Key, page.After.ID)
+	}
+	limitOffset, pageArgs := page.limitOffset(len(args) + 1)
+	query := concertSessionDetailsQuery + where + filter.orderBy() + limitOffset
+
+	var dbSessions []db.ConcertSessionDetails
+	err := r.db.Select(&dbSessions, query, append(args, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.ConcertSession, len(dbSessions))
+	for i := range dbSessions {
+		sessions[i] = *dbSessions[i].ToConcertSession()
+	}
+
+	return sessions, nil
+}
+
+// CountConcertSessions returns the number of concert sessions matching filter
+func (r *ConcertSessionRepository) CountConcertSessions(filter ConcertSessionFilter) (int, error) {
+	where, args := filter.where()
+	query := `SELECT COUNT(*) FROM concert_sessions cs JOIN concerts c ON c.id = cs.concert_id` + where
+
+	var count int
+	err := r.db.Get(&count, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -1,9 +1,14 @@
 package repository
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -190,3 +195,253 @@ func TestConcertSessionRepository_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestConcertSessionRepository_GetConcertSessionDetailsByID(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewConcertSessionRepository(baseRepo)
+	sessionID := createTestConcertSession(t, baseRepo)
+	tickets := createTestTicketsForSession(t, baseRepo, sessionID, 4)
+
+	// Held and sold tickets do not count as remaining seats
+	_, err := baseRepo.db.Exec(`UPDATE tickets SET status = 'sold' WHERE id = $1`, tickets[0].ID)
+	require.NoError(t, err)
+	_, err = baseRepo.db.Exec(`UPDATE tickets SET status = 'pending' WHERE id = $1`, tickets[1].ID)
+	require.NoError(t, err)
+
+	session, err := repo.GetConcertSessionDetailsByID(sessionID)
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, sessionID, session.ID)
+	assert.Equal(t, 100, session.NumberOfSeats)
+	assert.Equal(t, 2, session.RemainingSeats)
+	assert.True(t, session.Price.Equal(decimal.RequireFromString("50.00")))
+
+	require.NotNil(t, session.Concert)
+	assert.Equal(t, session.ConcertID, session.Concert.ID)
+	assert.Equal(t, "Test Concert", session.Concert.Name)
+	assert.Equal(t, "Test Venue", session.Concert.Location)
+	assert.Equal(t, "Test Description", session.Concert.Description)
+	assert.NotZero(t, session.Concert.CreatedAt)
+
+	session, err = repo.GetConcertSessionDetailsByID(999999)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestConcertSessionRepository_ListConcertSessions(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewConcertSessionRepository(baseRepo)
+	sessionIDs := []int{
+		createTestConcertSession(t, baseRepo),
+		createTestConcertSession(t, baseRepo),
+		createTestConcertSession(t, baseRepo),
+	}
+	createTestTicketsForSession(t, baseRepo, sessionIDs[0], 3)
+
+	count, err := repo.CountConcertSessions(ConcertSessionFilter{})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, len(sessionIDs))
+
+	sessions, err := repo.ListConcertSessions(ConcertSessionFilter{}, Page{Limit: count})
+	require.NoError(t, err)
+	require.Len(t, sessions, count)
+
+	remaining := make(map[int]int, len(sessions))
+	for i, session := range sessions {
+		require.NotNil(t, session.Concert)
+		remaining[session.ID] = session.RemainingSeats
+		if i > 0 {
+			assert.LessOrEqual(t, sessions[i-1].StartTime, session.StartTime)
+		}
+	}
+	assert.Equal(t, 3, remaining[sessionIDs[0]])
+	assert.Equal(t, 0, remaining[sessionIDs[1]])
+
+	// Pages do not overlap
+	first, err := repo.ListConcertSessions(ConcertSessionFilter{}, Page{Limit: 1})
+	require.NoError(t, err)
+	second, err := repo.ListConcertSessions(ConcertSessionFilter{}, Page{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	require.Len(t, second, 1)
+	assert.NotEqual(t, first[0].ID, second[0].ID)
+
+	// A cursor after the first page yields the same row as offset 1
+	filter := ConcertSessionFilter{}
+	after := &Cursor{Key: filter.SortKey(&first[0]), ID: first[0].ID}
+	keyset, err := repo.ListConcertSessions(filter, Page{Limit: 1, After: after})
+	require.NoError(t, err)
+	require.Len(t, keyset, 1)
+	assert.Equal(t, second[0].ID, keyset[0].ID)
+}
+
+func TestConcertSessionRepository_ListConcertSessions_Filters(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewConcertSessionRepository(baseRepo)
+
+	// Scope every filter to a venue no other test uses
+	venue := fmt.Sprintf("Filter Hall %d", time.Now().UnixNano())
+	insert := func(location string, startTime int64, price string, tickets int) int {
+		var concertID, sessionID int
+		err := baseRepo.db.QueryRow(`
+			INSERT INTO concerts (name, location, description)
+			VALUES ($1, $2, $3)
+			RETURNING id`,
+			"Filter Concert", location, "").Scan(&concertID)
+		require.NoError(t, err)
+
+		err = baseRepo.db.QueryRow(`
+			INSERT INTO concert_sessions (concert_id, start_time, end_time, venue, number_of_seats, price)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id`,
+			concertID, startTime, startTime+3600000, venue, 10, price).Scan(&sessionID)
+		require.NoError(t, err)
+
+		createTestTicketsForSession(t, baseRepo, sessionID, tickets)
+		return sessionID
+	}
+
+	early := insert("Berlin", 1000000, "80.00", 0)
+	middle := insert("Paris", 2000000, "20.00", 2)
+	late := insert("berlin", 3000000, "50.00", 1)
+
+	ids := func(filter ConcertSessionFilter) []int {
+		filter.Venue = venue
+		sessions, err := repo.ListConcertSessions(filter, Page{Limit: 100})
+		require.NoError(t, err)
+
+		count, err := repo.CountConcertSessions(filter)
+		require.NoError(t, err)
+		assert.Equal(t, len(sessions), count)
+
+		result := make([]int, len(sessions))
+		for i, session := range sessions {
+			result[i] = session.ID
+		}
+		return result
+	}
+
+	assert.Equal(t, []int{early, middle, late}, ids(ConcertSessionFilter{}))
+	assert.Equal(t, []int{middle, late}, ids(ConcertSessionFilter{StartTimeFrom: 1500000}))
+	assert.Equal(t, []int{early, middle}, ids(ConcertSessionFilter{StartTimeTo: 2000000}))
+	assert.Equal(t, []int{early, late}, ids(ConcertSessionFilter{Location: "BERLIN"}))
+	assert.Equal(t, []int{late}, ids(ConcertSessionFilter{MinPrice: decimal.NewFromInt(30), MaxPrice: decimal.NewFromInt(60)}))
+	assert.Equal(t, []int{middle, late}, ids(ConcertSessionFilter{OnlyAvailable: true}))
+	assert.Equal(t, []int{middle, late, early}, ids(ConcertSessionFilter{SortBy: SortSessionsByPrice}))
+	assert.Equal(t, []int{early, late, middle}, ids(ConcertSessionFilter{SortBy: SortSessionsByPrice, Descending: true}))
+	assert.Equal(t, []int{late, middle, early}, ids(ConcertSessionFilter{Descending: true}))
+
+	// Venue matches case-insensitively
+	sessions, err := repo.ListConcertSessions(ConcertSessionFilter{Venue: strings.ToUpper(venue)}, Page{Limit: 100})
+	require.NoError(t, err)
+	assert.Len(t, sessions, 3)
+}
+
+func TestConcertSessionRepository_CreateUpdateDelete(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewConcertSessionRepository(baseRepo)
+	ticketRepo := NewTicketRepository(baseRepo)
+	concertRepo := NewConcertRepository(baseRepo)
+
+	concert := &models.Concert{Name: "Session Concert", Location: "Session City"}
+	session := &models.ConcertSession{
+		StartTime:     1767225600000,
+		EndTime:       1767236400000,
+		Venue:         "Session Hall",
+		NumberOfSeats: 4,
+		Price:         decimal.RequireFromString("25.50"),
+	}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := concertRepo.CreateConcert(tx, concert); err != nil {
+			return err
+		}
+		session.ConcertID = concert.ID
+		if err := repo.CreateConcertSession(tx, session); err != nil {
+			return err
+		}
+		return ticketRepo.CreateTickets(tx, session.ID, session.NumberOfSeats)
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, session.ID)
+
+	details, err := repo.GetConcertSessionDetailsByID(session.ID)
+	require.NoError(t, err)
+	require.NotNil(t, details)
+	assert.Equal(t, 4, details.NumberOfSeats)
+	assert.Equal(t, 4, details.RemainingSeats)
+	assert.Equal(t, "Session Concert", details.Concert.Name)
+
+	session.Venue = "Moved Hall"
+	session.Price = decimal.RequireFromString("30.00")
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		locked, err := repo.GetConcertSessionForUpdate(tx, session.ID)
+		require.NoError(t, err)
+		require.NotNil(t, locked)
+		return repo.UpdateConcertSession(tx, session)
+	})
+	require.NoError(t, err)
+
+	updated, err := repo.GetConcertSessionByID(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Moved Hall", updated.Venue)
+	assert.True(t, decimal.RequireFromString("30.00").Equal(updated.Price))
+	assert.Equal(t, 4, updated.NumberOfSeats)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		hasOrders, err := repo.HasOrders(tx, session.ID)
+		require.NoError(t, err)
+		assert.False(t, hasOrders)
+
+		count, err := concertRepo.CountSessionsByConcertID(tx, concert.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		return repo.DeleteConcertSession(tx, session.ID)
+	})
+	require.NoError(t, err)
+
+	deleted, err := repo.GetConcertSessionByID(session.ID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+
+	available, err := ticketRepo.CountAvailableTicketsBySessionID(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, available)
+}
+
+func TestConcertSessionRepository_HasOrders(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewConcertSessionRepository(baseRepo)
+	orderRepo := NewOrderRepository(baseRepo)
+	sessionID := createTestConcertSession(t, baseRepo)
+	tickets := createTestTicketsForSession(t, baseRepo, sessionID, 1)
+
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		order := &models.Order{Status: "expired", TotalPrice: decimal.NewFromInt(50)}
+		if err := orderRepo.CreateOrder(tx, order); err != nil {
+			return err
+		}
+		return orderRepo.CreateOrderItems(tx, []models.OrderItem{
+			{OrderID: order.ID, TicketID: tickets[0].ID, Price: decimal.NewFromInt(50)},
+		})
+	})
+	require.NoError(t, err)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		hasOrders, err := repo.HasOrders(tx, sessionID)
+		require.NoError(t, err)
+		assert.True(t, hasOrders)
+		return nil
+	})
+	require.NoError(t, err)
+}
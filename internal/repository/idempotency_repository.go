@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"database/sql"
+	"tickets/internal/models/db"
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// IdempotencyRepository handles idempotency key-related database operations
+type IdempotencyRepository struct {
+	*BaseRepository
+}
+
+// NewIdempotencyRepository creates a new idempotency repository
+func NewIdempotencyRepository(base *BaseRepository) *IdempotencyRepository {
+	return &IdempotencyRepository{BaseRepository: base}
+}
+
+// ClaimIdempotencyKey reserves a key for the request running in tx. A key created before
+// cutoff is past its retention window and is reclaimed. It reports false if the key is still
+// held by an earlier request; a concurrent claim blocks until the other transaction finishes.
+func (r *IdempotencyRepository) ClaimIdempotencyKey(tx *sqlx.Tx, key *models.IdempotencyKey, cutoff int64) (bool, error) {
+	query := `
+	INSERT INTO idempotency_keys (user_id, idempotency_key, request_hash, created_at)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (user_id, idempotency_key) DO UPDATE
+	SET request_hash = EXCLUDED.request_hash, response = NULL, created_at = EXCLUDED.created_at
+	WHERE idempotency_keys.created_at < $5
+	RETURNING created_at`
+
+	var createdAt int64
+	err := tx.QueryRow(query, key.UserID, key.Key, key.RequestHash, key.CreatedAt, cutoff).Scan(&createdAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetIdempotencyKey retrieves a key within tx, returning nil if it does not exist
+func (r *IdempotencyRepository) GetIdempotencyKey(tx *sqlx.Tx, userID int, key string) (*models.IdempotencyKey, error) {
+	query := `
+	SELECT user_id, idempotency_key, request_hash, response, created_at
+	FROM idempotency_keys
+	WHERE user_id = $1 AND idempotency_key = $2`
+
+	var dbKey db.IdempotencyKey
+	err := tx.Get(&dbKey, query, userID, key)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return dbKey.ToIdempotencyKey(), nil
+}
+
+// SaveIdempotencyResponse stores the response for a key claimed in tx
+func (r *IdempotencyRepository) SaveIdempotencyResponse(tx *sqlx.Tx, userID int, key string, response []byte) error {
+	query := `UPDATE idempotency_keys SET response = $1 WHERE user_id = $2 AND idempotency_key = $3`
+
+	_, err := tx.Exec(query, string(response), userID, key)
+	return err
+}
+
+// DeleteIdempotencyKeysBefore removes keys created before cutoff and returns how many were deleted
+func (r *IdempotencyRepository) DeleteIdempotencyKeysBefore(cutoff int64) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE created_at < $1`
+
+	result, err := r.db.Exec(query, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
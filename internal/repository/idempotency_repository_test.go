@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIdempotencyRepository(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewIdempotencyRepository(baseRepo)
+	assert.NotNil(t, repo)
+	assert.Equal(t, baseRepo, repo.BaseRepository)
+}
+
+func TestIdempotencyRepository_ClaimAndReplay(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewIdempotencyRepository(baseRepo)
+	now := time.Now().UnixMilli()
+	cutoff := now - time.Hour.Milliseconds()
+
+	key := &models.IdempotencyKey{UserID: 1, Key: "retry-1", RequestHash: "hash-a", CreatedAt: now}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		claimed, err := repo.ClaimIdempotencyKey(tx, key, cutoff)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+
+		return repo.SaveIdempotencyResponse(tx, key.UserID, key.Key, []byte(`{"order_id":42}`))
+	})
+	require.NoError(t, err)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		// A fresh key cannot be claimed twice
+		claimed, err := repo.ClaimIdempotencyKey(tx, key, cutoff)
+		require.NoError(t, err)
+		assert.False(t, claimed)
+
+		stored, err := repo.GetIdempotencyKey(tx, key.UserID, key.Key)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, "hash-a", stored.RequestHash)
+		assert.JSONEq(t, `{"order_id":42}`, string(stored.Response))
+
+		// Keys are scoped per user
+		other := &models.IdempotencyKey{UserID: 2, Key: key.Key, RequestHash: "hash-b", CreatedAt: now}
+		claimed, err = repo.ClaimIdempotencyKey(tx, other, cutoff)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+
+		missing, err := repo.GetIdempotencyKey(tx, 3, key.Key)
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestIdempotencyRepository_RetentionWindow(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewIdempotencyRepository(baseRepo)
+	now := time.Now().UnixMilli()
+	stale := now - 2*time.Hour.Milliseconds()
+	cutoff := now - time.Hour.Milliseconds()
+
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		for _, key := range []*models.IdempotencyKey{
+			{UserID: 1, Key: "stale", RequestHash: "hash-a", CreatedAt: stale},
+			{UserID: 1, Key: "pruned", RequestHash: "hash-a", CreatedAt: stale},
+		} {
+			if _, err := repo.ClaimIdempotencyKey(tx, key, cutoff); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	// A key past the retention window is reclaimed by a new request
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		claimed, err := repo.ClaimIdempotencyKey(tx, &models.IdempotencyKey{
+			UserID: 1, Key: "stale", RequestHash: "hash-b", CreatedAt: now,
+		}, cutoff)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+
+		stored, err := repo.GetIdempotencyKey(tx, 1, "stale")
+		require.NoError(t, err)
+		assert.Equal(t, "hash-b", stored.RequestHash)
+		assert.Empty(t, stored.Response)
+		return nil
+	})
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteIdempotencyKeysBefore(cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
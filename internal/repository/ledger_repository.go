@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"tickets/internal/models/db"
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+// ErrUnbalancedLedgerTransaction is returned for a ledger transaction whose debits and credits
+// differ or whose entries are malformed
+var ErrUnbalancedLedgerTransaction = errors.New("ledger transaction does not balance")
+
+// LedgerRepository handles the append-only double-entry ledger
+type LedgerRepository struct {
+	*BaseRepository
+}
+
+// NewLedgerRepository creates a new ledger repository
+func NewLedgerRepository(base *BaseRepository) *LedgerRepository {
+	return &LedgerRepository{BaseRepository: base}
+}
+
+// PostTransaction appends a balanced transaction and its entries within tx, filling in their
+// IDs and the creation time
+func (r *LedgerRepository) PostTransaction(tx *sqlx.Tx, txn *models.LedgerTransaction) error {
+	if len(txn.Entries) == 0 || !txn.Balanced() {
+		return ErrUnbalancedLedgerTransaction
+	}
+
+	query := `
+	INSERT INTO ledger_transactions (kind, order_id, payment_id, refund_id)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at`
+	paymentID := sql.NullInt64{Int64: int64(txn.PaymentID), Valid: txn.PaymentID > 0}
+	refundID := sql.NullInt64{Int64: int64(txn.RefundID), Valid: txn.RefundID > 0}
+	err := tx.QueryRow(query, txn.Kind, txn.OrderID, paymentID, refundID).Scan(&txn.ID, &txn.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	entryQuery := `
+	INSERT INTO ledger_entries (transaction_id, account, debit, credit)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id`
+	for i := range txn.Entries {
+		entry := &txn.Entries[i]
+		entry.TransactionID = txn.ID
+		err = tx.QueryRow(entryQuery, txn.ID, entry.Account, entry.Debit, entry.Credit).Scan(&entry.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListTransactionsByOrderID retrieves an order's ledger transactions with their entries,
+// oldest first
+func (r *LedgerRepository) ListTransactionsByOrderID(orderID int) ([]models.LedgerTransaction, error) {
+	query := `
+	SELECT id, kind, order_id, payment_id, refund_id, created_at
+	FROM ledger_transactions
+	WHERE order_id = $1
+	ORDER BY id`
+
+	var dbTransactions []db.LedgerTransaction
+	if err := r.db.Select(&dbTransactions, query, orderID); err != nil {
+		return nil, err
+	}
+	if len(dbTransactions) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int, len(dbTransactions))
+	byID := make(map[int]int, len(dbTransactions))
+	transactions := make([]models.LedgerTransaction, len(dbTransactions))
+	for i := range dbTransactions {
+		transactions[i] = *dbTransactions[i].ToLedgerTransaction()
+		ids[i] = transactions[i].ID
+		byID[transactions[i].ID] = i
+	}
+
+	var dbEntries []db.LedgerEntry
+	err := r.db.Select(&dbEntries, `
+	SELECT id, transaction_id, account, debit, credit
+	FROM ledger_entries
+	WHERE transaction_id = ANY($1)
+	ORDER BY id`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	for i := range dbEntries {
+		t := &transactions[byID[dbEntries[i].TransactionID]]
+		t.Entries = append(t.Entries, dbEntries[i].ToLedgerEntry())
+	}
+
+	return transactions, nil
+}
+
+// accountTotals is the sum of an account's entries
+type accountTotals struct {
+	Account string          `db:"account"`
+	Debits  decimal.Decimal `db:"debits"`
+	Credits decimal.Decimal `db:"credits"`
+}
+
+// GetAccountBalances totals the entries of every ledger account, including accounts without
+// entries, in the order of models.LedgerAccounts
+func (r *LedgerRepository) GetAccountBalances() ([]models.AccountBalance, error) {
+	query := `
+	SELECT account, COALESCE(SUM(debit), 0) AS debits, COALESCE(SUM(credit), 0) AS credits
+	FROM ledger_entries
+	GROUP BY account`
+
+	var totals []accountTotals
+	if err := r.db.Select(&totals, query); err != nil {
+		return nil, err
+	}
+	byAccount := make(map[string]accountTotals, len(totals))
+	for _, t := range totals {
+		byAccount[t.Account] = t
+	}
+
+	balances := make([]models.AccountBalance, len(models.LedgerAccounts))
+	for i, account := range models.LedgerAccounts {
+		t, ok := byAccount[account]
+		if !ok {
+			t = accountTotals{Debits: decimal.Zero, Credits: decimal.Zero}
+		}
+		balances[i] = models.NewAccountBalance(account, t.Debits, t.Credits)
+	}
+
+	return balances, nil
+}
+
+// GetAccountBalance totals the entries of one ledger account
+func (r *LedgerRepository) GetAccountBalance(account string) (*models.AccountBalance, error) {
+	query := `
+	SELECT COALESCE(SUM(debit), 0) AS debits, COALESCE(SUM(credit), 0) AS credits
+	FROM ledger_entries
+	WHERE account = $1`
+
+	var t accountTotals
+	if err := r.db.Get(&t, query, account); err != nil {
+		return nil, err
+	}
+
+	balance := models.NewAccountBalance(account, t.Debits, t.Credits)
+	return &balance, nil
+}
+
+// CheckBalanced totals every debit and credit in the ledger and lists the transactions whose
+// entries do not balance
+func (r *LedgerRepository) CheckBalanced() (*models.LedgerCheck, error) {
+	var t accountTotals
+	err := r.db.Get(&t, `SELECT COALESCE(SUM(debit), 0) AS debits, COALESCE(SUM(credit), 0) AS credits FROM ledger_entries`)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT lt.id
+	FROM ledger_transactions lt
+	LEFT JOIN ledger_entries le ON le.transaction_id = lt.id
+	GROUP BY lt.id
+	HAVING COALESCE(SUM(le.debit), 0) <> COALESCE(SUM(le.credit), 0) OR COUNT(le.id) = 0
+	ORDER BY lt.id`
+	var unbalanced []int
+	if err := r.db.Select(&unbalanced, query); err != nil {
+		return nil, err
+	}
+
+	return &models.LedgerCheck{
+		TotalDebits:              t.Debits,
+		TotalCredits:             t.Credits,
+		UnbalancedTransactionIDs: unbalanced,
+	}, nil
+}
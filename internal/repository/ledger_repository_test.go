@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"testing"
+
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLedgerRepository(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewLedgerRepository(baseRepo)
+	assert.NotNil(t, repo)
+	assert.Equal(t, baseRepo, repo.BaseRepository)
+}
+
+func TestLedgerRepository_PostTransaction(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewLedgerRepository(baseRepo)
+	orderRepo := NewOrderRepository(baseRepo)
+
+	order := &models.Order{Status: models.OrderStatusPending, Subtotal: decimal.NewFromInt(100),
+		Discount: decimal.NewFromInt(10), TotalPrice: decimal.NewFromInt(90)}
+	placed := (&models.LedgerTransaction{Kind: models.LedgerOrderPlaced}).
+		Debit(models.AccountCustomerReceivable, order.TotalPrice).
+		Debit(models.AccountDiscounts, order.Discount).
+		Credit(models.AccountRevenue, order.Subtotal)
+	paid := (&models.LedgerTransaction{Kind: models.LedgerOrderPaid}).
+		Debit(models.AccountCash, order.TotalPrice).
+		Credit(models.AccountCustomerReceivable, order.TotalPrice)
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := orderRepo.CreateOrder(tx, order); err != nil {
+			return err
+		}
+		placed.OrderID, paid.OrderID = order.ID, order.ID
+		if err := repo.PostTransaction(tx, placed); err != nil {
+			return err
+		}
+		return repo.PostTransaction(tx, paid)
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, placed.ID)
+	assert.NotZero(t, placed.Entries[0].ID)
+
+	// Unbalanced transactions are refused
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		unbalanced := (&models.LedgerTransaction{Kind: models.LedgerOrderPaid, OrderID: order.ID}).
+			Debit(models.AccountCash, decimal.NewFromInt(5))
+		return repo.PostTransaction(tx, unbalanced)
+	})
+	assert.ErrorIs(t, err, ErrUnbalancedLedgerTransaction)
+
+	// Posted entries cannot be changed
+	_, err = baseRepo.db.Exec(`UPDATE ledger_entries SET debit = 0 WHERE id = $1`, placed.Entries[0].ID)
+	assert.Error(t, err)
+	_, err = baseRepo.db.Exec(`DELETE FROM ledger_transactions WHERE id = $1`, placed.ID)
+	assert.Error(t, err)
+
+	transactions, err := repo.ListTransactionsByOrderID(order.ID)
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+	assert.Equal(t, models.LedgerOrderPlaced, transactions[0].Kind)
+	assert.Len(t, transactions[0].Entries, 3)
+	assert.Equal(t, models.LedgerOrderPaid, transactions[1].Kind)
+
+	// Other tests post to the same ledger, so only this order's share of the totals is known
+	balances, err := repo.GetAccountBalances()
+	require.NoError(t, err)
+	require.Len(t, balances, len(models.LedgerAccounts))
+	for i, b := range balances {
+		assert.Equal(t, models.LedgerAccounts[i], b.Account)
+	}
+
+	revenue, err := repo.GetAccountBalance(models.AccountRevenue)
+	require.NoError(t, err)
+	assert.True(t, revenue.Credits.GreaterThanOrEqual(decimal.NewFromInt(100)))
+	assert.True(t, revenue.Balance.Equal(revenue.Credits.Sub(revenue.Debits)))
+
+	check, err := repo.CheckBalanced()
+	require.NoError(t, err)
+	assert.True(t, check.Balanced(), "unbalanced transactions %v", check.UnbalancedTransactionIDs)
+}
@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"database/sql"
+	"tickets/internal/models/db"
 	models "tickets/internal/models/domain"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 )
 
 // OrderRepository handles order and order item-related database operations
@@ -16,15 +20,27 @@ func NewOrderRepository(base *BaseRepository) *OrderRepository {
 	return &OrderRepository{BaseRepository: base}
 }
 
-// CreateOrder creates a new order in the database
+// orderColumns selects an order with the code of its promo code, if any
+const orderColumns = `id, user_id, created_at, status, subtotal, discount_amount, service_fee, tax_amount, tax_rate,
+	tax_jurisdiction, total_price, promo_code_id,
+	(SELECT code FROM promo_codes WHERE promo_codes.id = orders.promo_code_id) AS promo_code,
+	expires_at, cancelled_at, cancellation_reason, refunded_amount`
+
+// CreateOrder creates a new order in the database. A zero Subtotal is recorded as TotalPrice.
 func (r *OrderRepository) CreateOrder(tx *sqlx.Tx, order *models.Order) error {
 	query := `
-		INSERT INTO orders (status, total_price) 
-		VALUES ($1, $2) 
+		INSERT INTO orders (user_id, status, subtotal, discount_amount, service_fee, tax_amount, tax_rate,
+			tax_jurisdiction, total_price, promo_code_id, expires_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) 
 		RETURNING id, created_at, status, total_price`
+	if order.Subtotal.IsZero() {
+		order.Subtotal = order.TotalPrice
+	}
+	promoCodeID := sql.NullInt64{Int64: int64(order.PromoCodeID), Valid: order.PromoCodeID > 0}
+	expiresAt := sql.NullInt64{Int64: order.ExpiresAt, Valid: order.ExpiresAt > 0}
 	var createdAt int64
-	err := tx.QueryRow(query, order.Status, order.TotalPrice).Scan(
-		&order.ID, &createdAt, &order.Status, &order.TotalPrice)
+	err := tx.QueryRow(query, order.UserID, order.Status, order.Subtotal, order.Discount, order.ServiceFee, order.Tax,
+		order.TaxRate, nullString(order.TaxJurisdiction), order.TotalPrice, promoCodeID, expiresAt).Scan(&order.ID, &createdAt, &order.Status, &order.TotalPrice)
 	if err != nil {
 		return err
 	}
@@ -32,3 +48,235 @@ func (r *OrderRepository) CreateOrder(tx *sqlx.Tx, order *models.Order) error {
 
 	return nil
 }
+
+// CreateOrderItems records the tickets belonging to an order, filling in each item's ID
+func (r *OrderRepository) CreateOrderItems(tx *sqlx.Tx, items []models.OrderItem) error {
+	query := `
+		INSERT INTO order_items (order_id, ticket_id, price, ticket_type_id) 
+		VALUES ($1, $2, $3, $4) 
+		RETURNING id`
+
+	for i := range items {
+		ticketTypeID := sql.NullInt64{Int64: int64(items[i].TicketTypeID), Valid: items[i].TicketTypeID > 0}
+		err := tx.QueryRow(query, items[i].OrderID, items[i].TicketID, items[i].Price, ticketTypeID).Scan(&items[i].ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetOrderByID retrieves an order by ID without its items
+func (r *OrderRepository) GetOrderByID(id int) (*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+
+	var dbOrder db.Order
+	err := r.db.Get(&dbOrder, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbOrder.ToOrder(), nil
+}
+
+// GetOrderForUpdate locks an order within tx and loads it together with its items and tickets.
+// It returns nil if the order does not exist.
+func (r *OrderRepository) GetOrderForUpdate(tx *sqlx.Tx, id int) (*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1 FOR UPDATE`
+
+	var dbOrder db.Order
+	err := tx.Get(&dbOrder, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	order := dbOrder.ToOrder()
+	order.Items, err = selectOrderItems(tx, []int{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// GetOrdersByIDs retrieves orders by ID within tx together with their items and tickets
+func (r *OrderRepository) GetOrdersByIDs(tx *sqlx.Tx, ids []int) ([]models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = ANY($1) ORDER BY id`
+
+	var dbOrders []db.Order
+	if err := tx.Select(&dbOrders, query, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	items, err := selectOrderItems(tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	itemsByOrder := make(map[int][]models.OrderItem, len(dbOrders))
+	for _, item := range items {
+		itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], item)
+	}
+
+	orders := make([]models.Order, len(dbOrders))
+	for i := range dbOrders {
+		orders[i] = *dbOrders[i].ToOrder()
+		orders[i].Items = itemsByOrder[orders[i].ID]
+	}
+
+	return orders, nil
+}
+
+// ListOrdersByUserID retrieves a page of a user's orders, newest first, without their items
+func (r *OrderRepository) ListOrdersByUserID(userID int, page Page) ([]models.Order, error) {
+	query := `
+	SELECT ` + orderColumns + `
+	FROM orders
+	WHERE user_id = $1`
+	args := []interface{}{userID}
+
+	if page.After != nil {
+		query += " AND " + keysetCondition("created_at", "id", true, len(args)+1)
+		args = append(args, page.After.Key, page.After.ID)
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	limitOffset, pageArgs := page.limitOffset(len(args) + 1)
+	query += limitOffset
+
+	var dbOrders []db.Order
+	err := r.db.Select(&dbOrders, query, append(args, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]models.Order, len(dbOrders))
+	for i := range dbOrders {
+		orders[i] = *dbOrders[i].ToOrder()
+	}
+
+	return orders, nil
+}
+
+// CountOrdersByUserID returns the total number of orders placed by a user
+func (r *OrderRepository) CountOrdersByUserID(userID int) (int, error) {
+	query := `SELECT COUNT(*) FROM orders WHERE user_id = $1`
+
+	var count int
+	err := r.db.Get(&count, query, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetOrderItemsByOrderID retrieves the items of an order together with their tickets
+func (r *OrderRepository) GetOrderItemsByOrderID(orderID int) ([]models.OrderItem, error) {
+	return r.GetOrderItemsByOrderIDs([]int{orderID})
+}
+
+// GetOrderItemsByOrderIDs retrieves the items of several orders together with their tickets
+func (r *OrderRepository) GetOrderItemsByOrderIDs(orderIDs []int) ([]models.OrderItem, error) {
+	return selectOrderItems(r.db, orderIDs)
+}
+
+// selectOrderItems loads order items joined with their tickets using q, which may be a transaction
+func selectOrderItems(q sqlx.Queryer, orderIDs []int) ([]models.OrderItem, error) {
+	query := `
+	SELECT oi.id, oi.order_id, oi.ticket_id, oi.price, oi.refund_id,
+		t.session_id AS ticket_session_id, t.status AS ticket_status,
+		COALESCE(t.section, '') AS ticket_section, COALESCE(t.seat_row, '') AS ticket_seat_row,
+		COALESCE(t.seat_number, 0) AS ticket_seat_number,
+		oi.ticket_type_id, COALESCE(tt.name, '') AS ticket_type_name
+	FROM order_items oi
+	JOIN tickets t ON t.id = oi.ticket_id
+	LEFT JOIN ticket_types tt ON tt.id = oi.ticket_type_id
+	WHERE oi.order_id = ANY($1)
+	ORDER BY oi.order_id ASC, oi.id ASC`
+
+	var dbItems []db.OrderItem
+	err := sqlx.Select(q, &dbItems, query, pq.Array(orderIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.OrderItem, len(dbItems))
+	for i := range dbItems {
+		items[i] = dbItems[i].ToOrderItem()
+	}
+
+	return items, nil
+}
+
+// LockExpiredPendingOrders locks up to limit pending orders whose hold expired at or before now
+// (Unix milliseconds), other than the orders in skip, and returns their IDs. Orders locked by
+// other transactions are skipped.
+func (r *OrderRepository) LockExpiredPendingOrders(tx *sqlx.Tx, now int64, skip []int, limit int) ([]int, error) {
+	query := `
+	SELECT id
+	FROM orders
+	WHERE status = 'pending' AND expires_at <= $1 AND id <> ALL($2)
+	ORDER BY expires_at ASC
+	LIMIT $3
+	FOR UPDATE SKIP LOCKED`
+
+	if skip == nil {
+		skip = []int{}
+	}
+	var orderIDs []int
+	err := tx.Select(&orderIDs, query, now, pq.Array(skip), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return orderIDs, nil
+}
+
+// UpdateOrderStatuses updates the status of multiple orders
+func (r *OrderRepository) UpdateOrderStatuses(tx *sqlx.Tx, orderIDs []int, status string) error {
+	query := `UPDATE orders SET status = $1 WHERE id = ANY($2)`
+
+	_, err := tx.Exec(query, status, pq.Array(orderIDs))
+	return err
+}
+
+// CancelOrder marks an order as cancelled and records when and why
+func (r *OrderRepository) CancelOrder(tx *sqlx.Tx, orderID int, reason string, cancelledAt int64) error {
+	query := `
+	UPDATE orders
+	SET status = 'cancelled', cancelled_at = $1, cancellation_reason = $2
+	WHERE id = $3`
+
+	_, err := tx.Exec(query, cancelledAt, sql.NullString{String: reason, Valid: reason != ""}, orderID)
+	return err
+}
+
+// SetOrderItemsRefund marks order items as refunded by a refund
+func (r *OrderRepository) SetOrderItemsRefund(tx *sqlx.Tx, itemIDs []int, refundID int) error {
+	query := `UPDATE order_items SET refund_id = $1 WHERE id = ANY($2)`
+
+	_, err := tx.Exec(query, refundID, pq.Array(itemIDs))
+	return err
+}
+
+// ClearOrderItemsRefund unmarks the order items refunded by a refund that failed
+func (r *OrderRepository) ClearOrderItemsRefund(tx *sqlx.Tx, refundID int) error {
+	query := `UPDATE order_items SET refund_id = NULL WHERE refund_id = $1`
+
+	_, err := tx.Exec(query, refundID)
+	return err
+}
+
+// AddRefundedAmount adds amount to an order's refunded amount and sets its status
+func (r *OrderRepository) AddRefundedAmount(tx *sqlx.Tx, orderID int, amount decimal.Decimal, status string) error {
+	query := `UPDATE orders SET refunded_amount = refunded_amount + $1, status = $2 WHERE id = $3`
+
+	_, err := tx.Exec(query, amount, status, orderID)
+	return err
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"strconv"
 	"testing"
+	"time"
 
 	models "tickets/internal/models/domain"
 
@@ -272,3 +274,324 @@ func TestOrderRepository_CreateOrder_DataConsistency(t *testing.T) {
 		assert.True(t, order.TotalPrice.Equal(dbOrder.TotalPrice))
 	}
 }
+
+func TestOrderRepository_GetOrderByID(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewOrderRepository(baseRepo)
+
+	// Test getting non-existent order
+	order, err := repo.GetOrderByID(999999)
+	require.NoError(t, err)
+	assert.Nil(t, order)
+
+	// Create an order and read it back
+	created := &models.Order{
+		UserID:     7,
+		Status:     "pending",
+		TotalPrice: decimal.NewFromFloat(149.97),
+	}
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		return repo.CreateOrder(tx, created)
+	})
+	require.NoError(t, err)
+
+	order, err = repo.GetOrderByID(created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, order)
+	assert.Equal(t, created.ID, order.ID)
+	assert.Equal(t, 7, order.UserID)
+	assert.Equal(t, created.CreatedAt, order.CreatedAt)
+	assert.Equal(t, "pending", order.Status)
+	assert.True(t, order.TotalPrice.Equal(created.TotalPrice))
+	assert.Empty(t, order.Items)
+}
+
+func TestOrderRepository_GetOrderItemsByOrderID(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewOrderRepository(baseRepo)
+	tickets := createTestTickets(t, baseRepo, 2)
+
+	order := &models.Order{
+		Status:     "pending",
+		TotalPrice: decimal.NewFromFloat(100.00),
+	}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		return repo.CreateOrder(tx, order)
+	})
+	require.NoError(t, err)
+
+	for _, ticket := range tickets {
+		_, err := baseRepo.db.Exec(
+			"INSERT INTO order_items (order_id, ticket_id, price) VALUES ($1, $2, $3)",
+			order.ID, ticket.ID, "50.00")
+		require.NoError(t, err)
+	}
+
+	items, err := repo.GetOrderItemsByOrderID(order.ID)
+	require.NoError(t, err)
+	require.Len(t, items, len(tickets))
+
+	for i, item := range items {
+		assert.NotZero(t, item.ID)
+		assert.Equal(t, order.ID, item.OrderID)
+		assert.Equal(t, tickets[i].ID, item.TicketID)
+		assert.True(t, item.Price.Equal(decimal.NewFromFloat(50.00)))
+		require.NotNil(t, item.Ticket)
+		assert.Equal(t, tickets[i].ID, item.Ticket.ID)
+		assert.Equal(t, tickets[i].SessionID, item.Ticket.SessionID)
+		assert.Equal(t, "available", item.Ticket.Status)
+	}
+
+	// An order without items returns an empty slice
+	items, err = repo.GetOrderItemsByOrderID(999999)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestOrderRepository_CreateOrderItems(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewOrderRepository(baseRepo)
+	tickets := createTestTickets(t, baseRepo, 3)
+
+	order := &models.Order{
+		Status:     "pending",
+		TotalPrice: decimal.NewFromFloat(150.00),
+	}
+	items := make([]models.OrderItem, len(tickets))
+
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.CreateOrder(tx, order); err != nil {
+			return err
+		}
+		for i, ticket := range tickets {
+			items[i] = models.OrderItem{
+				OrderID:  order.ID,
+				TicketID: ticket.ID,
+				Price:    decimal.NewFromFloat(50.00),
+			}
+		}
+		return repo.CreateOrderItems(tx, items)
+	})
+	require.NoError(t, err)
+
+	for _, item := range items {
+		assert.NotZero(t, item.ID)
+	}
+
+	// Verify the items were persisted against the order
+	var count int
+	err = baseRepo.db.Get(&count, "SELECT COUNT(*) FROM order_items WHERE order_id = $1", order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, len(tickets), count)
+}
+
+func TestOrderRepository_CreateOrderItems_TransactionRollback(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewOrderRepository(baseRepo)
+	tickets := createTestTickets(t, baseRepo, 1)
+
+	order := &models.Order{
+		Status:     "pending",
+		TotalPrice: decimal.NewFromFloat(50.00),
+	}
+
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.CreateOrder(tx, order); err != nil {
+			return err
+		}
+		items := []models.OrderItem{{OrderID: order.ID, TicketID: tickets[0].ID, Price: decimal.NewFromFloat(50.00)}}
+		if err := repo.CreateOrderItems(tx, items); err != nil {
+			return err
+		}
+		return assert.AnError
+	})
+	require.Error(t, err)
+
+	var count int
+	err = baseRepo.db.Get(&count, "SELECT COUNT(*) FROM order_items WHERE ticket_id = $1", tickets[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestOrderRepository_ListOrdersByUserID(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewOrderRepository(baseRepo)
+
+	// Use a user ID that no other test writes to
+	const userID = 424242
+	_, err := baseRepo.db.Exec("DELETE FROM orders WHERE user_id = $1", userID)
+	require.NoError(t, err)
+
+	created := make([]*models.Order, 5)
+	for i := range created {
+		created[i] = &models.Order{
+			UserID:     userID,
+			Status:     "pending",
+			TotalPrice: decimal.NewFromInt(int64(i + 1)),
+		}
+		err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+			return repo.CreateOrder(tx, created[i])
+		})
+		require.NoError(t, err)
+	}
+
+	count, err := repo.CountOrdersByUserID(userID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	// First page holds the newest orders
+	orders, err := repo.ListOrdersByUserID(userID, Page{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, created[4].ID, orders[0].ID)
+	assert.Equal(t, created[3].ID, orders[1].ID)
+	assert.Equal(t, userID, orders[0].UserID)
+
+	// Last page holds the remainder
+	orders, err = repo.ListOrdersByUserID(userID, Page{Limit: 2, Offset: 4})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, created[0].ID, orders[0].ID)
+
+	// A cursor continues after the given order
+	after := &Cursor{Key: strconv.FormatInt(created[3].CreatedAt, 10), ID: created[3].ID}
+	orders, err = repo.ListOrdersByUserID(userID, Page{Limit: 2, After: after})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, created[2].ID, orders[0].ID)
+	assert.Equal(t, created[1].ID, orders[1].ID)
+
+	// Other users see nothing
+	orders, err = repo.ListOrdersByUserID(userID+1, Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+}
+
+func TestOrderRepository_LockExpiredPendingOrders(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewOrderRepository(baseRepo)
+	now := time.Now().UnixMilli()
+
+	orders := map[string]*models.Order{
+		"expired":          {Status: "pending", TotalPrice: decimal.NewFromInt(10), ExpiresAt: now - 1000},
+		"still held":       {Status: "pending", TotalPrice: decimal.NewFromInt(10), ExpiresAt: now + 60000},
+		"already paid":     {Status: "paid", TotalPrice: decimal.NewFromInt(10), ExpiresAt: now - 1000},
+		"without deadline": {Status: "pending", TotalPrice: decimal.NewFromInt(10)},
+	}
+	for name, order := range orders {
+		err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+			return repo.CreateOrder(tx, order)
+		})
+		require.NoError(t, err, name)
+	}
+
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		// Skipped orders are left out
+		orderIDs, err := repo.LockExpiredPendingOrders(tx, now, []int{orders["expired"].ID}, 1000)
+		if err != nil {
+			return err
+		}
+		assert.NotContains(t, orderIDs, orders["expired"].ID)
+
+		orderIDs, err = repo.LockExpiredPendingOrders(tx, now, nil, 1000)
+		if err != nil {
+			return err
+		}
+
+		assert.Contains(t, orderIDs, orders["expired"].ID)
+		assert.NotContains(t, orderIDs, orders["still held"].ID)
+		assert.NotContains(t, orderIDs, orders["already paid"].ID)
+		assert.NotContains(t, orderIDs, orders["without deadline"].ID)
+
+		return repo.UpdateOrderStatuses(tx, orderIDs, "expired")
+	})
+	require.NoError(t, err)
+
+	order, err := repo.GetOrderByID(orders["expired"].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "expired", order.Status)
+	assert.Equal(t, orders["expired"].ExpiresAt, order.ExpiresAt)
+}
+
+func TestOrderRepository_GetOrderForUpdate(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewOrderRepository(baseRepo)
+	tickets := createTestTickets(t, baseRepo, 2)
+
+	order := &models.Order{Status: "pending", TotalPrice: decimal.NewFromInt(100)}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.CreateOrder(tx, order); err != nil {
+			return err
+		}
+		return repo.CreateOrderItems(tx, []models.OrderItem{
+			{OrderID: order.ID, TicketID: tickets[0].ID, Price: decimal.NewFromInt(50)},
+			{OrderID: order.ID, TicketID: tickets[1].ID, Price: decimal.NewFromInt(50)},
+		})
+	})
+	require.NoError(t, err)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		locked, err := repo.GetOrderForUpdate(tx, order.ID)
+		require.NoError(t, err)
+		require.NotNil(t, locked)
+		assert.Equal(t, order.ID, locked.ID)
+		assert.Equal(t, "pending", locked.Status)
+		require.Len(t, locked.Items, 2)
+		assert.Equal(t, tickets[0].ID, locked.Items[0].Ticket.ID)
+
+		missing, err := repo.GetOrderForUpdate(tx, 999999)
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestOrderRepository_CancelOrder(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewOrderRepository(baseRepo)
+	cancelledAt := time.Now().UnixMilli()
+
+	withReason := &models.Order{Status: "paid", TotalPrice: decimal.NewFromInt(10)}
+	withoutReason := &models.Order{Status: "pending", TotalPrice: decimal.NewFromInt(10)}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		for _, order := range []*models.Order{withReason, withoutReason} {
+			if err := repo.CreateOrder(tx, order); err != nil {
+				return err
+			}
+		}
+		if err := repo.CancelOrder(tx, withReason.ID, "duplicate purchase", cancelledAt); err != nil {
+			return err
+		}
+		return repo.CancelOrder(tx, withoutReason.ID, "", cancelledAt)
+	})
+	require.NoError(t, err)
+
+	order, err := repo.GetOrderByID(withReason.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", order.Status)
+	assert.Equal(t, cancelledAt, order.CancelledAt)
+	assert.Equal(t, "duplicate purchase", order.CancellationReason)
+
+	order, err = repo.GetOrderByID(withoutReason.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", order.Status)
+	assert.Equal(t, cancelledAt, order.CancelledAt)
+	assert.Empty(t, order.CancellationReason)
+}
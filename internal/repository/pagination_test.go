@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageTokenCodec_RoundTrip(t *testing.T) {
+	codec := NewPageTokenCodec([]byte("secret"))
+
+	cursor := Cursor{Key: "1760000000000", ID: 42, Scope: "orders:7"}
+	token := codec.Encode(cursor)
+	assert.NotContains(t, token, "orders")
+
+	decoded, err := codec.Decode(token)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, *decoded)
+
+	// Another codec with the same secret accepts the token
+	decoded, err = NewPageTokenCodec([]byte("secret")).Decode(token)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, *decoded)
+}
+
+func TestPageTokenCodec_RejectsInvalidTokens(t *testing.T) {
+	codec := NewPageTokenCodec([]byte("secret"))
+	token := codec.Encode(Cursor{Key: "10", ID: 1, Scope: "orders:1"})
+	payload, signature, _ := strings.Cut(token, ".")
+
+	forged := NewPageTokenCodec([]byte("secret")).Encode(Cursor{Key: "10", ID: 1, Scope: "orders:2"})
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", payload},
+		{"bad signature encoding", payload + ".!!"},
+		{"tampered payload", forgedPayload + "." + signature},
+		{"wrong secret", NewPageTokenCodec([]byte("other")).Encode(Cursor{Key: "10", ID: 1})},
+		{"signed non-json payload", "bm90LWpzb24." + base64.RawURLEncoding.EncodeToString(codec.sign("bm90LWpzb24"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codec.Decode(tt.token)
+			assert.ErrorIs(t, err, ErrInvalidPageToken)
+		})
+	}
+}
+
+func TestPageTokenCodec_RandomSecret(t *testing.T) {
+	token := NewPageTokenCodec(nil).Encode(Cursor{Key: "1", ID: 1})
+
+	_, err := NewPageTokenCodec(nil).Decode(token)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestPage_LimitOffset(t *testing.T) {
+	clause, args := Page{Limit: 10, Offset: 20}.limitOffset(3)
+	assert.Equal(t, " LIMIT $3 OFFSET $4", clause)
+	assert.Equal(t, []interface{}{10, 20}, args)
+
+	clause, args = Page{Limit: 10, Offset: 20, After: &Cursor{}}.limitOffset(3)
+	assert.Equal(t, " LIMIT $3", clause)
+	assert.Equal(t, []interface{}{10}, args)
+}
+
+func TestKeysetCondition(t *testing.T) {
+	assert.Equal(t, "(cs.price, cs.id) > ($2, $3)", keysetCondition("cs.price", "cs.id", false, 2))
+	assert.Equal(t, "(created_at, id) < ($1, $2)", keysetCondition("created_at", "id", true, 1))
+}
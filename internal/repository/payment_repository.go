@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"database/sql"
+	"tickets/internal/models/db"
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PaymentRepository handles payment-related database operations
+type PaymentRepository struct {
+	*BaseRepository
+}
+
+// NewPaymentRepository creates a new payment repository
+func NewPaymentRepository(base *BaseRepository) *PaymentRepository {
+	return &PaymentRepository{BaseRepository: base}
+}
+
+const paymentColumns = `id, order_id, provider, provider_reference, amount, status, failure_code, failure_message,
+	created_at, updated_at`
+
+// CreatePayment inserts a payment, filling in its ID and timestamps. It reports false, without
+// inserting anything, if the order already has a pending, authorized or captured payment.
+func (r *PaymentRepository) CreatePayment(tx *sqlx.Tx, payment *models.Payment) (bool, error) {
+	query := `
+	INSERT INTO payments (order_id, provider, provider_reference, amount, status, failure_code, failure_message)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT DO NOTHING
+	RETURNING id, created_at, updated_at`
+
+	err := tx.QueryRow(query, payment.OrderID, payment.Provider, nullString(payment.ProviderReference),
+		payment.Amount, payment.Status, nullString(payment.FailureCode), nullString(payment.FailureMessage),
+	).Scan(&payment.ID, &payment.CreatedAt, &payment.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UpdatePayment stores a payment's status, provider reference and failure, refreshing UpdatedAt
+func (r *PaymentRepository) UpdatePayment(tx *sqlx.Tx, payment *models.Payment) error {
+	query := `
+	UPDATE payments
+	SET provider_reference = $1, status = $2, failure_code = $3, failure_message = $4,
+		updated_at = EXTRACT(EPOCH FROM NOW()) * 1000
+	WHERE id = $5
+	RETURNING updated_at`
+
+	return tx.QueryRow(query, nullString(payment.ProviderReference), payment.Status,
+		nullString(payment.FailureCode), nullString(payment.FailureMessage), payment.ID,
+	).Scan(&payment.UpdatedAt)
+}
+
+// GetPaymentByReference retrieves a payment by its provider's reference, returning nil if it
+// does not exist
+func (r *PaymentRepository) GetPaymentByReference(provider, reference string) (*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE provider = $1 AND provider_reference = $2`
+
+	var dbPayment db.Payment
+	err := r.db.Get(&dbPayment, query, provider, reference)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbPayment.ToPayment(), nil
+}
+
+// GetPaymentForUpdate locks a payment within tx, returning nil if it does not exist
+func (r *PaymentRepository) GetPaymentForUpdate(tx *sqlx.Tx, id int) (*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = $1 FOR UPDATE`
+
+	var dbPayment db.Payment
+	err := tx.Get(&dbPayment, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbPayment.ToPayment(), nil
+}
+
+// GetCapturedPaymentForUpdate locks the captured payment of an order within tx, returning nil
+// if the order has none
+func (r *PaymentRepository) GetCapturedPaymentForUpdate(tx *sqlx.Tx, orderID int) (*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE order_id = $1 AND status = 'captured' FOR UPDATE`
+
+	var dbPayment db.Payment
+	err := tx.Get(&dbPayment, query, orderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbPayment.ToPayment(), nil
+}
+
+// ListPaymentsByOrderID retrieves an order's payments, oldest first
+func (r *PaymentRepository) ListPaymentsByOrderID(orderID int) ([]models.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE order_id = $1 ORDER BY id`
+
+	var dbPayments []db.Payment
+	if err := r.db.Select(&dbPayments, query, orderID); err != nil {
+		return nil, err
+	}
+
+	payments := make([]models.Payment, len(dbPayments))
+	for i := range dbPayments {
+		payments[i] = *dbPayments[i].ToPayment()
+	}
+
+	return payments, nil
+}
+
+// LockPaymentEvent locks a webhook event until tx ends, so a redelivery arriving meanwhile is
+// not applied alongside it. It reports false, without waiting, if another transaction holds
+// the lock.
+func (r *PaymentRepository) LockPaymentEvent(tx *sqlx.Tx, provider, eventID string) (bool, error) {
+	var locked bool
+	err := tx.QueryRow(`SELECT pg_try_advisory_xact_lock(hashtext($1 || ':' || $2))`, provider, eventID).Scan(&locked)
+	if err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+// PaymentEventRecorded reports whether a provider's webhook event was recorded
+func (r *PaymentRepository) PaymentEventRecorded(tx *sqlx.Tx, provider, eventID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM payment_events WHERE provider = $1 AND event_id = $2)`
+
+	var recorded bool
+	if err := tx.QueryRow(query, provider, eventID).Scan(&recorded); err != nil {
+		return false, err
+	}
+	return recorded, nil
+}
+
+// RecordPaymentEvent records a webhook event, filling in its receipt time. It reports false,
+// without recording anything, if the provider's event was already recorded.
+func (r *PaymentRepository) RecordPaymentEvent(tx *sqlx.Tx, event *models.PaymentEvent) (bool, error) {
+	query := `
+	INSERT INTO payment_events (provider, event_id, payment_id, event_type)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (provider, event_id) DO NOTHING
+	RETURNING received_at`
+
+	err := tx.QueryRow(query, event.Provider, event.EventID, event.PaymentID, event.Type).Scan(&event.ReceivedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"testing"
+
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPaymentRepository(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewPaymentRepository(baseRepo)
+	assert.NotNil(t, repo)
+	assert.Equal(t, baseRepo, repo.BaseRepository)
+}
+
+func TestPaymentRepository_Lifecycle(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewPaymentRepository(baseRepo)
+	orderRepo := NewOrderRepository(baseRepo)
+
+	order := &models.Order{Status: models.OrderStatusPending, TotalPrice: decimal.RequireFromString("80.00")}
+	declined := &models.Payment{Provider: "fake", Amount: order.TotalPrice, Status: models.PaymentStatusPending}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := orderRepo.CreateOrder(tx, order); err != nil {
+			return err
+		}
+		declined.OrderID = order.ID
+
+		created, err := repo.CreatePayment(tx, declined)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		// Only one payment may be in flight per order
+		concurrent := &models.Payment{OrderID: order.ID, Provider: "fake", Amount: order.TotalPrice, Status: models.PaymentStatusPending}
+		created, err = repo.CreatePayment(tx, concurrent)
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		declined.Status = models.PaymentStatusDeclined
+		declined.FailureCode = "card_declined"
+		declined.FailureMessage = "the card was declined"
+		return repo.UpdatePayment(tx, declined)
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, declined.ID)
+	assert.NotZero(t, declined.UpdatedAt)
+
+	// A declined payment no longer blocks another attempt
+	captured := &models.Payment{OrderID: order.ID, Provider: "fake", Amount: order.TotalPrice, Status: models.PaymentStatusPending}
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		created, err := repo.CreatePayment(tx, captured)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		captured.ProviderReference = "fake_1"
+		captured.Status = models.PaymentStatusCaptured
+		return repo.UpdatePayment(tx, captured)
+	})
+	require.NoError(t, err)
+
+	payments, err := repo.ListPaymentsByOrderID(order.ID)
+	require.NoError(t, err)
+	require.Len(t, payments, 2)
+	assert.Equal(t, models.PaymentStatusDeclined, payments[0].Status)
+	assert.Equal(t, "card_declined", payments[0].FailureCode)
+	assert.Empty(t, payments[0].ProviderReference)
+	assert.Equal(t, models.PaymentStatusCaptured, payments[1].Status)
+	assert.Equal(t, "fake_1", payments[1].ProviderReference)
+	assert.True(t, order.TotalPrice.Equal(payments[1].Amount))
+}
+
+func TestPaymentRepository_Events(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewPaymentRepository(baseRepo)
+	orderRepo := NewOrderRepository(baseRepo)
+
+	order := &models.Order{Status: models.OrderStatusPending, TotalPrice: decimal.RequireFromString("25.00")}
+	pmt := &models.Payment{Provider: "fake", ProviderReference: "fake_42", Amount: order.TotalPrice, Status: models.PaymentStatusAuthorized}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := orderRepo.CreateOrder(tx, order); err != nil {
+			return err
+		}
+		pmt.OrderID = order.ID
+		_, err := repo.CreatePayment(tx, pmt)
+		return err
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetPaymentByReference("fake", "fake_42")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, pmt.ID, found.ID)
+
+	found, err = repo.GetPaymentByReference("other", "fake_42")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		recorded, err := repo.PaymentEventRecorded(tx, "fake", "evt_1")
+		require.NoError(t, err)
+		assert.False(t, recorded)
+
+		event := &models.PaymentEvent{Provider: "fake", EventID: "evt_1", PaymentID: pmt.ID, Type: "payment.captured"}
+		recorded, err = repo.RecordPaymentEvent(tx, event)
+		require.NoError(t, err)
+		assert.True(t, recorded)
+		assert.NotZero(t, event.ReceivedAt)
+
+		recorded, err = repo.PaymentEventRecorded(tx, "fake", "evt_1")
+		require.NoError(t, err)
+		assert.True(t, recorded)
+
+		// The same event is recorded once per provider
+		recorded, err = repo.RecordPaymentEvent(tx, &models.PaymentEvent{Provider: "fake", EventID: "evt_1", PaymentID: pmt.ID, Type: "payment.captured"})
+		require.NoError(t, err)
+		assert.False(t, recorded)
+
+		locked, err := repo.GetPaymentForUpdate(tx, pmt.ID)
+		require.NoError(t, err)
+		require.NotNil(t, locked)
+		assert.Equal(t, models.PaymentStatusAuthorized, locked.Status)
+		return nil
+	})
+	require.NoError(t, err)
+}
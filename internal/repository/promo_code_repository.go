@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"tickets/internal/models/db"
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PromoCodeRepository handles promo code-related database operations
+type PromoCodeRepository struct {
+	*BaseRepository
+}
+
+// NewPromoCodeRepository creates a new promo code repository
+func NewPromoCodeRepository(base *BaseRepository) *PromoCodeRepository {
+	return &PromoCodeRepository{BaseRepository: base}
+}
+
+// promoCodeColumns selects a promo code with the number of pending and paid orders that
+// redeemed it, which count against its limit
+const promoCodeColumns = `pc.id, pc.code, pc.discount_type, pc.discount_value, pc.valid_from, pc.valid_until,
+	pc.max_redemptions, pc.max_redemptions_per_user, pc.concert_id, pc.session_id, pc.created_at,
+	(SELECT COUNT(*) FROM orders o WHERE o.promo_code_id = pc.id AND o.status IN ('pending', 'paid')) AS redemptions`
+
+// CreatePromoCode inserts a promo code, filling in its ID and creation time. It reports false,
+// without inserting anything, if the code is already taken.
+func (r *PromoCodeRepository) CreatePromoCode(tx *sqlx.Tx, promoCode *models.PromoCode) (bool, error) {
+	query := `
+	INSERT INTO promo_codes (code, discount_type, discount_value, valid_from, valid_until,
+		max_redemptions, max_redemptions_per_user, concert_id, session_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (code) DO NOTHING
+	RETURNING id, created_at`
+
+	err := tx.QueryRow(query, promoCode.Code, promoCode.DiscountType, promoCode.DiscountValue,
+		nullInt64(promoCode.ValidFrom), nullInt64(promoCode.ValidUntil),
+		nullInt64(int64(promoCode.MaxRedemptions)), nullInt64(int64(promoCode.MaxRedemptionsPerUser)),
+		nullInt64(int64(promoCode.ConcertID)), nullInt64(int64(promoCode.SessionID)),
+	).Scan(&promoCode.ID, &promoCode.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetPromoCodeByCode retrieves a promo code with its redemptions, returning nil if it does not exist
+func (r *PromoCodeRepository) GetPromoCodeByCode(code string) (*models.PromoCode, error) {
+	query := `SELECT ` + promoCodeColumns + ` FROM promo_codes pc WHERE pc.code = $1`
+
+	var dbPromoCode db.PromoCode
+	err := r.db.Get(&dbPromoCode, query, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbPromoCode.ToPromoCode(), nil
+}
+
+// LockPromoCodeByCode locks a promo code within tx, serialising its redemptions, and returns it
+// with its redemptions. It returns nil if the code does not exist.
+func (r *PromoCodeRepository) LockPromoCodeByCode(tx *sqlx.Tx, code string) (*models.PromoCode, error) {
+	var id int
+	err := tx.Get(&id, `SELECT id FROM promo_codes WHERE code = $1 FOR UPDATE`, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.getPromoCode(tx, id)
+}
+
+// GetPromoCodeForUpdate locks a promo code within tx, returning nil if it does not exist
+func (r *PromoCodeRepository) GetPromoCodeForUpdate(tx *sqlx.Tx, id int) (*models.PromoCode, error) {
+	var locked int
+	err := tx.Get(&locked, `SELECT id FROM promo_codes WHERE id = $1 FOR UPDATE`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.getPromoCode(tx, id)
+}
+
+// getPromoCode reads a promo code locked in tx. Redemptions are counted once the lock is held,
+// so they include orders committed while waiting for it.
+func (r *PromoCodeRepository) getPromoCode(tx *sqlx.Tx, id int) (*models.PromoCode, error) {
+	var dbPromoCode db.PromoCode
+	err := tx.Get(&dbPromoCode, `SELECT `+promoCodeColumns+` FROM promo_codes pc WHERE pc.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbPromoCode.ToPromoCode(), nil
+}
+
+// CountUserRedemptions returns how many of a user's pending and paid orders redeemed a promo code
+func (r *PromoCodeRepository) CountUserRedemptions(tx *sqlx.Tx, promoCodeID, userID int) (int, error) {
+	query := `SELECT COUNT(*) FROM orders WHERE promo_code_id = $1 AND user_id = $2 AND status IN ('pending', 'paid')`
+
+	var count int
+	err := tx.Get(&count, query, promoCodeID, userID)
+	return count, err
+}
+
+// HasOrders reports whether any order, in any status, redeemed a promo code
+func (r *PromoCodeRepository) HasOrders(tx *sqlx.Tx, id int) (bool, error) {
+	var exists bool
+	err := tx.Get(&exists, `SELECT EXISTS (SELECT 1 FROM orders WHERE promo_code_id = $1)`, id)
+	return exists, err
+}
+
+// DeletePromoCode deletes a promo code
+func (r *PromoCodeRepository) DeletePromoCode(tx *sqlx.Tx, id int) error {
+	_, err := tx.Exec(`DELETE FROM promo_codes WHERE id = $1`, id)
+	return err
+}
+
+// nullInt64 stores a zero value as NULL
+func nullInt64(v int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: v, Valid: v != 0}
+}
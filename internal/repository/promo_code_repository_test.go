@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"testing"
+
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPromoCodeRepository(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewPromoCodeRepository(baseRepo)
+	assert.NotNil(t, repo)
+	assert.Equal(t, baseRepo, repo.BaseRepository)
+}
+
+func TestPromoCodeRepository_CreateAndGet(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewPromoCodeRepository(baseRepo)
+	sessionID := createTestConcertSession(t, baseRepo)
+
+	promoCode := &models.PromoCode{
+		Code: "REPO-SPRING", DiscountType: models.DiscountTypePercentage, DiscountValue: decimal.NewFromInt(15),
+		ValidUntil: 4102444800000, MaxRedemptionsPerUser: 1, SessionID: sessionID,
+	}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		created, err := repo.CreatePromoCode(tx, promoCode)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		// The same code cannot be created twice
+		duplicate := *promoCode
+		created, err = repo.CreatePromoCode(tx, &duplicate)
+		require.NoError(t, err)
+		assert.False(t, created)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, promoCode.ID)
+	assert.NotZero(t, promoCode.CreatedAt)
+
+	found, err := repo.GetPromoCodeByCode("REPO-SPRING")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, models.DiscountTypePercentage, found.DiscountType)
+	assert.True(t, decimal.NewFromInt(15).Equal(found.DiscountValue))
+	assert.Equal(t, int64(0), found.ValidFrom)
+	assert.Equal(t, int64(4102444800000), found.ValidUntil)
+	assert.Equal(t, 0, found.MaxRedemptions)
+	assert.Equal(t, 1, found.MaxRedemptionsPerUser)
+	assert.Equal(t, sessionID, found.SessionID)
+	assert.Equal(t, 0, found.Redemptions)
+
+	missing, err := repo.GetPromoCodeByCode("REPO-MISSING")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestPromoCodeRepository_Redemptions(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewPromoCodeRepository(baseRepo)
+	orderRepo := NewOrderRepository(baseRepo)
+
+	promoCode := &models.PromoCode{Code: "REPO-TENOFF", DiscountType: models.DiscountTypeFixed, DiscountValue: decimal.NewFromInt(10)}
+	var order *models.Order
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if _, err := repo.CreatePromoCode(tx, promoCode); err != nil {
+			return err
+		}
+		// Two orders by user 7, one of which expired and no longer counts
+		for _, status := range []string{"expired", "paid"} {
+			order = &models.Order{
+				UserID: 7, Status: status, Subtotal: decimal.NewFromInt(50), Discount: decimal.NewFromInt(10),
+				TotalPrice: decimal.NewFromInt(40), PromoCodeID: promoCode.ID,
+			}
+			if err := orderRepo.CreateOrder(tx, order); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		locked, err := repo.LockPromoCodeByCode(tx, "REPO-TENOFF")
+		require.NoError(t, err)
+		require.NotNil(t, locked)
+		assert.Equal(t, 1, locked.Redemptions)
+
+		count, err := repo.CountUserRedemptions(tx, promoCode.ID, 7)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		count, err = repo.CountUserRedemptions(tx, promoCode.ID, 8)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		hasOrders, err := repo.HasOrders(tx, promoCode.ID)
+		require.NoError(t, err)
+		assert.True(t, hasOrders)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Orders record the applied discount and code
+	found, err := orderRepo.GetOrderByID(order.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.True(t, decimal.NewFromInt(50).Equal(found.Subtotal))
+	assert.True(t, decimal.NewFromInt(10).Equal(found.Discount))
+	assert.Equal(t, promoCode.ID, found.PromoCodeID)
+	assert.Equal(t, "REPO-TENOFF", found.PromoCode)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"database/sql"
+	"tickets/internal/models/db"
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RefundRepository handles refund-related database operations
+type RefundRepository struct {
+	*BaseRepository
+}
+
+// NewRefundRepository creates a new refund repository
+func NewRefundRepository(base *BaseRepository) *RefundRepository {
+	return &RefundRepository{BaseRepository: base}
+}
+
+// CreateRefund inserts a refund, filling in its ID and creation time. The refunded order items
+// are marked with OrderRepository.SetOrderItemsRefund.
+func (r *RefundRepository) CreateRefund(tx *sqlx.Tx, refund *models.Refund) error {
+	query := `
+	INSERT INTO refunds (order_id, payment_id, amount, reason, refunded_by, status, failure_message)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id, created_at`
+
+	paymentID := sql.NullInt64{Int64: int64(refund.PaymentID), Valid: refund.PaymentID > 0}
+	return tx.QueryRow(query, refund.OrderID, paymentID, refund.Amount, refund.Reason, refund.RefundedBy,
+		refund.Status, nullString(refund.FailureMessage),
+	).Scan(&refund.ID, &refund.CreatedAt)
+}
+
+// UpdateRefund stores a refund's status and failure message
+func (r *RefundRepository) UpdateRefund(tx *sqlx.Tx, refund *models.Refund) error {
+	query := `UPDATE refunds SET status = $1, failure_message = $2 WHERE id = $3`
+
+	_, err := tx.Exec(query, refund.Status, nullString(refund.FailureMessage), refund.ID)
+	return err
+}
+
+// HasPendingRefund reports whether an order has a refund the payment provider has not settled
+func (r *RefundRepository) HasPendingRefund(tx *sqlx.Tx, orderID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM refunds WHERE order_id = $1 AND status = 'pending')`
+
+	var pending bool
+	if err := tx.QueryRow(query, orderID).Scan(&pending); err != nil {
+		return false, err
+	}
+	return pending, nil
+}
+
+// ListRefundsByOrderID retrieves an order's refunds, oldest first, with the tickets each one
+// refunded. Failed refunds list no tickets.
+func (r *RefundRepository) ListRefundsByOrderID(orderID int) ([]models.Refund, error) {
+	query := `
+	SELECT r.id, r.order_id, r.payment_id, r.amount, r.reason, r.refunded_by, r.status, r.failure_message,
+		r.created_at,
+		ARRAY(SELECT oi.ticket_id::text FROM order_items oi WHERE oi.refund_id = r.id ORDER BY oi.id) AS ticket_ids
+	FROM refunds r
+	WHERE r.order_id = $1
+	ORDER BY r.id`
+
+	var dbRefunds []db.Refund
+	if err := r.db.Select(&dbRefunds, query, orderID); err != nil {
+		return nil, err
+	}
+
+	refunds := make([]models.Refund, len(dbRefunds))
+	for i := range dbRefunds {
+		refunds[i] = *dbRefunds[i].ToRefund()
+	}
+
+	return refunds, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"testing"
+
+	models "tickets/internal/models/domain"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRefundRepository(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewRefundRepository(baseRepo)
+	assert.NotNil(t, repo)
+	assert.Equal(t, baseRepo, repo.BaseRepository)
+}
+
+func TestRefundRepository_Lifecycle(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewRefundRepository(baseRepo)
+	orderRepo := NewOrderRepository(baseRepo)
+	tickets := createTestTickets(t, baseRepo, 2)
+
+	order := &models.Order{Status: models.OrderStatusPaid, TotalPrice: decimal.NewFromInt(100)}
+	items := []models.OrderItem{
+		{TicketID: tickets[0].ID, Price: decimal.NewFromInt(50)},
+		{TicketID: tickets[1].ID, Price: decimal.NewFromInt(50)},
+	}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := orderRepo.CreateOrder(tx, order); err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].OrderID = order.ID
+		}
+		return orderRepo.CreateOrderItems(tx, items)
+	})
+	require.NoError(t, err)
+
+	// A refund that fails gives its item back
+	failed := &models.Refund{OrderID: order.ID, Amount: decimal.NewFromInt(50), Reason: "duplicate purchase",
+		RefundedBy: "support", Status: models.RefundStatusPending}
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.CreateRefund(tx, failed); err != nil {
+			return err
+		}
+		if err := orderRepo.SetOrderItemsRefund(tx, []int{items[0].ID}, failed.ID); err != nil {
+			return err
+		}
+
+		failed.Status = models.RefundStatusFailed
+		failed.FailureMessage = "provider error"
+		if err := repo.UpdateRefund(tx, failed); err != nil {
+			return err
+		}
+		return orderRepo.ClearOrderItemsRefund(tx, failed.ID)
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, failed.ID)
+	assert.NotZero(t, failed.CreatedAt)
+
+	succeeded := &models.Refund{OrderID: order.ID, Amount: decimal.NewFromInt(50), Reason: "duplicate purchase",
+		RefundedBy: "support", Status: models.RefundStatusPending}
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.CreateRefund(tx, succeeded); err != nil {
+			return err
+		}
+		if err := orderRepo.SetOrderItemsRefund(tx, []int{items[0].ID}, succeeded.ID); err != nil {
+			return err
+		}
+		pending, err := repo.HasPendingRefund(tx, order.ID)
+		require.NoError(t, err)
+		assert.True(t, pending)
+
+		succeeded.Status = models.RefundStatusSucceeded
+		if err := repo.UpdateRefund(tx, succeeded); err != nil {
+			return err
+		}
+		pending, err = repo.HasPendingRefund(tx, order.ID)
+		require.NoError(t, err)
+		assert.False(t, pending)
+		return orderRepo.AddRefundedAmount(tx, order.ID, succeeded.Amount, models.OrderStatusPaid)
+	})
+	require.NoError(t, err)
+
+	refunds, err := repo.ListRefundsByOrderID(order.ID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 2)
+	assert.Equal(t, models.RefundStatusFailed, refunds[0].Status)
+	assert.Equal(t, "provider error", refunds[0].FailureMessage)
+	assert.Empty(t, refunds[0].TicketIDs)
+	assert.Equal(t, models.RefundStatusSucceeded, refunds[1].Status)
+	assert.Equal(t, "support", refunds[1].RefundedBy)
+	assert.Zero(t, refunds[1].PaymentID)
+	assert.Equal(t, []uuid.UUID{tickets[0].ID}, refunds[1].TicketIDs)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		locked, err := orderRepo.GetOrderForUpdate(tx, order.ID)
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(50).Equal(locked.RefundedAmount))
+		require.Len(t, locked.Items, 2)
+		assert.Equal(t, succeeded.ID, locked.Items[0].RefundID)
+		assert.Zero(t, locked.Items[1].RefundID)
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -192,8 +192,6 @@ func initializeTestSchema(db *sqlx.DB) error {
 			total_price DECIMAL(10,2) NOT NULL
 		);
 
-		-- Note: order_items table removed as it's not used in the current application
-
 		-- Create schema_migrations table for migration tests
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			version BIGINT PRIMARY KEY,
@@ -208,6 +206,193 @@ func initializeTestSchema(db *sqlx.DB) error {
 		}
 	}
 
+	// Apply schema added by later migrations; every statement is idempotent so
+	// databases created before these migrations are brought up to date as well
+	incrementalSchema := `
+	-- 003_create_order_items
+	CREATE TABLE IF NOT EXISTS order_items (
+		id SERIAL PRIMARY KEY,
+		order_id INTEGER NOT NULL,
+		ticket_id UUID NOT NULL,
+		price DECIMAL(10,2) NOT NULL,
+		FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+		FOREIGN KEY (ticket_id) REFERENCES tickets(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id);
+
+	-- 004_add_orders_user_id
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS user_id INTEGER NOT NULL DEFAULT 0;
+	CREATE INDEX IF NOT EXISTS idx_orders_user_id_created_at ON orders(user_id, created_at DESC, id DESC);
+
+	-- 005_add_orders_expires_at
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS expires_at BIGINT;
+	CREATE INDEX IF NOT EXISTS idx_orders_pending_expires_at ON orders(expires_at) WHERE status = 'pending';
+
+	-- 006_add_order_cancellation
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancelled_at BIGINT;
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+
+	-- 007_create_idempotency_keys
+	CREATE TABLE IF NOT EXISTS idempotency_keys (
+		user_id INTEGER NOT NULL,
+		idempotency_key VARCHAR(255) NOT NULL,
+		request_hash VARCHAR(64) NOT NULL,
+		response JSONB,
+		created_at BIGINT NOT NULL,
+		PRIMARY KEY (user_id, idempotency_key)
+	);
+	CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+
+	-- 008_add_concert_session_filter_indexes
+	CREATE INDEX IF NOT EXISTS idx_concert_sessions_start_time ON concert_sessions(start_time, id);
+	CREATE INDEX IF NOT EXISTS idx_concert_sessions_price ON concert_sessions(price, id);
+	CREATE INDEX IF NOT EXISTS idx_concert_sessions_venue_lower ON concert_sessions(LOWER(venue));
+	CREATE INDEX IF NOT EXISTS idx_concerts_location_lower ON concerts(LOWER(location));
+	CREATE INDEX IF NOT EXISTS idx_tickets_available_session_id ON tickets(session_id) WHERE status = 'available';
+
+	-- 009_add_ticket_seats
+	ALTER TABLE tickets ADD COLUMN IF NOT EXISTS section VARCHAR(50);
+	ALTER TABLE tickets ADD COLUMN IF NOT EXISTS seat_row VARCHAR(10);
+	ALTER TABLE tickets ADD COLUMN IF NOT EXISTS seat_number INTEGER;
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_session_seat
+		ON tickets(session_id, section, seat_row, seat_number)
+		WHERE seat_number IS NOT NULL;
+
+	-- 010_add_ticket_section_rank
+	ALTER TABLE tickets ADD COLUMN IF NOT EXISTS section_rank SMALLINT;
+	CREATE INDEX IF NOT EXISTS idx_tickets_session_available_seats
+		ON tickets(session_id, section_rank, section, seat_row, seat_number)
+		WHERE status = 'available' AND seat_number IS NOT NULL;
+
+	-- 011_create_ticket_types
+	CREATE TABLE IF NOT EXISTS ticket_types (
+		id SERIAL PRIMARY KEY,
+		session_id INTEGER NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		description TEXT,
+		price DECIMAL(10,2) NOT NULL CHECK (price > 0),
+		quota INTEGER NOT NULL CHECK (quota > 0),
+		FOREIGN KEY (session_id) REFERENCES concert_sessions(id) ON DELETE CASCADE,
+		UNIQUE (session_id, name)
+	);
+	ALTER TABLE order_items ADD COLUMN IF NOT EXISTS ticket_type_id INTEGER REFERENCES ticket_types(id);
+	CREATE INDEX IF NOT EXISTS idx_order_items_ticket_type_id ON order_items(ticket_type_id)
+		WHERE ticket_type_id IS NOT NULL;
+
+	-- 012_create_promo_codes
+	CREATE TABLE IF NOT EXISTS promo_codes (
+		id SERIAL PRIMARY KEY,
+		code VARCHAR(50) NOT NULL UNIQUE,
+		discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+		discount_value DECIMAL(10,2) NOT NULL CHECK (discount_value > 0),
+		valid_from BIGINT,
+		valid_until BIGINT,
+		max_redemptions INTEGER CHECK (max_redemptions > 0),
+		max_redemptions_per_user INTEGER CHECK (max_redemptions_per_user > 0),
+		concert_id INTEGER REFERENCES concerts(id) ON DELETE CASCADE,
+		session_id INTEGER REFERENCES concert_sessions(id) ON DELETE CASCADE,
+		created_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000,
+		CHECK (discount_type <> 'percentage' OR discount_value <= 100),
+		CHECK (valid_from IS NULL OR valid_until IS NULL OR valid_from < valid_until)
+	);
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal DECIMAL(10,2);
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code_id INTEGER REFERENCES promo_codes(id);
+	UPDATE orders SET subtotal = total_price WHERE subtotal IS NULL;
+	CREATE INDEX IF NOT EXISTS idx_orders_promo_code_id ON orders(promo_code_id, user_id)
+		WHERE promo_code_id IS NOT NULL;
+
+	-- 013_create_payments
+	CREATE TABLE IF NOT EXISTS payments (
+		id SERIAL PRIMARY KEY,
+		order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+		provider VARCHAR(50) NOT NULL,
+		provider_reference VARCHAR(255),
+		amount DECIMAL(10,2) NOT NULL CHECK (amount >= 0),
+		status VARCHAR(20) NOT NULL DEFAULT 'pending'
+			CHECK (status IN ('pending', 'authorized', 'captured', 'declined', 'failed', 'voided', 'refunded')),
+		failure_code VARCHAR(100),
+		failure_message TEXT,
+		created_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000,
+		updated_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000
+	);
+	CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(order_id);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_order_id_active ON payments(order_id)
+		WHERE status IN ('pending', 'authorized', 'captured');
+
+	-- 014_create_payment_events
+	CREATE TABLE IF NOT EXISTS payment_events (
+		provider VARCHAR(50) NOT NULL,
+		event_id VARCHAR(255) NOT NULL,
+		payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+		event_type VARCHAR(50) NOT NULL,
+		received_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000,
+		PRIMARY KEY (provider, event_id)
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_provider_reference ON payments(provider, provider_reference)
+		WHERE provider_reference IS NOT NULL;
+
+	-- 015_create_refunds
+	CREATE TABLE IF NOT EXISTS refunds (
+		id SERIAL PRIMARY KEY,
+		order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+		payment_id INTEGER REFERENCES payments(id),
+		amount DECIMAL(10,2) NOT NULL CHECK (amount >= 0),
+		reason TEXT NOT NULL,
+		refunded_by VARCHAR(100) NOT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+		failure_message TEXT,
+		created_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000
+	);
+	CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds(order_id);
+	ALTER TABLE order_items ADD COLUMN IF NOT EXISTS refund_id INTEGER REFERENCES refunds(id);
+	CREATE INDEX IF NOT EXISTS idx_order_items_refund_id ON order_items(refund_id) WHERE refund_id IS NOT NULL;
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS refunded_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+	ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_status_check;
+	ALTER TABLE tickets ADD CONSTRAINT tickets_status_check CHECK (status IN ('pending', 'sold', 'available', 'void'));
+
+	-- 016_create_ledger
+	CREATE TABLE IF NOT EXISTS ledger_transactions (
+		id SERIAL PRIMARY KEY,
+		kind VARCHAR(30) NOT NULL,
+		order_id INTEGER NOT NULL REFERENCES orders(id),
+		payment_id INTEGER REFERENCES payments(id),
+		refund_id INTEGER REFERENCES refunds(id),
+		created_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000
+	);
+	CREATE INDEX IF NOT EXISTS idx_ledger_transactions_order_id ON ledger_transactions(order_id);
+	CREATE TABLE IF NOT EXISTS ledger_entries (
+		id SERIAL PRIMARY KEY,
+		transaction_id INTEGER NOT NULL REFERENCES ledger_transactions(id),
+		account VARCHAR(50) NOT NULL,
+		debit DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (debit >= 0),
+		credit DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (credit >= 0),
+		CHECK ((debit > 0) <> (credit > 0))
+	);
+	CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
+	CREATE INDEX IF NOT EXISTS idx_ledger_entries_account ON ledger_entries(account);
+	CREATE OR REPLACE FUNCTION ledger_append_only() RETURNS trigger AS $$
+	BEGIN
+		RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+	END;
+	$$ LANGUAGE plpgsql;
+	DROP TRIGGER IF EXISTS ledger_transactions_append_only ON ledger_transactions;
+	CREATE TRIGGER ledger_transactions_append_only BEFORE UPDATE OR DELETE ON ledger_transactions
+		FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+	DROP TRIGGER IF EXISTS ledger_entries_append_only ON ledger_entries;
+	CREATE TRIGGER ledger_entries_append_only BEFORE UPDATE OR DELETE ON ledger_entries
+		FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+
+	-- 017_add_order_fees_and_tax
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS service_fee DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (service_fee >= 0);
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_amount DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (tax_amount >= 0);
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_rate DECIMAL(6,3) NOT NULL DEFAULT 0 CHECK (tax_rate BETWEEN 0 AND 100);
+	ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_jurisdiction VARCHAR(100);
+	`
+	if _, err = tx.Exec(incrementalSchema); err != nil {
+		return fmt.Errorf("failed to apply incremental schema: %w", err)
+	}
+
 	// Commit the transaction
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -232,8 +417,17 @@ func initializeTestSchema(db *sqlx.DB) error {
 func CleanupTestData(t *testing.T, baseRepo *BaseRepository) {
 	// Clean up test data
 	queries := []string{
+		// The append-only ledger rejects DELETE
+		"TRUNCATE ledger_entries, ledger_transactions",
+		"DELETE FROM idempotency_keys",
+		"DELETE FROM order_items",
+		"DELETE FROM refunds",
+		"DELETE FROM payment_events",
+		"DELETE FROM payments",
 		"DELETE FROM orders",
+		"DELETE FROM promo_codes",
 		"DELETE FROM tickets",
+		"DELETE FROM ticket_types",
 		"DELETE FROM concert_sessions",
 		"DELETE FROM concerts",
 		"DELETE FROM schema_migrations",
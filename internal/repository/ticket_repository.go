@@ -1,10 +1,17 @@
 package repository
 
 import (
-	"github.com/jmoiron/sqlx"
 	models "tickets/internal/models/domain"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// ticketColumns selects a ticket with its seat, if any, mapped onto models.Ticket
+const ticketColumns = `id, session_id, status,
+	COALESCE(section, '') AS section, COALESCE(seat_row, '') AS seat_row, COALESCE(seat_number, 0) AS seat_number`
+
 // TicketRepository handles ticket-related database operations
 type TicketRepository struct {
 	*BaseRepository
@@ -15,19 +22,65 @@ func NewTicketRepository(base *BaseRepository) *TicketRepository {
 	return &TicketRepository{BaseRepository: base}
 }
 
-// GetAvailableTicketsBySessionID retrieves available tickets for a session
-func (r *TicketRepository) GetAvailableTicketsBySessionID(sessionID int, numberOfTickets int) ([]models.Ticket, error) {
+// LockAvailableTicketsBySessionID locks up to numberOfTickets available tickets for a session
+// within tx. Rows already locked by concurrent transactions are skipped rather than waited on,
+// so the locks are held until tx commits or rolls back and no two buyers receive the same ticket.
+func (r *TicketRepository) LockAvailableTicketsBySessionID(tx *sqlx.Tx, sessionID int, numberOfTickets int) ([]models.Ticket, error) {
 	query := `
-	SELECT id, session_id, status 
+	SELECT ` + ticketColumns + `
 	FROM tickets 
 	WHERE session_id = $1 AND status = 'available'
 	ORDER BY id ASC
 	LIMIT $2
-	FOR UPDATE
+	FOR UPDATE SKIP LOCKED
 	`
 
 	var tickets []models.Ticket
-	err := r.GetDB().Select(&tickets, query, sessionID, numberOfTickets)
+	err := tx.Select(&tickets, query, sessionID, numberOfTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// GetAvailableTicketsBySessionID retrieves up to limit available tickets for a session without
+// locking them. Use it for browsing only; buying must go through LockAvailableTicketsBySessionID.
+func (r *TicketRepository) GetAvailableTicketsBySessionID(sessionID int, limit int) ([]models.Ticket, error) {
+	query := `
+	SELECT ` + ticketColumns + `
+	FROM tickets
+	WHERE session_id = $1 AND status = 'available'
+	ORDER BY id ASC
+	LIMIT $2`
+
+	tickets := []models.Ticket{}
+	err := r.db.Select(&tickets, query, sessionID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// LockTicketsByIDs locks the given tickets of a session within tx, waiting for concurrent
+// checkouts holding them to finish, and returns those that exist whatever their status.
+// Tickets of other sessions are not returned.
+func (r *TicketRepository) LockTicketsByIDs(tx *sqlx.Tx, sessionID int, ticketIDs []uuid.UUID) ([]models.Ticket, error) {
+	query := `
+	SELECT ` + ticketColumns + `
+	FROM tickets
+	WHERE session_id = $1 AND id = ANY($2::uuid[])
+	ORDER BY id ASC
+	FOR UPDATE`
+
+	ids := make([]string, len(ticketIDs))
+	for i, id := range ticketIDs {
+		ids[i] = id.String()
+	}
+
+	var tickets []models.Ticket
+	err := tx.Select(&tickets, query, sessionID, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
@@ -35,6 +88,173 @@ func (r *TicketRepository) GetAvailableTicketsBySessionID(sessionID int, numberO
 	return tickets, nil
 }
 
+// GetSeatMap retrieves the reserved seats of a session, best section first, optionally limited
+// to one section. General admission tickets are not included.
+func (r *TicketRepository) GetSeatMap(sessionID int, section string) ([]models.Ticket, error) {
+	query := `
+	SELECT ` + ticketColumns + `
+	FROM tickets
+	WHERE session_id = $1 AND seat_number IS NOT NULL AND ($2 = '' OR section = $2)
+	ORDER BY ` + seatPreferenceOrder + `, seat_number ASC`
+
+	seats := []models.Ticket{}
+	err := r.db.Select(&seats, query, sessionID, section)
+	if err != nil {
+		return nil, err
+	}
+
+	return seats, nil
+}
+
+// CountAvailableTicketsBySessionID returns the number of available tickets for a session
+func (r *TicketRepository) CountAvailableTicketsBySessionID(sessionID int) (int, error) {
+	query := `SELECT COUNT(*) FROM tickets WHERE session_id = $1 AND status = 'available'`
+
+	var count int
+	err := r.db.Get(&count, query, sessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CreateTickets creates count available tickets for a session within tx
+func (r *TicketRepository) CreateTickets(tx *sqlx.Tx, sessionID int, count int) error {
+	query := `
+	INSERT INTO tickets (session_id, status)
+	SELECT $1, 'available' FROM generate_series(1, $2)`
+
+	_, err := tx.Exec(query, sessionID, count)
+	return err
+}
+
+// CreateSeatedTickets creates one available ticket for every seat of a section within tx. rank
+// orders the session's sections by preference for best-available allocation, 0 being the best.
+func (r *TicketRepository) CreateSeatedTickets(tx *sqlx.Tx, sessionID int, layout models.SectionLayout, rank int) error {
+	query := `
+	INSERT INTO tickets (session_id, status, section, section_rank, seat_row, seat_number)
+	SELECT $1, 'available', $2, $3, r.label, s.seat
+	FROM unnest($4::text[]) AS r(label)
+	CROSS JOIN generate_series(1, $5) AS s(seat)`
+
+	_, err := tx.Exec(query, sessionID, layout.Name, rank, pq.Array(layout.RowLabels()), layout.SeatsPerRow)
+	return err
+}
+
+// seatPreferenceOrder sorts seats best first: by section rank, then front rows, then seat number
+const seatPreferenceOrder = `COALESCE(section_rank, 0) ASC, section ASC, LENGTH(seat_row) ASC, seat_row ASC`
+
+// FindSeatBlocks returns up to limit runs of at least size adjacent available seats in a row,
+// best first. Seats are read without locks, so callers lock a block with LockSeatBlock and
+// move on to the next one if a concurrent order got there first.
+func (r *TicketRepository) FindSeatBlocks(tx *sqlx.Tx, sessionID int, size int, limit int) ([]models.SeatBlock, error) {
+	query := `
+	SELECT section, seat_row, MIN(seat_number) AS first_seat, COUNT(*) AS length
+	FROM (
+		SELECT section_rank, section, seat_row, seat_number,
+			seat_number - ROW_NUMBER() OVER (PARTITION BY section, seat_row ORDER BY seat_number) AS run
+		FROM tickets
+		WHERE session_id = $1 AND status = 'available' AND seat_number IS NOT NULL
+	) seats
+	GROUP BY section_rank, section, seat_row, run
+	HAVING COUNT(*) >= $2
+	ORDER BY ` + seatPreferenceOrder + `, first_seat ASC
+	LIMIT $3`
+
+	blocks := []models.SeatBlock{}
+	err := tx.Select(&blocks, query, sessionID, size, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// LockSeatBlock locks the size available seats of a row starting at firstSeat within tx.
+// Seats locked by concurrent transactions are skipped rather than waited on, so fewer than size
+// tickets are returned if part of the block is taken.
+func (r *TicketRepository) LockSeatBlock(tx *sqlx.Tx, sessionID int, section, row string, firstSeat, size int) ([]models.Ticket, error) {
+	query := `
+	SELECT ` + ticketColumns + `
+	FROM tickets
+	WHERE session_id = $1 AND section = $2 AND seat_row = $3
+		AND seat_number BETWEEN $4 AND $5 AND status = 'available'
+	ORDER BY seat_number ASC
+	FOR UPDATE SKIP LOCKED`
+
+	var tickets []models.Ticket
+	err := tx.Select(&tickets, query, sessionID, section, row, firstSeat, firstSeat+size-1)
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// LockBestAvailableSeats locks up to count available seats of a session within tx, best first
+// and wherever they are, skipping seats locked by concurrent transactions
+func (r *TicketRepository) LockBestAvailableSeats(tx *sqlx.Tx, sessionID int, count int) ([]models.Ticket, error) {
+	query := `
+	SELECT ` + ticketColumns + `
+	FROM tickets
+	WHERE session_id = $1 AND status = 'available' AND seat_number IS NOT NULL
+	ORDER BY ` + seatPreferenceOrder + `, seat_number ASC
+	LIMIT $2
+	FOR UPDATE SKIP LOCKED`
+
+	var tickets []models.Ticket
+	err := tx.Select(&tickets, query, sessionID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// HasSeats reports whether any ticket of the session is for a reserved seat
+func (r *TicketRepository) HasSeats(tx *sqlx.Tx, sessionID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM tickets WHERE session_id = $1 AND seat_number IS NOT NULL)`
+
+	var exists bool
+	err := tx.Get(&exists, query, sessionID)
+	return exists, err
+}
+
+// CountTicketsBySessionID returns the number of tickets of a session in any status
+func (r *TicketRepository) CountTicketsBySessionID(tx *sqlx.Tx, sessionID int) (int, error) {
+	var count int
+	err := tx.Get(&count, `SELECT COUNT(*) FROM tickets WHERE session_id = $1`, sessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// DeleteAvailableTickets deletes up to count available tickets of a session that no order
+// has ever referenced and returns how many were deleted. Tickets locked by a concurrent
+// checkout are skipped, so a sale in progress is never affected.
+func (r *TicketRepository) DeleteAvailableTickets(tx *sqlx.Tx, sessionID int, count int) (int64, error) {
+	query := `
+	DELETE FROM tickets
+	WHERE id IN (
+		SELECT t.id FROM tickets t
+		WHERE t.session_id = $1 AND t.status = 'available'
+			AND NOT EXISTS (SELECT 1 FROM order_items oi WHERE oi.ticket_id = t.id)
+		ORDER BY t.id DESC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)`
+
+	result, err := tx.Exec(query, sessionID, count)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 // UpdateTicketStatuses updates the status of multiple tickets
 func (r *TicketRepository) UpdateTicketStatuses(tx *sqlx.Tx, tickets []models.Ticket, status string) error {
 	query := `
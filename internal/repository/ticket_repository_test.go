@@ -5,7 +5,9 @@ import (
 
 	models "tickets/internal/models/domain"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -217,7 +219,7 @@ func TestTicketRepository_UpdateTicketStatuses_DataConsistency(t *testing.T) {
 	}
 }
 
-func TestTicketRepository_GetAvailableTicketsBySessionID(t *testing.T) {
+func TestTicketRepository_LockAvailableTicketsBySessionID(t *testing.T) {
 	baseRepo, cleanup := SetupTestDB(t)
 	defer cleanup()
 
@@ -229,8 +231,13 @@ func TestTicketRepository_GetAvailableTicketsBySessionID(t *testing.T) {
 	// Create test tickets for this session
 	createTestTicketsForSession(t, baseRepo, sessionID, 5)
 
-	// Test getting available tickets
-	availableTickets, err := repo.GetAvailableTicketsBySessionID(sessionID, 3)
+	// Test locking available tickets
+	var availableTickets []models.Ticket
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		var err error
+		availableTickets, err = repo.LockAvailableTicketsBySessionID(tx, sessionID, 3)
+		return err
+	})
 	require.NoError(t, err)
 	assert.Len(t, availableTickets, 3)
 
@@ -241,6 +248,109 @@ func TestTicketRepository_GetAvailableTicketsBySessionID(t *testing.T) {
 	}
 }
 
+func TestTicketRepository_LockAvailableTicketsBySessionID_SkipsLockedTickets(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTicketRepository(baseRepo)
+
+	sessionID := createTestConcertSession(t, baseRepo)
+	createTestTicketsForSession(t, baseRepo, sessionID, 3)
+
+	// The first transaction locks two tickets and keeps them locked
+	first, err := baseRepo.GetDB().Beginx()
+	require.NoError(t, err)
+	defer func() {
+		_ = first.Rollback()
+	}()
+
+	locked, err := repo.LockAvailableTicketsBySessionID(first, sessionID, 2)
+	require.NoError(t, err)
+	require.Len(t, locked, 2)
+
+	// A concurrent transaction only sees the remaining ticket and does not block
+	second, err := baseRepo.GetDB().Beginx()
+	require.NoError(t, err)
+	defer func() {
+		_ = second.Rollback()
+	}()
+
+	remaining, err := repo.LockAvailableTicketsBySessionID(second, sessionID, 2)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	for _, ticket := range locked {
+		assert.NotEqual(t, ticket.ID, remaining[0].ID)
+	}
+
+	// Once the first transaction releases its locks the tickets become available again
+	require.NoError(t, first.Rollback())
+
+	third, err := baseRepo.GetDB().Beginx()
+	require.NoError(t, err)
+	defer func() {
+		_ = third.Rollback()
+	}()
+
+	released, err := repo.LockAvailableTicketsBySessionID(third, sessionID, 3)
+	require.NoError(t, err)
+	assert.Len(t, released, 2)
+}
+
+func TestTicketRepository_GetAvailableTicketsBySessionID(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTicketRepository(baseRepo)
+
+	sessionID := createTestConcertSession(t, baseRepo)
+	tickets := createTestTicketsForSession(t, baseRepo, sessionID, 4)
+	_, err := baseRepo.db.Exec(`UPDATE tickets SET status = 'sold' WHERE id = $1`, tickets[0].ID)
+	require.NoError(t, err)
+
+	count, err := repo.CountAvailableTicketsBySessionID(sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	available, err := repo.GetAvailableTicketsBySessionID(sessionID, 2)
+	require.NoError(t, err)
+	require.Len(t, available, 2)
+	for _, ticket := range available {
+		assert.Equal(t, "available", ticket.Status)
+		assert.NotEqual(t, tickets[0].ID, ticket.ID)
+	}
+
+	// Browsing neither blocks on nor takes row locks
+	tx, err := baseRepo.GetDB().Beginx()
+	require.NoError(t, err)
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	locked, err := repo.LockAvailableTicketsBySessionID(tx, sessionID, 1)
+	require.NoError(t, err)
+	require.Len(t, locked, 1)
+
+	available, err = repo.GetAvailableTicketsBySessionID(sessionID, 10)
+	require.NoError(t, err)
+	assert.Len(t, available, 3)
+	require.NoError(t, tx.Rollback())
+
+	buyer, err := baseRepo.GetDB().Beginx()
+	require.NoError(t, err)
+	defer func() {
+		_ = buyer.Rollback()
+	}()
+
+	locked, err = repo.LockAvailableTicketsBySessionID(buyer, sessionID, 10)
+	require.NoError(t, err)
+	assert.Len(t, locked, 3)
+
+	// Sessions without tickets return an empty list
+	empty, err := repo.GetAvailableTicketsBySessionID(createTestConcertSession(t, baseRepo), 10)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
 // Helper functions for creating test data
 
 func createTestTickets(t *testing.T, baseRepo *BaseRepository, count int) []models.Ticket {
@@ -288,3 +398,193 @@ func createTestTicketsForSession(t *testing.T, baseRepo *BaseRepository, session
 
 	return tickets
 }
+
+func TestTicketRepository_CreateAndDeleteAvailableTickets(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTicketRepository(baseRepo)
+	orderRepo := NewOrderRepository(baseRepo)
+	sessionID := createTestConcertSession(t, baseRepo)
+
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		return repo.CreateTickets(tx, sessionID, 5)
+	})
+	require.NoError(t, err)
+
+	tickets, err := repo.GetAvailableTicketsBySessionID(sessionID, 10)
+	require.NoError(t, err)
+	require.Len(t, tickets, 5)
+
+	// One ticket is sold and one was part of an expired order; neither may be deleted
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.UpdateTicketStatuses(tx, tickets[:1], "sold"); err != nil {
+			return err
+		}
+		order := &models.Order{Status: "expired", TotalPrice: decimal.NewFromInt(50)}
+		if err := orderRepo.CreateOrder(tx, order); err != nil {
+			return err
+		}
+		return orderRepo.CreateOrderItems(tx, []models.OrderItem{
+			{OrderID: order.ID, TicketID: tickets[1].ID, Price: decimal.NewFromInt(50)},
+		})
+	})
+	require.NoError(t, err)
+
+	var deleted int64
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		var err error
+		deleted, err = repo.DeleteAvailableTickets(tx, sessionID, 5)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		count, err := repo.CountTicketsBySessionID(tx, sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestTicketRepository_SeatedTickets(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTicketRepository(baseRepo)
+	sessionID := createTestConcertSession(t, baseRepo)
+	otherSessionID := createTestConcertSession(t, baseRepo)
+
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.CreateSeatedTickets(tx, sessionID, models.SectionLayout{Name: "Stalls", Rows: 2, SeatsPerRow: 3}, 0); err != nil {
+			return err
+		}
+		return repo.CreateSeatedTickets(tx, sessionID, models.SectionLayout{Name: "Balcony", Rows: 1, SeatsPerRow: 2}, 1)
+	})
+	require.NoError(t, err)
+
+	// Seats come back in section rank, row and seat order
+	seats, err := repo.GetSeatMap(sessionID, "")
+	require.NoError(t, err)
+	require.Len(t, seats, 8)
+	assert.Equal(t, "Stalls A1", seats[0].SeatLabel())
+	assert.Equal(t, "Stalls B3", seats[5].SeatLabel())
+	assert.Equal(t, "Balcony A1", seats[6].SeatLabel())
+
+	stalls, err := repo.GetSeatMap(sessionID, "Stalls")
+	require.NoError(t, err)
+	assert.Len(t, stalls, 6)
+
+	// General admission tickets are not part of the seat map
+	createTestTicketsForSession(t, baseRepo, otherSessionID, 2)
+	none, err := repo.GetSeatMap(otherSessionID, "")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		seated, err := repo.HasSeats(tx, sessionID)
+		require.NoError(t, err)
+		assert.True(t, seated)
+
+		seated, err = repo.HasSeats(tx, otherSessionID)
+		require.NoError(t, err)
+		assert.False(t, seated)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// A seat cannot be created twice
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		return repo.CreateSeatedTickets(tx, sessionID, models.SectionLayout{Name: "Stalls", Rows: 1, SeatsPerRow: 1}, 0)
+	})
+	assert.Error(t, err)
+}
+
+func TestTicketRepository_LockTicketsByIDs(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTicketRepository(baseRepo)
+	tickets := createTestTickets(t, baseRepo, 3)
+	other := createTestTickets(t, baseRepo, 1)
+
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.UpdateTicketStatuses(tx, tickets[2:], "sold"); err != nil {
+			return err
+		}
+
+		// Tickets of other sessions are left out; sold tickets are returned with their status
+		locked, err := repo.LockTicketsByIDs(tx, tickets[0].SessionID,
+			[]uuid.UUID{tickets[0].ID, tickets[2].ID, other[0].ID})
+		require.NoError(t, err)
+		require.Len(t, locked, 2)
+
+		statuses := map[uuid.UUID]string{}
+		for _, ticket := range locked {
+			statuses[ticket.ID] = ticket.Status
+		}
+		assert.Equal(t, map[uuid.UUID]string{tickets[0].ID: "available", tickets[2].ID: "sold"}, statuses)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestTicketRepository_SeatBlocks(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTicketRepository(baseRepo)
+	sessionID := createTestConcertSession(t, baseRepo)
+
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.CreateSeatedTickets(tx, sessionID, models.SectionLayout{Name: "Balcony", Rows: 1, SeatsPerRow: 4}, 1); err != nil {
+			return err
+		}
+		return repo.CreateSeatedTickets(tx, sessionID, models.SectionLayout{Name: "Stalls", Rows: 2, SeatsPerRow: 4}, 0)
+	})
+	require.NoError(t, err)
+
+	// Sell Stalls A2 and B3, leaving runs A1, A3-A4, B1-B2 and B4
+	seats, err := repo.GetSeatMap(sessionID, "Stalls")
+	require.NoError(t, err)
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		return repo.UpdateTicketStatuses(tx, []models.Ticket{seats[1], seats[6]}, "sold")
+	})
+	require.NoError(t, err)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		blocks, err := repo.FindSeatBlocks(tx, sessionID, 2, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []models.SeatBlock{
+			{Section: "Stalls", Row: "A", FirstSeat: 3, Length: 2},
+			{Section: "Stalls", Row: "B", FirstSeat: 1, Length: 2},
+			{Section: "Balcony", Row: "A", FirstSeat: 1, Length: 4},
+		}, blocks)
+
+		blocks, err = repo.FindSeatBlocks(tx, sessionID, 3, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []models.SeatBlock{{Section: "Balcony", Row: "A", FirstSeat: 1, Length: 4}}, blocks)
+
+		locked, err := repo.LockSeatBlock(tx, sessionID, "Stalls", "B", 1, 2)
+		require.NoError(t, err)
+		require.Len(t, locked, 2)
+		assert.Equal(t, "Stalls B1", locked[0].SeatLabel())
+		assert.Equal(t, "Stalls B2", locked[1].SeatLabel())
+
+		// A block overlapping a sold seat locks only its available seats
+		locked, err = repo.LockSeatBlock(tx, sessionID, "Stalls", "A", 1, 2)
+		require.NoError(t, err)
+		assert.Len(t, locked, 1)
+
+		best, err := repo.LockBestAvailableSeats(tx, sessionID, 3)
+		require.NoError(t, err)
+		require.Len(t, best, 3)
+		assert.Equal(t, "Stalls A1", best[0].SeatLabel())
+		assert.Equal(t, "Stalls A3", best[1].SeatLabel())
+		assert.Equal(t, "Stalls A4", best[2].SeatLabel())
+		return nil
+	})
+	require.NoError(t, err)
+}
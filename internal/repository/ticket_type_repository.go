@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"database/sql"
+	"tickets/internal/models/db"
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// TicketTypeRepository handles ticket type-related database operations
+type TicketTypeRepository struct {
+	*BaseRepository
+}
+
+// NewTicketTypeRepository creates a new ticket type repository
+func NewTicketTypeRepository(base *BaseRepository) *TicketTypeRepository {
+	return &TicketTypeRepository{BaseRepository: base}
+}
+
+// ticketTypeColumns selects a ticket type with the number of its tickets held by pending and
+// paid orders and not refunded, which count against its quota
+const ticketTypeColumns = `tt.id, tt.session_id, tt.name, COALESCE(tt.description, '') AS description, tt.price, tt.quota,
+	(SELECT COUNT(*) FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE oi.ticket_type_id = tt.id AND o.status IN ('pending', 'paid') AND oi.refund_id IS NULL) AS allocated`
+
+// CreateTicketType inserts a ticket type, filling in its ID
+func (r *TicketTypeRepository) CreateTicketType(tx *sqlx.Tx, ticketType *models.TicketType) error {
+	query := `
+	INSERT INTO ticket_types (session_id, name, description, price, quota)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`
+
+	return tx.QueryRow(query, ticketType.SessionID, ticketType.Name, ticketType.Description,
+		ticketType.Price, ticketType.Quota).Scan(&ticketType.ID)
+}
+
+// GetTicketTypeByID retrieves a ticket type with what is left of its quota
+func (r *TicketTypeRepository) GetTicketTypeByID(id int) (*models.TicketType, error) {
+	query := `SELECT ` + ticketTypeColumns + ` FROM ticket_types tt WHERE tt.id = $1`
+
+	var dbTicketType db.TicketType
+	err := r.db.Get(&dbTicketType, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbTicketType.ToTicketType(), nil
+}
+
+// GetTicketTypeForUpdate locks a ticket type within tx, returning nil if it does not exist
+func (r *TicketTypeRepository) GetTicketTypeForUpdate(tx *sqlx.Tx, id int) (*models.TicketType, error) {
+	// The allocation count is read after the lock is granted, so it includes orders committed
+	// while waiting for it
+	var locked int
+	err := tx.Get(&locked, `SELECT id FROM ticket_types WHERE id = $1 FOR UPDATE`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var dbTicketType db.TicketType
+	err = tx.Get(&dbTicketType, `SELECT `+ticketTypeColumns+` FROM ticket_types tt WHERE tt.id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dbTicketType.ToTicketType(), nil
+}
+
+// LockTicketTypes locks the given ticket types of a session within tx, in id order so
+// concurrent orders for several types cannot deadlock. Types of other sessions are not returned.
+func (r *TicketTypeRepository) LockTicketTypes(tx *sqlx.Tx, sessionID int, ids []int) ([]models.TicketType, error) {
+	lockQuery := `
+	SELECT id
+	FROM ticket_types
+	WHERE session_id = $1 AND id = ANY($2)
+	ORDER BY id ASC
+	FOR UPDATE`
+
+	var locked []int
+	err := tx.Select(&locked, lockQuery, sessionID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	// As in GetTicketTypeForUpdate, allocations are counted once the locks are held
+	query := `SELECT ` + ticketTypeColumns + ` FROM ticket_types tt WHERE tt.id = ANY($1) ORDER BY tt.id ASC`
+
+	var dbTicketTypes []db.TicketType
+	err = tx.Select(&dbTicketTypes, query, pq.Array(locked))
+	if err != nil {
+		return nil, err
+	}
+
+	ticketTypes := make([]models.TicketType, len(dbTicketTypes))
+	for i := range dbTicketTypes {
+		ticketTypes[i] = *dbTicketTypes[i].ToTicketType()
+	}
+
+	return ticketTypes, nil
+}
+
+// ListTicketTypesBySessionID retrieves a session's ticket types, cheapest first, with what is
+// left of their quotas
+func (r *TicketTypeRepository) ListTicketTypesBySessionID(sessionID int) ([]models.TicketType, error) {
+	query := `
+	SELECT ` + ticketTypeColumns + `
+	FROM ticket_types tt
+	WHERE tt.session_id = $1
+	ORDER BY tt.price ASC, tt.id ASC`
+
+	var dbTicketTypes []db.TicketType
+	err := r.db.Select(&dbTicketTypes, query, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketTypes := make([]models.TicketType, len(dbTicketTypes))
+	for i := range dbTicketTypes {
+		ticketTypes[i] = *dbTicketTypes[i].ToTicketType()
+	}
+
+	return ticketTypes, nil
+}
+
+// HasTicketTypes reports whether a session sells its tickets by ticket type
+func (r *TicketTypeRepository) HasTicketTypes(tx *sqlx.Tx, sessionID int) (bool, error) {
+	var exists bool
+	err := tx.Get(&exists, `SELECT EXISTS (SELECT 1 FROM ticket_types WHERE session_id = $1)`, sessionID)
+	return exists, err
+}
+
+// UpdateTicketType updates a ticket type's name, description, price and quota
+func (r *TicketTypeRepository) UpdateTicketType(tx *sqlx.Tx, ticketType *models.TicketType) error {
+	query := `
+	UPDATE ticket_types
+	SET name = $1, description = $2, price = $3, quota = $4
+	WHERE id = $5`
+
+	_, err := tx.Exec(query, ticketType.Name, ticketType.Description, ticketType.Price, ticketType.Quota, ticketType.ID)
+	return err
+}
+
+// DeleteTicketType deletes a ticket type
+func (r *TicketTypeRepository) DeleteTicketType(tx *sqlx.Tx, id int) error {
+	_, err := tx.Exec(`DELETE FROM ticket_types WHERE id = $1`, id)
+	return err
+}
+
+// NameTaken reports whether another ticket type of the session, other than excludeID, has name
+// in any letter case
+func (r *TicketTypeRepository) NameTaken(tx *sqlx.Tx, sessionID int, name string, excludeID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM ticket_types WHERE session_id = $1 AND LOWER(name) = LOWER($2) AND id <> $3)`
+
+	var exists bool
+	err := tx.Get(&exists, query, sessionID, name, excludeID)
+	return exists, err
+}
+
+// HasOrders reports whether any order, in any status, includes a ticket of the type
+func (r *TicketTypeRepository) HasOrders(tx *sqlx.Tx, id int) (bool, error) {
+	var exists bool
+	err := tx.Get(&exists, `SELECT EXISTS (SELECT 1 FROM order_items WHERE ticket_type_id = $1)`, id)
+	return exists, err
+}
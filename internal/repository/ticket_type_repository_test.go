@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"testing"
+
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTicketTypeRepository(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTicketTypeRepository(baseRepo)
+	assert.NotNil(t, repo)
+	assert.Equal(t, baseRepo, repo.BaseRepository)
+}
+
+func TestTicketTypeRepository_CRUD(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTicketTypeRepository(baseRepo)
+	sessionID := createTestConcertSession(t, baseRepo)
+
+	vip := &models.TicketType{SessionID: sessionID, Name: "VIP", Description: "Front rows", Price: decimal.NewFromInt(120), Quota: 10}
+	student := &models.TicketType{SessionID: sessionID, Name: "Student", Price: decimal.NewFromInt(30), Quota: 50}
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.CreateTicketType(tx, vip); err != nil {
+			return err
+		}
+		return repo.CreateTicketType(tx, student)
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, vip.ID)
+
+	found, err := repo.GetTicketTypeByID(vip.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "VIP", found.Name)
+	assert.Equal(t, "Front rows", found.Description)
+	assert.True(t, decimal.NewFromInt(120).Equal(found.Price))
+	assert.Equal(t, 10, found.Remaining)
+
+	// Listed cheapest first
+	ticketTypes, err := repo.ListTicketTypesBySessionID(sessionID)
+	require.NoError(t, err)
+	require.Len(t, ticketTypes, 2)
+	assert.Equal(t, student.ID, ticketTypes[0].ID)
+	assert.Equal(t, vip.ID, ticketTypes[1].ID)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		hasTypes, err := repo.HasTicketTypes(tx, sessionID)
+		require.NoError(t, err)
+		assert.True(t, hasTypes)
+
+		taken, err := repo.NameTaken(tx, sessionID, "vip", 0)
+		require.NoError(t, err)
+		assert.True(t, taken)
+		taken, err = repo.NameTaken(tx, sessionID, "VIP", vip.ID)
+		require.NoError(t, err)
+		assert.False(t, taken)
+
+		vip.Name = "VIP Lounge"
+		vip.Quota = 20
+		if err := repo.UpdateTicketType(tx, vip); err != nil {
+			return err
+		}
+		return repo.DeleteTicketType(tx, student.ID)
+	})
+	require.NoError(t, err)
+
+	found, err = repo.GetTicketTypeByID(vip.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "VIP Lounge", found.Name)
+	assert.Equal(t, 20, found.Quota)
+
+	found, err = repo.GetTicketTypeByID(student.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestTicketTypeRepository_Allocated(t *testing.T) {
+	baseRepo, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTicketTypeRepository(baseRepo)
+	orderRepo := NewOrderRepository(baseRepo)
+	sessionID := createTestConcertSession(t, baseRepo)
+	tickets := createTestTicketsForSession(t, baseRepo, sessionID, 3)
+
+	ticketType := &models.TicketType{SessionID: sessionID, Name: "GA", Price: decimal.NewFromInt(40), Quota: 5}
+	var orders [2]*models.Order
+	err := baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		if err := repo.CreateTicketType(tx, ticketType); err != nil {
+			return err
+		}
+		// A pending order holding two tickets and an expired order that no longer counts
+		for i, status := range []string{"pending", "expired"} {
+			orders[i] = &models.Order{Status: status, TotalPrice: decimal.NewFromInt(40)}
+			if err := orderRepo.CreateOrder(tx, orders[i]); err != nil {
+				return err
+			}
+		}
+		return orderRepo.CreateOrderItems(tx, []models.OrderItem{
+			{OrderID: orders[0].ID, TicketID: tickets[0].ID, Price: ticketType.Price, TicketTypeID: ticketType.ID},
+			{OrderID: orders[0].ID, TicketID: tickets[1].ID, Price: ticketType.Price, TicketTypeID: ticketType.ID},
+			{OrderID: orders[1].ID, TicketID: tickets[2].ID, Price: ticketType.Price, TicketTypeID: ticketType.ID},
+		})
+	})
+	require.NoError(t, err)
+
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		locked, err := repo.LockTicketTypes(tx, sessionID, []int{ticketType.ID})
+		require.NoError(t, err)
+		require.Len(t, locked, 1)
+		assert.Equal(t, 3, locked[0].Remaining)
+
+		hasOrders, err := repo.HasOrders(tx, ticketType.ID)
+		require.NoError(t, err)
+		assert.True(t, hasOrders)
+
+		// Ticket types of other sessions are not locked
+		locked, err = repo.LockTicketTypes(tx, sessionID+1, []int{ticketType.ID})
+		require.NoError(t, err)
+		assert.Empty(t, locked)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Order items carry their ticket type
+	items, err := orderRepo.GetOrderItemsByOrderID(orders[0].ID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, ticketType.ID, items[0].TicketTypeID)
+	require.NotNil(t, items[0].Ticket)
+	assert.Equal(t, "GA", items[0].Ticket.TicketType)
+}
@@ -0,0 +1,792 @@
+package service
+
+import (
+	"strings"
+	models "tickets/internal/models/domain"
+	"tickets/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// MaxNameLength is the longest concert name, location or venue accepted
+	MaxNameLength = 255
+	// MaxSessionSeats caps the tickets generated for a single session
+	MaxSessionSeats = 100000
+	// MaxSectionNameLength matches the tickets.section column
+	MaxSectionNameLength = 50
+	// MaxSectionRows keeps row labels to at most two letters (A to ZZ)
+	MaxSectionRows = 702
+	// MaxSeatsPerRow caps the seats in a single row
+	MaxSeatsPerRow = 1000
+	// MaxTicketTypeNameLength matches the ticket_types.name column
+	MaxTicketTypeNameLength = 100
+	// MaxPromoCodeLength matches the promo_codes.code column
+	MaxPromoCodeLength = 50
+)
+
+// AdminService handles creating and managing concerts and their sessions, and reporting on
+// the ledger
+type AdminService struct {
+	concertRepo        *repository.ConcertRepository
+	concertSessionRepo *repository.ConcertSessionRepository
+	ticketRepo         *repository.TicketRepository
+	ticketTypeRepo     *repository.TicketTypeRepository
+	promoCodeRepo      *repository.PromoCodeRepository
+	ledgerRepo         *repository.LedgerRepository
+}
+
+// NewAdminService creates a new admin service
+func NewAdminService(base *BaseService) *AdminService {
+	baseRepo := base.GetBaseRepository()
+	return &AdminService{
+		concertRepo:        repository.NewConcertRepository(baseRepo),
+		concertSessionRepo: repository.NewConcertSessionRepository(baseRepo),
+		ticketRepo:         repository.NewTicketRepository(baseRepo),
+		ticketTypeRepo:     repository.NewTicketTypeRepository(baseRepo),
+		promoCodeRepo:      repository.NewPromoCodeRepository(baseRepo),
+		ledgerRepo:         repository.NewLedgerRepository(baseRepo),
+	}
+}
+
+// CreateConcertRequest represents the request structure for creating a concert
+type CreateConcertRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Location    string `json:"location" binding:"required"`
+	Description string `json:"description"`
+}
+
+// UpdateConcertRequest represents the request structure for updating a concert
+type UpdateConcertRequest struct {
+	ConcertID   int    `json:"concert_id" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Location    string `json:"location" binding:"required"`
+	Description string `json:"description"`
+}
+
+// CreateConcertSessionRequest represents the request structure for scheduling a concert session.
+// Sections, when set, make the session reserved seating with one ticket per seat;
+// NumberOfSeats may then be omitted. Otherwise NumberOfSeats general admission tickets are made.
+type CreateConcertSessionRequest struct {
+	ConcertID     int                    `json:"concert_id" binding:"required"`
+	StartTime     int64                  `json:"start_time" binding:"required"`
+	EndTime       int64                  `json:"end_time" binding:"required"`
+	Venue         string                 `json:"venue" binding:"required"`
+	NumberOfSeats int                    `json:"number_of_seats"`
+	Price         decimal.Decimal        `json:"price" binding:"required"`
+	Sections      []models.SectionLayout `json:"sections,omitempty"`
+}
+
+// UpdateConcertSessionRequest represents the request structure for updating a concert session
+type UpdateConcertSessionRequest struct {
+	SessionID int             `json:"session_id" binding:"required"`
+	StartTime int64           `json:"start_time" binding:"required"`
+	EndTime   int64           `json:"end_time" binding:"required"`
+	Venue     string          `json:"venue" binding:"required"`
+	Price     decimal.Decimal `json:"price" binding:"required"`
+}
+
+// UpdateSessionCapacityRequest represents the request structure for changing a session's capacity
+type UpdateSessionCapacityRequest struct {
+	SessionID     int `json:"session_id" binding:"required"`
+	NumberOfSeats int `json:"number_of_seats" binding:"required"`
+}
+
+// CreateConcert creates a concert
+func (s *AdminService) CreateConcert(req *CreateConcertRequest) (*models.Concert, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	concert, err := newConcert(req.Name, req.Location, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.concertRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		return s.concertRepo.CreateConcert(tx, concert)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return concert, nil
+}
+
+// UpdateConcert replaces a concert's name, location and description
+func (s *AdminService) UpdateConcert(req *UpdateConcertRequest) (*models.Concert, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.ConcertID <= 0 {
+		return nil, ErrInvalidConcertID
+	}
+	concert, err := newConcert(req.Name, req.Location, req.Description)
+	if err != nil {
+		return nil, err
+	}
+	concert.ID = req.ConcertID
+
+	err = s.concertRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		existing, err := s.concertRepo.GetConcertForUpdate(tx, concert.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrConcertNotFound
+		}
+		concert.CreatedAt = existing.CreatedAt
+
+		return s.concertRepo.UpdateConcert(tx, concert)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return concert, nil
+}
+
+// DeleteConcert deletes a concert. Concerts with sessions cannot be deleted; delete the
+// sessions first.
+func (s *AdminService) DeleteConcert(concertID int) error {
+	if concertID <= 0 {
+		return ErrInvalidConcertID
+	}
+
+	return s.concertRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		concert, err := s.concertRepo.GetConcertForUpdate(tx, concertID)
+		if err != nil {
+			return err
+		}
+		if concert == nil {
+			return ErrConcertNotFound
+		}
+
+		sessions, err := s.concertRepo.CountSessionsByConcertID(tx, concertID)
+		if err != nil {
+			return err
+		}
+		if sessions > 0 {
+			return ErrConcertHasSessions
+		}
+
+		return s.concertRepo.DeleteConcert(tx, concertID)
+	})
+}
+
+// CreateConcertSession schedules a session for a concert and creates one available ticket per
+// seat in the same transaction
+func (s *AdminService) CreateConcertSession(req *CreateConcertSessionRequest) (*models.ConcertSession, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.ConcertID <= 0 {
+		return nil, ErrInvalidConcertID
+	}
+	session, err := newConcertSession(req.StartTime, req.EndTime, req.Venue, req.Price)
+	if err != nil {
+		return nil, err
+	}
+	numberOfSeats, err := sessionCapacity(req)
+	if err != nil {
+		return nil, err
+	}
+	session.ConcertID = req.ConcertID
+	session.NumberOfSeats = numberOfSeats
+
+	err = s.concertSessionRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		// Lock the concert so it cannot be deleted while the session is added
+		concert, err := s.concertRepo.GetConcertForUpdate(tx, session.ConcertID)
+		if err != nil {
+			return err
+		}
+		if concert == nil {
+			return ErrConcertNotFound
+		}
+		session.Concert = concert
+
+		if err := s.concertSessionRepo.CreateConcertSession(tx, session); err != nil {
+			return err
+		}
+
+		if len(req.Sections) == 0 {
+			return s.ticketRepo.CreateTickets(tx, session.ID, session.NumberOfSeats)
+		}
+		// Sections are listed best first
+		for i, section := range req.Sections {
+			section.Name = strings.TrimSpace(section.Name)
+			if err := s.ticketRepo.CreateSeatedTickets(tx, session.ID, section, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session.RemainingSeats = session.NumberOfSeats
+	return session, nil
+}
+
+// UpdateConcertSession replaces a session's schedule, venue and price. Existing orders keep
+// the price they were placed at.
+func (s *AdminService) UpdateConcertSession(req *UpdateConcertSessionRequest) (*models.ConcertSession, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.SessionID <= 0 {
+		return nil, ErrInvalidSessionID
+	}
+	session, err := newConcertSession(req.StartTime, req.EndTime, req.Venue, req.Price)
+	if err != nil {
+		return nil, err
+	}
+	session.ID = req.SessionID
+
+	err = s.concertSessionRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		existing, err := s.concertSessionRepo.GetConcertSessionForUpdate(tx, session.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrConcertSessionNotFound
+		}
+
+		return s.concertSessionRepo.UpdateConcertSession(tx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.concertSessionRepo.GetConcertSessionDetailsByID(session.ID)
+}
+
+// UpdateSessionCapacity sets a session's number of seats and brings its tickets in line: new
+// available tickets are added when capacity grows, and only available tickets that no order
+// has referenced are removed when it shrinks. Shrinking below the tickets that are sold,
+// pending or part of an order history fails and leaves the session unchanged.
+func (s *AdminService) UpdateSessionCapacity(req *UpdateSessionCapacityRequest) (*models.ConcertSession, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.SessionID <= 0 {
+		return nil, ErrInvalidSessionID
+	}
+	if req.NumberOfSeats <= 0 || req.NumberOfSeats > MaxSessionSeats {
+		return nil, ErrInvalidNumberOfSeats
+	}
+
+	err := s.concertSessionRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		// Locking the session serialises capacity changes for it
+		session, err := s.concertSessionRepo.GetConcertSessionForUpdate(tx, req.SessionID)
+		if err != nil {
+			return err
+		}
+		if session == nil {
+			return ErrConcertSessionNotFound
+		}
+
+		seated, err := s.ticketRepo.HasSeats(tx, req.SessionID)
+		if err != nil {
+			return err
+		}
+		if seated {
+			return ErrReservedSeatingCapacity
+		}
+
+		// Compare against the tickets that exist rather than number_of_seats, which may have drifted
+		tickets, err := s.ticketRepo.CountTicketsBySessionID(tx, req.SessionID)
+		if err != nil {
+			return err
+		}
+
+		switch delta := req.NumberOfSeats - tickets; {
+		case delta > 0:
+			if err := s.ticketRepo.CreateTickets(tx, req.SessionID, delta); err != nil {
+				return err
+			}
+		case delta < 0:
+			deleted, err := s.ticketRepo.DeleteAvailableTickets(tx, req.SessionID, -delta)
+			if err != nil {
+				return err
+			}
+			if deleted < int64(-delta) {
+				return ErrCapacityBelowCommitted
+			}
+		}
+
+		return s.concertSessionRepo.UpdateNumberOfSeats(tx, req.SessionID, req.NumberOfSeats)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.concertSessionRepo.GetConcertSessionDetailsByID(req.SessionID)
+}
+
+// DeleteConcertSession deletes a session and its tickets. Sessions with orders cannot be
+// deleted, so order history is never lost.
+func (s *AdminService) DeleteConcertSession(sessionID int) error {
+	if sessionID <= 0 {
+		return ErrInvalidSessionID
+	}
+
+	return s.concertSessionRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		session, err := s.concertSessionRepo.GetConcertSessionForUpdate(tx, sessionID)
+		if err != nil {
+			return err
+		}
+		if session == nil {
+			return ErrConcertSessionNotFound
+		}
+
+		hasOrders, err := s.concertSessionRepo.HasOrders(tx, sessionID)
+		if err != nil {
+			return err
+		}
+		if hasOrders {
+			return ErrConcertSessionHasOrders
+		}
+
+		return s.concertSessionRepo.DeleteConcertSession(tx, sessionID)
+	})
+}
+
+// CreateTicketTypeRequest represents the request structure for adding a ticket type to a session
+type CreateTicketTypeRequest struct {
+	SessionID   int             `json:"session_id" binding:"required"`
+	Name        string          `json:"name" binding:"required"`
+	Description string          `json:"description"`
+	Price       decimal.Decimal `json:"price" binding:"required"`
+	Quota       int             `json:"quota" binding:"required"`
+}
+
+// UpdateTicketTypeRequest represents the request structure for updating a ticket type
+type UpdateTicketTypeRequest struct {
+	TicketTypeID int             `json:"ticket_type_id" binding:"required"`
+	Name         string          `json:"name" binding:"required"`
+	Description  string          `json:"description"`
+	Price        decimal.Decimal `json:"price" binding:"required"`
+	Quota        int             `json:"quota" binding:"required"`
+}
+
+// CreateTicketType adds a priced ticket type to a session. Once a session has ticket types,
+// its orders must choose among them.
+func (s *AdminService) CreateTicketType(req *CreateTicketTypeRequest) (*models.TicketType, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.SessionID <= 0 {
+		return nil, ErrInvalidSessionID
+	}
+	ticketType, err := newTicketType(req.Name, req.Description, req.Price, req.Quota)
+	if err != nil {
+		return nil, err
+	}
+	ticketType.SessionID = req.SessionID
+
+	err = s.ticketTypeRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		// Locking the session serialises ticket type changes for it, keeping names unique
+		session, err := s.concertSessionRepo.GetConcertSessionForUpdate(tx, ticketType.SessionID)
+		if err != nil {
+			return err
+		}
+		if session == nil {
+			return ErrConcertSessionNotFound
+		}
+
+		taken, err := s.ticketTypeRepo.NameTaken(tx, ticketType.SessionID, ticketType.Name, 0)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrTicketTypeNameTaken
+		}
+
+		return s.ticketTypeRepo.CreateTicketType(tx, ticketType)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ticketType.Remaining = ticketType.Quota
+	return ticketType, nil
+}
+
+// UpdateTicketType replaces a ticket type's name, description, price and quota. Existing orders
+// keep the price they were placed at; the quota cannot drop below the tickets already held.
+func (s *AdminService) UpdateTicketType(req *UpdateTicketTypeRequest) (*models.TicketType, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.TicketTypeID <= 0 {
+		return nil, ErrInvalidTicketTypeID
+	}
+	ticketType, err := newTicketType(req.Name, req.Description, req.Price, req.Quota)
+	if err != nil {
+		return nil, err
+	}
+	ticketType.ID = req.TicketTypeID
+
+	current, err := s.ticketTypeRepo.GetTicketTypeByID(ticketType.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, ErrTicketTypeNotFound
+	}
+	ticketType.SessionID = current.SessionID
+
+	err = s.ticketTypeRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		// Lock the session before the type, in the same order as CreateTicketType
+		if _, err := s.concertSessionRepo.GetConcertSessionForUpdate(tx, ticketType.SessionID); err != nil {
+			return err
+		}
+		existing, err := s.ticketTypeRepo.GetTicketTypeForUpdate(tx, ticketType.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrTicketTypeNotFound
+		}
+
+		allocated := existing.Quota - existing.Remaining
+		if ticketType.Quota < allocated {
+			return ErrQuotaBelowAllocated
+		}
+		ticketType.Remaining = ticketType.Quota - allocated
+
+		taken, err := s.ticketTypeRepo.NameTaken(tx, ticketType.SessionID, ticketType.Name, ticketType.ID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrTicketTypeNameTaken
+		}
+
+		return s.ticketTypeRepo.UpdateTicketType(tx, ticketType)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ticketType, nil
+}
+
+// DeleteTicketType deletes a ticket type that no order has used
+func (s *AdminService) DeleteTicketType(ticketTypeID int) error {
+	if ticketTypeID <= 0 {
+		return ErrInvalidTicketTypeID
+	}
+
+	return s.ticketTypeRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		ticketType, err := s.ticketTypeRepo.GetTicketTypeForUpdate(tx, ticketTypeID)
+		if err != nil {
+			return err
+		}
+		if ticketType == nil {
+			return ErrTicketTypeNotFound
+		}
+
+		hasOrders, err := s.ticketTypeRepo.HasOrders(tx, ticketTypeID)
+		if err != nil {
+			return err
+		}
+		if hasOrders {
+			return ErrTicketTypeHasOrders
+		}
+
+		return s.ticketTypeRepo.DeleteTicketType(tx, ticketTypeID)
+	})
+}
+
+// CreatePromoCodeRequest represents the request structure for creating a promo code. Zero-valued
+// limits and scopes do not apply; ValidUntil is exclusive.
+type CreatePromoCodeRequest struct {
+	Code                  string          `json:"code" binding:"required"`
+	DiscountType          string          `json:"discount_type" binding:"required"`
+	DiscountValue         decimal.Decimal `json:"discount_value" binding:"required"`
+	ValidFrom             int64           `json:"valid_from"`
+	ValidUntil            int64           `json:"valid_until"`
+	MaxRedemptions        int             `json:"max_redemptions"`
+	MaxRedemptionsPerUser int             `json:"max_redemptions_per_user"`
+	ConcertID             int             `json:"concert_id"`
+	SessionID             int             `json:"session_id"`
+}
+
+// CreatePromoCode creates a promo code. Codes are stored upper case and matched case-insensitively.
+func (s *AdminService) CreatePromoCode(req *CreatePromoCodeRequest) (*models.PromoCode, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	promoCode, err := newPromoCode(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.promoCodeRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		// Lock the scope so it cannot be deleted before the code is stored
+		if promoCode.ConcertID > 0 {
+			concert, err := s.concertRepo.GetConcertForUpdate(tx, promoCode.ConcertID)
+			if err != nil {
+				return err
+			}
+			if concert == nil {
+				return ErrConcertNotFound
+			}
+		}
+		if promoCode.SessionID > 0 {
+			session, err := s.concertSessionRepo.GetConcertSessionForUpdate(tx, promoCode.SessionID)
+			if err != nil {
+				return err
+			}
+			if session == nil {
+				return ErrConcertSessionNotFound
+			}
+		}
+
+		created, err := s.promoCodeRepo.CreatePromoCode(tx, promoCode)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return ErrPromoCodeTaken
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return promoCode, nil
+}
+
+// GetPromoCode retrieves a promo code with the number of pending and paid orders that redeemed it
+func (s *AdminService) GetPromoCode(code string) (*models.PromoCode, error) {
+	code, err := normalizePromoCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	promoCode, err := s.promoCodeRepo.GetPromoCodeByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if promoCode == nil {
+		return nil, ErrPromoCodeNotFound
+	}
+
+	return promoCode, nil
+}
+
+// DeletePromoCode deletes a promo code that no order has redeemed. Limit a redeemed code's
+// validity window instead.
+func (s *AdminService) DeletePromoCode(promoCodeID int) error {
+	if promoCodeID <= 0 {
+		return ErrInvalidPromoCodeID
+	}
+
+	return s.promoCodeRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		promoCode, err := s.promoCodeRepo.GetPromoCodeForUpdate(tx, promoCodeID)
+		if err != nil {
+			return err
+		}
+		if promoCode == nil {
+			return ErrPromoCodeNotFound
+		}
+
+		hasOrders, err := s.promoCodeRepo.HasOrders(tx, promoCodeID)
+		if err != nil {
+			return err
+		}
+		if hasOrders {
+			return ErrPromoCodeHasOrders
+		}
+
+		return s.promoCodeRepo.DeletePromoCode(tx, promoCodeID)
+	})
+}
+
+// GetLedgerBalances totals the ledger entries of an account, or of every account if account
+// is empty
+func (s *AdminService) GetLedgerBalances(account string) ([]models.AccountBalance, error) {
+	if account == "" {
+		return s.ledgerRepo.GetAccountBalances()
+	}
+	if !models.IsLedgerAccount(account) {
+		return nil, ErrInvalidLedgerAccount
+	}
+
+	balance, err := s.ledgerRepo.GetAccountBalance(account)
+	if err != nil {
+		return nil, err
+	}
+	return []models.AccountBalance{*balance}, nil
+}
+
+// CheckLedger checks that the ledger's debits equal its credits, overall and within every
+// transaction
+func (s *AdminService) CheckLedger() (*models.LedgerCheck, error) {
+	return s.ledgerRepo.CheckBalanced()
+}
+
+// sessionCapacity validates the seating of a new session and returns its number of seats
+func sessionCapacity(req *CreateConcertSessionRequest) (int, error) {
+	if len(req.Sections) == 0 {
+		if req.NumberOfSeats <= 0 || req.NumberOfSeats > MaxSessionSeats {
+			return 0, ErrInvalidNumberOfSeats
+		}
+		return req.NumberOfSeats, nil
+	}
+
+	capacity := 0
+	names := make(map[string]bool, len(req.Sections))
+	for _, section := range req.Sections {
+		name := strings.TrimSpace(section.Name)
+		if name == "" || len(name) > MaxSectionNameLength || names[name] ||
+			section.Rows <= 0 || section.Rows > MaxSectionRows ||
+			section.SeatsPerRow <= 0 || section.SeatsPerRow > MaxSeatsPerRow {
+			return 0, ErrInvalidSeatingLayout
+		}
+		names[name] = true
+		capacity += section.Capacity()
+	}
+
+	if capacity > MaxSessionSeats {
+		return 0, ErrInvalidNumberOfSeats
+	}
+	if req.NumberOfSeats != 0 && req.NumberOfSeats != capacity {
+		return 0, ErrLayoutCapacityMismatch
+	}
+	return capacity, nil
+}
+
+// newConcert validates the editable fields of a concert
+func newConcert(name, location, description string) (*models.Concert, error) {
+	concert := &models.Concert{
+		Name:        strings.TrimSpace(name),
+		Location:    strings.TrimSpace(location),
+		Description: strings.TrimSpace(description),
+	}
+	if concert.Name == "" || len(concert.Name) > MaxNameLength {
+		return nil, ErrInvalidConcertName
+	}
+	if concert.Location == "" || len(concert.Location) > MaxNameLength {
+		return nil, ErrInvalidConcertLocation
+	}
+
+	return concert, nil
+}
+
+// newTicketType validates the editable fields of a ticket type
+func newTicketType(name, description string, price decimal.Decimal, quota int) (*models.TicketType, error) {
+	ticketType := &models.TicketType{
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(description),
+		Price:       price,
+		Quota:       quota,
+	}
+	if ticketType.Name == "" || len(ticketType.Name) > MaxTicketTypeNameLength {
+		return nil, ErrInvalidTicketTypeName
+	}
+	if !ticketType.Price.IsPositive() {
+		return nil, ErrInvalidPrice
+	}
+	if ticketType.Quota <= 0 || ticketType.Quota > MaxSessionSeats {
+		return nil, ErrInvalidQuota
+	}
+
+	return ticketType, nil
+}
+
+// newPromoCode validates a promo code creation request
+func newPromoCode(req *CreatePromoCodeRequest) (*models.PromoCode, error) {
+	code, err := normalizePromoCode(req.Code)
+	if err != nil {
+		// The code is named code here rather than promo_code as on orders
+		invalid := *ErrInvalidPromoCode
+		invalid.Field = "code"
+		return nil, &invalid
+	}
+	promoCode := &models.PromoCode{
+		Code:                  code,
+		DiscountType:          strings.ToLower(strings.TrimSpace(req.DiscountType)),
+		DiscountValue:         req.DiscountValue,
+		ValidFrom:             req.ValidFrom,
+		ValidUntil:            req.ValidUntil,
+		MaxRedemptions:        req.MaxRedemptions,
+		MaxRedemptionsPerUser: req.MaxRedemptionsPerUser,
+		ConcertID:             req.ConcertID,
+		SessionID:             req.SessionID,
+	}
+
+	switch promoCode.DiscountType {
+	case models.DiscountTypePercentage:
+		if promoCode.DiscountValue.GreaterThan(decimal.NewFromInt(100)) {
+			return nil, ErrInvalidDiscountValue
+		}
+	case models.DiscountTypeFixed:
+	default:
+		return nil, ErrInvalidDiscountType
+	}
+	if !promoCode.DiscountValue.IsPositive() {
+		return nil, ErrInvalidDiscountValue
+	}
+	if promoCode.ValidFrom < 0 || promoCode.ValidUntil < 0 ||
+		(promoCode.ValidFrom > 0 && promoCode.ValidUntil > 0 && promoCode.ValidUntil <= promoCode.ValidFrom) {
+		return nil, ErrInvalidValidityWindow
+	}
+	if promoCode.MaxRedemptions < 0 || promoCode.MaxRedemptionsPerUser < 0 {
+		return nil, ErrInvalidRedemptionLimit
+	}
+	if promoCode.ConcertID < 0 {
+		return nil, ErrInvalidConcertID
+	}
+	if promoCode.SessionID < 0 {
+		return nil, ErrInvalidSessionID
+	}
+	if promoCode.ConcertID > 0 && promoCode.SessionID > 0 {
+		return nil, ErrInvalidPromoScope
+	}
+
+	return promoCode, nil
+}
+
+// normalizePromoCode upper-cases a promo code and checks its format
+func normalizePromoCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" || len(code) > MaxPromoCodeLength {
+		return "", ErrInvalidPromoCode
+	}
+	for _, c := range code {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return "", ErrInvalidPromoCode
+		}
+	}
+	return code, nil
+}
+
+// newConcertSession validates the schedule, venue and price of a concert session
+func newConcertSession(startTime, endTime int64, venue string, price decimal.Decimal) (*models.ConcertSession, error) {
+	session := &models.ConcertSession{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Venue:     strings.TrimSpace(venue),
+		Price:     price,
+	}
+	if session.StartTime <= 0 {
+		return nil, ErrInvalidStartTime
+	}
+	if session.EndTime <= session.StartTime {
+		return nil, ErrInvalidEndTime
+	}
+	if session.Venue == "" || len(session.Venue) > MaxNameLength {
+		return nil, ErrInvalidVenue
+	}
+	if !session.Price.IsPositive() {
+		return nil, ErrInvalidPrice
+	}
+
+	return session, nil
+}
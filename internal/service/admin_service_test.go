@@ -0,0 +1,436 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	models "tickets/internal/models/domain"
+	"tickets/internal/repository"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminService_ConcertLifecycle(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	adminService := NewAdminService(NewBaseService(baseRepo))
+
+	concert, err := adminService.CreateConcert(&CreateConcertRequest{
+		Name:     "  Admin Concert ",
+		Location: "Admin City",
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, concert.ID)
+	assert.Equal(t, "Admin Concert", concert.Name)
+
+	updated, err := adminService.UpdateConcert(&UpdateConcertRequest{
+		ConcertID:   concert.ID,
+		Name:        "Admin Concert II",
+		Location:    "Admin City",
+		Description: "Second edition",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Admin Concert II", updated.Name)
+	assert.Equal(t, concert.CreatedAt, updated.CreatedAt)
+
+	session, err := adminService.CreateConcertSession(&CreateConcertSessionRequest{
+		ConcertID:     concert.ID,
+		StartTime:     1767225600000,
+		EndTime:       1767236400000,
+		Venue:         "Admin Hall",
+		NumberOfSeats: 5,
+		Price:         decimal.RequireFromString("42.00"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, session.NumberOfSeats)
+	assert.Equal(t, 5, session.RemainingSeats)
+	require.NotNil(t, session.Concert)
+	assert.Equal(t, "Admin Concert II", session.Concert.Name)
+
+	// One ticket is generated per seat
+	resp, err := NewConcertService(NewBaseService(baseRepo)).GetAvailableTickets(&GetAvailableTicketsRequest{SessionID: session.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 5, resp.TotalAvailable)
+
+	// A concert with sessions cannot be deleted
+	err = adminService.DeleteConcert(concert.ID)
+	assert.ErrorIs(t, err, ErrConcertHasSessions)
+
+	moved, err := adminService.UpdateConcertSession(&UpdateConcertSessionRequest{
+		SessionID: session.ID,
+		StartTime: 1767312000000,
+		EndTime:   1767322800000,
+		Venue:     "Admin Arena",
+		Price:     decimal.RequireFromString("45.00"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Admin Arena", moved.Venue)
+	assert.Equal(t, int64(1767312000000), moved.StartTime)
+	assert.Equal(t, 5, moved.RemainingSeats)
+
+	require.NoError(t, adminService.DeleteConcertSession(session.ID))
+	require.NoError(t, adminService.DeleteConcert(concert.ID))
+
+	err = adminService.DeleteConcert(concert.ID)
+	assert.ErrorIs(t, err, ErrConcertNotFound)
+	err = adminService.DeleteConcertSession(session.ID)
+	assert.ErrorIs(t, err, ErrConcertSessionNotFound)
+}
+
+func TestAdminService_DeleteConcertSession_WithOrders(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	adminService := NewAdminService(baseService)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 2)
+	_, err := orderService.CreateOrder(&CreateOrderRequest{UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 1})
+	require.NoError(t, err)
+
+	err = adminService.DeleteConcertSession(sessionID)
+	assert.ErrorIs(t, err, ErrConcertSessionHasOrders)
+}
+
+func TestAdminService_NotFound(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	adminService := NewAdminService(NewBaseService(baseRepo))
+
+	_, err := adminService.UpdateConcert(&UpdateConcertRequest{ConcertID: 999999, Name: "x", Location: "y"})
+	assert.ErrorIs(t, err, ErrConcertNotFound)
+
+	_, err = adminService.CreateConcertSession(&CreateConcertSessionRequest{
+		ConcertID: 999999, StartTime: 1, EndTime: 2, Venue: "v", NumberOfSeats: 1, Price: decimal.NewFromInt(1),
+	})
+	assert.ErrorIs(t, err, ErrConcertNotFound)
+
+	_, err = adminService.UpdateConcertSession(&UpdateConcertSessionRequest{
+		SessionID: 999999, StartTime: 1, EndTime: 2, Venue: "v", Price: decimal.NewFromInt(1),
+	})
+	assert.ErrorIs(t, err, ErrConcertSessionNotFound)
+}
+
+func TestAdminService_UpdateSessionCapacity(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	adminService := NewAdminService(baseService)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 4)
+
+	// Growing adds available tickets
+	session, err := adminService.UpdateSessionCapacity(&UpdateSessionCapacityRequest{SessionID: sessionID, NumberOfSeats: 6})
+	require.NoError(t, err)
+	assert.Equal(t, 6, session.NumberOfSeats)
+	assert.Equal(t, 6, session.RemainingSeats)
+
+	// Hold three tickets
+	_, err = orderService.CreateOrder(&CreateOrderRequest{UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 3})
+	require.NoError(t, err)
+
+	// Shrinking removes only free tickets
+	session, err = adminService.UpdateSessionCapacity(&UpdateSessionCapacityRequest{SessionID: sessionID, NumberOfSeats: 4})
+	require.NoError(t, err)
+	assert.Equal(t, 4, session.NumberOfSeats)
+	assert.Equal(t, 1, session.RemainingSeats)
+
+	// Held tickets cannot be removed, and a refused change leaves the session untouched
+	_, err = adminService.UpdateSessionCapacity(&UpdateSessionCapacityRequest{SessionID: sessionID, NumberOfSeats: 2})
+	assert.ErrorIs(t, err, ErrCapacityBelowCommitted)
+
+	session, err = NewConcertService(baseService).GetConcertSession(sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, session.NumberOfSeats)
+	assert.Equal(t, 1, session.RemainingSeats)
+
+	// Shrinking to exactly the held tickets succeeds
+	session, err = adminService.UpdateSessionCapacity(&UpdateSessionCapacityRequest{SessionID: sessionID, NumberOfSeats: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 3, session.NumberOfSeats)
+	assert.Equal(t, 0, session.RemainingSeats)
+
+	_, err = adminService.UpdateSessionCapacity(&UpdateSessionCapacityRequest{SessionID: 999999, NumberOfSeats: 3})
+	assert.ErrorIs(t, err, ErrConcertSessionNotFound)
+}
+
+func TestAdminService_CreateConcertSession_ReservedSeating(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	adminService := NewAdminService(NewBaseService(baseRepo))
+
+	concert, err := adminService.CreateConcert(&CreateConcertRequest{Name: "Seated Concert", Location: "Seat City"})
+	require.NoError(t, err)
+
+	session, err := adminService.CreateConcertSession(&CreateConcertSessionRequest{
+		ConcertID: concert.ID,
+		StartTime: 1767225600000,
+		EndTime:   1767236400000,
+		Venue:     "Seated Hall",
+		Price:     decimal.RequireFromString("60.00"),
+		Sections: []models.SectionLayout{
+			{Name: " Stalls ", Rows: 3, SeatsPerRow: 4},
+			{Name: "Balcony", Rows: 1, SeatsPerRow: 5},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 17, session.NumberOfSeats)
+	assert.Equal(t, 17, session.RemainingSeats)
+
+	seatMap, err := NewConcertService(NewBaseService(baseRepo)).GetSeatMap(&GetSeatMapRequest{SessionID: session.ID, Section: "Stalls"})
+	require.NoError(t, err)
+	require.Len(t, seatMap.Seats, 12)
+	assert.Equal(t, "Stalls A1", seatMap.Seats[0].SeatLabel())
+	assert.Equal(t, "Stalls C4", seatMap.Seats[11].SeatLabel())
+
+	// The layout fixes the capacity
+	_, err = adminService.UpdateSessionCapacity(&UpdateSessionCapacityRequest{SessionID: session.ID, NumberOfSeats: 20})
+	assert.ErrorIs(t, err, ErrReservedSeatingCapacity)
+}
+
+func TestAdminService_TicketTypeLifecycle(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	adminService := NewAdminService(baseService)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "50.00", 5)
+	vip, err := adminService.CreateTicketType(&CreateTicketTypeRequest{
+		SessionID: sessionID, Name: " VIP ", Description: "Front rows", Price: decimal.NewFromInt(120), Quota: 2,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, vip.ID)
+	assert.Equal(t, "VIP", vip.Name)
+	assert.Equal(t, 2, vip.Remaining)
+
+	_, err = adminService.CreateTicketType(&CreateTicketTypeRequest{
+		SessionID: sessionID, Name: "vip", Price: decimal.NewFromInt(100), Quota: 1,
+	})
+	assert.ErrorIs(t, err, ErrTicketTypeNameTaken)
+
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID, TicketTypes: []TicketSelection{{TicketTypeID: vip.ID, Quantity: 2}},
+	})
+	require.NoError(t, err)
+
+	// The quota cannot drop below the tickets held by orders
+	_, err = adminService.UpdateTicketType(&UpdateTicketTypeRequest{
+		TicketTypeID: vip.ID, Name: "VIP", Price: decimal.NewFromInt(150), Quota: 1,
+	})
+	assert.ErrorIs(t, err, ErrQuotaBelowAllocated)
+
+	updated, err := adminService.UpdateTicketType(&UpdateTicketTypeRequest{
+		TicketTypeID: vip.ID, Name: "VIP Lounge", Price: decimal.NewFromInt(150), Quota: 3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "VIP Lounge", updated.Name)
+	assert.Equal(t, 1, updated.Remaining)
+
+	assert.ErrorIs(t, adminService.DeleteTicketType(vip.ID), ErrTicketTypeHasOrders)
+
+	unused, err := adminService.CreateTicketType(&CreateTicketTypeRequest{
+		SessionID: sessionID, Name: "Student", Price: decimal.NewFromInt(30), Quota: 10,
+	})
+	require.NoError(t, err)
+	require.NoError(t, adminService.DeleteTicketType(unused.ID))
+	assert.ErrorIs(t, adminService.DeleteTicketType(unused.ID), ErrTicketTypeNotFound)
+
+	_, err = adminService.CreateTicketType(&CreateTicketTypeRequest{
+		SessionID: 999999, Name: "GA", Price: decimal.NewFromInt(1), Quota: 1,
+	})
+	assert.ErrorIs(t, err, ErrConcertSessionNotFound)
+}
+
+func TestAdminService_PromoCodeLifecycle(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	adminService := NewAdminService(baseService)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 3)
+	promoCode, err := adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: " admin-save10 ", DiscountType: "percentage", DiscountValue: decimal.NewFromInt(10), SessionID: sessionID,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, promoCode.ID)
+	assert.Equal(t, "ADMIN-SAVE10", promoCode.Code)
+
+	_, err = adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "Admin-Save10", DiscountType: "fixed", DiscountValue: decimal.NewFromInt(5),
+	})
+	assert.ErrorIs(t, err, ErrPromoCodeTaken)
+
+	_, err = adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "ADMIN-NOSESSION", DiscountType: "fixed", DiscountValue: decimal.NewFromInt(5), SessionID: 999999,
+	})
+	assert.ErrorIs(t, err, ErrConcertSessionNotFound)
+
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 1, PromoCode: "admin-save10",
+	})
+	require.NoError(t, err)
+
+	found, err := adminService.GetPromoCode("admin-save10")
+	require.NoError(t, err)
+	assert.Equal(t, 1, found.Redemptions)
+
+	assert.ErrorIs(t, adminService.DeletePromoCode(promoCode.ID), ErrPromoCodeHasOrders)
+
+	unused, err := adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "ADMIN-UNUSED", DiscountType: "fixed", DiscountValue: decimal.NewFromInt(5),
+	})
+	require.NoError(t, err)
+	require.NoError(t, adminService.DeletePromoCode(unused.ID))
+	_, err = adminService.GetPromoCode("ADMIN-UNUSED")
+	assert.ErrorIs(t, err, ErrPromoCodeNotFound)
+}
+
+func TestAdminService_InvalidRequests(t *testing.T) {
+	// Validation runs before any database access
+	adminService := &AdminService{}
+
+	valid := CreateConcertSessionRequest{
+		ConcertID: 1, StartTime: 1000, EndTime: 2000, Venue: "Hall", NumberOfSeats: 10, Price: decimal.NewFromInt(10),
+	}
+	testCases := []struct {
+		name   string
+		modify func(*CreateConcertSessionRequest)
+		err    error
+	}{
+		{"missing concert", func(r *CreateConcertSessionRequest) { r.ConcertID = 0 }, ErrInvalidConcertID},
+		{"missing start", func(r *CreateConcertSessionRequest) { r.StartTime = 0 }, ErrInvalidStartTime},
+		{"end before start", func(r *CreateConcertSessionRequest) { r.EndTime = 500 }, ErrInvalidEndTime},
+		{"end equals start", func(r *CreateConcertSessionRequest) { r.EndTime = r.StartTime }, ErrInvalidEndTime},
+		{"blank venue", func(r *CreateConcertSessionRequest) { r.Venue = "  " }, ErrInvalidVenue},
+		{"zero price", func(r *CreateConcertSessionRequest) { r.Price = decimal.Zero }, ErrInvalidPrice},
+		{"negative price", func(r *CreateConcertSessionRequest) { r.Price = decimal.NewFromInt(-1) }, ErrInvalidPrice},
+		{"zero seats", func(r *CreateConcertSessionRequest) { r.NumberOfSeats = 0 }, ErrInvalidNumberOfSeats},
+		{"too many seats", func(r *CreateConcertSessionRequest) { r.NumberOfSeats = MaxSessionSeats + 1 }, ErrInvalidNumberOfSeats},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := valid
+			tc.modify(&req)
+			_, err := adminService.CreateConcertSession(&req)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	_, err := adminService.CreateConcertSession(nil)
+	assert.ErrorIs(t, err, ErrNilRequest)
+
+	layoutCases := []struct {
+		name          string
+		numberOfSeats int
+		sections      []models.SectionLayout
+		err           error
+	}{
+		{"blank section name", 0, []models.SectionLayout{{Name: " ", Rows: 1, SeatsPerRow: 1}}, ErrInvalidSeatingLayout},
+		{"duplicate section", 0, []models.SectionLayout{
+			{Name: "Stalls", Rows: 1, SeatsPerRow: 1}, {Name: " Stalls", Rows: 1, SeatsPerRow: 1},
+		}, ErrInvalidSeatingLayout},
+		{"no rows", 0, []models.SectionLayout{{Name: "Stalls", SeatsPerRow: 1}}, ErrInvalidSeatingLayout},
+		{"too many rows", 0, []models.SectionLayout{{Name: "Stalls", Rows: MaxSectionRows + 1, SeatsPerRow: 1}}, ErrInvalidSeatingLayout},
+		{"row too long", 0, []models.SectionLayout{{Name: "Stalls", Rows: 1, SeatsPerRow: MaxSeatsPerRow + 1}}, ErrInvalidSeatingLayout},
+		{"too many seats", 0, []models.SectionLayout{{Name: "Stalls", Rows: 700, SeatsPerRow: 1000}}, ErrInvalidNumberOfSeats},
+		{"capacity mismatch", 5, []models.SectionLayout{{Name: "Stalls", Rows: 2, SeatsPerRow: 3}}, ErrLayoutCapacityMismatch},
+	}
+	for _, tc := range layoutCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := valid
+			req.NumberOfSeats = tc.numberOfSeats
+			req.Sections = tc.sections
+			_, err := adminService.CreateConcertSession(&req)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	_, err = adminService.CreateConcert(&CreateConcertRequest{Name: " ", Location: "City"})
+	assert.ErrorIs(t, err, ErrInvalidConcertName)
+
+	_, err = adminService.CreateConcert(&CreateConcertRequest{Name: "Concert"})
+	assert.ErrorIs(t, err, ErrInvalidConcertLocation)
+
+	_, err = adminService.UpdateConcert(&UpdateConcertRequest{Name: "Concert", Location: "City"})
+	assert.ErrorIs(t, err, ErrInvalidConcertID)
+
+	_, err = adminService.UpdateConcertSession(&UpdateConcertSessionRequest{
+		SessionID: 1, StartTime: 2000, EndTime: 1000, Venue: "Hall", Price: decimal.NewFromInt(1),
+	})
+	assert.ErrorIs(t, err, ErrInvalidEndTime)
+
+	_, err = adminService.UpdateSessionCapacity(&UpdateSessionCapacityRequest{SessionID: 0, NumberOfSeats: 1})
+	assert.ErrorIs(t, err, ErrInvalidSessionID)
+
+	_, err = adminService.UpdateSessionCapacity(&UpdateSessionCapacityRequest{SessionID: 1, NumberOfSeats: 0})
+	assert.ErrorIs(t, err, ErrInvalidNumberOfSeats)
+
+	ticketTypeCases := []struct {
+		name string
+		req  CreateTicketTypeRequest
+		err  error
+	}{
+		{"missing session", CreateTicketTypeRequest{Name: "VIP", Price: decimal.NewFromInt(1), Quota: 1}, ErrInvalidSessionID},
+		{"blank name", CreateTicketTypeRequest{SessionID: 1, Name: " ", Price: decimal.NewFromInt(1), Quota: 1}, ErrInvalidTicketTypeName},
+		{"zero price", CreateTicketTypeRequest{SessionID: 1, Name: "VIP", Quota: 1}, ErrInvalidPrice},
+		{"zero quota", CreateTicketTypeRequest{SessionID: 1, Name: "VIP", Price: decimal.NewFromInt(1)}, ErrInvalidQuota},
+		{"quota too large", CreateTicketTypeRequest{SessionID: 1, Name: "VIP", Price: decimal.NewFromInt(1), Quota: MaxSessionSeats + 1}, ErrInvalidQuota},
+	}
+	for _, tc := range ticketTypeCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := adminService.CreateTicketType(&tc.req)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	_, err = adminService.UpdateTicketType(&UpdateTicketTypeRequest{Name: "VIP", Price: decimal.NewFromInt(1), Quota: 1})
+	assert.ErrorIs(t, err, ErrInvalidTicketTypeID)
+
+	assert.ErrorIs(t, adminService.DeleteTicketType(0), ErrInvalidTicketTypeID)
+
+	validPromo := CreatePromoCodeRequest{Code: "SAVE10", DiscountType: "percentage", DiscountValue: decimal.NewFromInt(10)}
+	promoCases := []struct {
+		name   string
+		modify func(*CreatePromoCodeRequest)
+		err    error
+	}{
+		{"blank code", func(r *CreatePromoCodeRequest) { r.Code = " " }, ErrInvalidPromoCode},
+		{"code with spaces", func(r *CreatePromoCodeRequest) { r.Code = "SAVE 10" }, ErrInvalidPromoCode},
+		{"code too long", func(r *CreatePromoCodeRequest) { r.Code = strings.Repeat("A", MaxPromoCodeLength+1) }, ErrInvalidPromoCode},
+		{"unknown discount type", func(r *CreatePromoCodeRequest) { r.DiscountType = "bogo" }, ErrInvalidDiscountType},
+		{"zero discount", func(r *CreatePromoCodeRequest) { r.DiscountValue = decimal.Zero }, ErrInvalidDiscountValue},
+		{"percentage over 100", func(r *CreatePromoCodeRequest) { r.DiscountValue = decimal.NewFromInt(101) }, ErrInvalidDiscountValue},
+		{"window ends before start", func(r *CreatePromoCodeRequest) { r.ValidFrom, r.ValidUntil = 2000, 1000 }, ErrInvalidValidityWindow},
+		{"negative limit", func(r *CreatePromoCodeRequest) { r.MaxRedemptionsPerUser = -1 }, ErrInvalidRedemptionLimit},
+		{"concert and session", func(r *CreatePromoCodeRequest) { r.ConcertID, r.SessionID = 1, 1 }, ErrInvalidPromoScope},
+	}
+	for _, tc := range promoCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validPromo
+			tc.modify(&req)
+			_, err := adminService.CreatePromoCode(&req)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	_, err = adminService.GetPromoCode("")
+	assert.ErrorIs(t, err, ErrInvalidPromoCode)
+	assert.ErrorIs(t, adminService.DeletePromoCode(0), ErrInvalidPromoCodeID)
+	assert.ErrorIs(t, adminService.DeleteConcert(0), ErrInvalidConcertID)
+	assert.ErrorIs(t, adminService.DeleteConcertSession(-1), ErrInvalidSessionID)
+
+	_, err = adminService.GetLedgerBalances("petty_cash")
+	assert.ErrorIs(t, err, ErrInvalidLedgerAccount)
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"strings"
+	models "tickets/internal/models/domain"
+	"tickets/internal/repository"
+
+	"github.com/shopspring/decimal"
+)
+
+// ConcertService handles concert and concert session-related business logic
+type ConcertService struct {
+	concertSessionRepo *repository.ConcertSessionRepository
+	ticketRepo         *repository.TicketRepository
+	ticketTypeRepo     *repository.TicketTypeRepository
+}
+
+// NewConcertService creates a new concert service
+func NewConcertService(base *BaseService) *ConcertService {
+	baseRepo := base.GetBaseRepository()
+	return &ConcertService{
+		concertSessionRepo: repository.NewConcertSessionRepository(baseRepo),
+		ticketRepo:         repository.NewTicketRepository(baseRepo),
+		ticketTypeRepo:     repository.NewTicketTypeRepository(baseRepo),
+	}
+}
+
+// ListConcertSessionsRequest represents the request structure for listing concert sessions.
+// Zero-valued filters are ignored. OrderBy is "start_time" (default) or "price", optionally
+// followed by "asc" or "desc".
+type ListConcertSessionsRequest struct {
+	Page          int             `json:"page"`
+	PageSize      int             `json:"page_size"`
+	PageToken     string          `json:"page_token"`
+	StartTimeFrom int64           `json:"start_time_from"`
+	StartTimeTo   int64           `json:"start_time_to"`
+	Venue         string          `json:"venue"`
+	Location      string          `json:"location"`
+	MinPrice      decimal.Decimal `json:"min_price"`
+	MaxPrice      decimal.Decimal `json:"max_price"`
+	OnlyAvailable bool            `json:"only_available"`
+	OrderBy       string          `json:"order_by"`
+}
+
+// ListConcertSessionsResponse represents a page of concert sessions. Page is 0 when the
+// request paged by token; NextPageToken is empty on the last page.
+type ListConcertSessionsResponse struct {
+	Sessions      []models.ConcertSession `json:"sessions"`
+	TotalCount    int                     `json:"total_count"`
+	Page          int                     `json:"page"`
+	PageSize      int                     `json:"page_size"`
+	NextPageToken string                  `json:"next_page_token"`
+}
+
+// GetAvailableTicketsRequest represents the request structure for browsing a session's tickets
+type GetAvailableTicketsRequest struct {
+	SessionID int `json:"session_id" binding:"required"`
+	Limit     int `json:"limit"`
+}
+
+// GetAvailableTicketsResponse lists available tickets together with the total available
+type GetAvailableTicketsResponse struct {
+	Tickets        []models.Ticket `json:"tickets"`
+	TotalAvailable int             `json:"total_available"`
+}
+
+// GetSeatMapRequest represents the request structure for a session's seat map
+type GetSeatMapRequest struct {
+	SessionID int    `json:"session_id" binding:"required"`
+	Section   string `json:"section"`
+}
+
+// GetSeatMapResponse lists a session's reserved seats with their status. Seats is empty for
+// general admission sessions.
+type GetSeatMapResponse struct {
+	SessionID int             `json:"session_id"`
+	Seats     []models.Ticket `json:"seats"`
+	Available int             `json:"available"`
+}
+
+// GetConcertSession retrieves a concert session with its concert and remaining seats
+func (s *ConcertService) GetConcertSession(sessionID int) (*models.ConcertSession, error) {
+	if sessionID <= 0 {
+		return nil, ErrInvalidSessionID
+	}
+
+	session, err := s.concertSessionRepo.GetConcertSessionDetailsByID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrConcertSessionNotFound
+	}
+
+	return session, nil
+}
+
+// ListConcertSessions retrieves a page of concert sessions matching the request's filters
+func (s *ConcertService) ListConcertSessions(req *ListConcertSessionsRequest) (*ListConcertSessionsResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+
+	filter, err := sessionFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	paging, err := newPagination(s.concertSessionRepo.PageTokens(), "sessions:"+filter.Fingerprint(),
+		req.Page, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := s.concertSessionRepo.CountConcertSessions(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.concertSessionRepo.ListConcertSessions(filter, paging.query)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextPageToken string
+	if paging.hasMore(len(sessions)) {
+		sessions = sessions[:paging.pageSize]
+		last := &sessions[len(sessions)-1]
+		nextPageToken = paging.nextPageToken(filter.SortKey(last), last.ID)
+	}
+
+	return &ListConcertSessionsResponse{
+		Sessions:      sessions,
+		TotalCount:    totalCount,
+		Page:          paging.page,
+		PageSize:      paging.pageSize,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// sessionFilter validates the filters of a list request and converts them to a repository filter
+func sessionFilter(req *ListConcertSessionsRequest) (repository.ConcertSessionFilter, error) {
+	filter := repository.ConcertSessionFilter{
+		StartTimeFrom: req.StartTimeFrom,
+		StartTimeTo:   req.StartTimeTo,
+		Venue:         strings.TrimSpace(req.Venue),
+		Location:      strings.TrimSpace(req.Location),
+		MinPrice:      req.MinPrice,
+		MaxPrice:      req.MaxPrice,
+		OnlyAvailable: req.OnlyAvailable,
+	}
+
+	if req.StartTimeFrom < 0 || req.StartTimeTo < 0 ||
+		(req.StartTimeFrom > 0 && req.StartTimeTo > 0 && req.StartTimeFrom > req.StartTimeTo) {
+		return filter, ErrInvalidTimeRange
+	}
+	if req.MinPrice.IsNegative() || req.MaxPrice.IsNegative() ||
+		(req.MinPrice.IsPositive() && req.MaxPrice.IsPositive() && req.MinPrice.GreaterThan(req.MaxPrice)) {
+		return filter, ErrInvalidPriceRange
+	}
+
+	// order_by is a field name optionally followed by a direction, e.g. "price desc"
+	parts := strings.Fields(strings.ToLower(req.OrderBy))
+	if len(parts) > 2 {
+		return filter, ErrInvalidOrderBy
+	}
+	if len(parts) > 0 {
+		switch parts[0] {
+		case repository.SortSessionsByStartTime, repository.SortSessionsByPrice:
+			filter.SortBy = parts[0]
+		default:
+			return filter, ErrInvalidOrderBy
+		}
+	}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+		case "desc":
+			filter.Descending = true
+		default:
+			return filter, ErrInvalidOrderBy
+		}
+	}
+
+	return filter, nil
+}
+
+// GetAvailableTickets returns up to req.Limit available tickets for a session and the total
+// number available. Tickets are read without locks, so they may be bought by the time a
+// client orders them.
+func (s *ConcertService) GetAvailableTickets(req *GetAvailableTicketsRequest) (*GetAvailableTicketsResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.SessionID <= 0 {
+		return nil, ErrInvalidSessionID
+	}
+	if req.Limit < 0 {
+		return nil, ErrInvalidLimit
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	session, err := s.concertSessionRepo.GetConcertSessionByID(req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrConcertSessionNotFound
+	}
+
+	totalAvailable, err := s.ticketRepo.CountAvailableTicketsBySessionID(req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.ticketRepo.GetAvailableTicketsBySessionID(req.SessionID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAvailableTicketsResponse{
+		Tickets:        tickets,
+		TotalAvailable: totalAvailable,
+	}, nil
+}
+
+// GetSeatMap returns every reserved seat of a session, or of one section, in seating order with
+// its status. Seats are read without locks; buy them with CreateOrder's SeatIDs.
+func (s *ConcertService) GetSeatMap(req *GetSeatMapRequest) (*GetSeatMapResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.SessionID <= 0 {
+		return nil, ErrInvalidSessionID
+	}
+
+	session, err := s.concertSessionRepo.GetConcertSessionByID(req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrConcertSessionNotFound
+	}
+
+	seats, err := s.ticketRepo.GetSeatMap(req.SessionID, strings.TrimSpace(req.Section))
+	if err != nil {
+		return nil, err
+	}
+
+	available := 0
+	for _, seat := range seats {
+		if seat.Status == models.TicketStatusAvailable {
+			available++
+		}
+	}
+
+	return &GetSeatMapResponse{
+		SessionID: req.SessionID,
+		Seats:     seats,
+		Available: available,
+	}, nil
+}
+
+// ListTicketTypes returns a session's ticket types, cheapest first, with what is left of their
+// quotas. Sessions without ticket types sell every ticket at the session price.
+func (s *ConcertService) ListTicketTypes(sessionID int) ([]models.TicketType, error) {
+	if sessionID <= 0 {
+		return nil, ErrInvalidSessionID
+	}
+
+	session, err := s.concertSessionRepo.GetConcertSessionByID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrConcertSessionNotFound
+	}
+
+	return s.ticketTypeRepo.ListTicketTypesBySessionID(sessionID)
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"tickets/internal/repository"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConcertService(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	concertService := NewConcertService(baseService)
+
+	assert.NotNil(t, concertService)
+	assert.NotNil(t, concertService.concertSessionRepo)
+}
+
+func TestConcertService_GetConcertSession(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	concertService := NewConcertService(baseService)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "45.00", 5)
+	_, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+
+	session, err := concertService.GetConcertSession(sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID, session.ID)
+	assert.Equal(t, 5, session.NumberOfSeats)
+	assert.Equal(t, 3, session.RemainingSeats)
+	require.NotNil(t, session.Concert)
+	assert.Equal(t, "Test Concert", session.Concert.Name)
+
+	session, err = concertService.GetConcertSession(0)
+	assert.Nil(t, session)
+	assert.ErrorIs(t, err, ErrInvalidSessionID)
+
+	session, err = concertService.GetConcertSession(999999)
+	assert.Nil(t, session)
+	assert.ErrorIs(t, err, ErrConcertSessionNotFound)
+}
+
+func TestConcertService_ListConcertSessions(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	concertService := NewConcertService(baseService)
+
+	for i := 0; i < 3; i++ {
+		insertTestSession(t, baseRepo, "20.00", 2)
+	}
+
+	// Defaults apply when paging is omitted
+	resp, err := concertService.ListConcertSessions(&ListConcertSessionsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Page)
+	assert.Equal(t, DefaultPageSize, resp.PageSize)
+	assert.GreaterOrEqual(t, resp.TotalCount, 3)
+
+	resp, err = concertService.ListConcertSessions(&ListConcertSessionsRequest{Page: 2, PageSize: 1})
+	require.NoError(t, err)
+	assert.Len(t, resp.Sessions, 1)
+	assert.Equal(t, 2, resp.Page)
+
+	// Page size is capped
+	resp, err = concertService.ListConcertSessions(&ListConcertSessionsRequest{PageSize: MaxPageSize + 1})
+	require.NoError(t, err)
+	assert.Equal(t, MaxPageSize, resp.PageSize)
+}
+
+func TestConcertService_ListConcertSessions_InvalidRequest(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	concertService := NewConcertService(baseService)
+
+	_, err := concertService.ListConcertSessions(nil)
+	assert.ErrorIs(t, err, ErrNilRequest)
+
+	_, err = concertService.ListConcertSessions(&ListConcertSessionsRequest{Page: -1})
+	assert.ErrorIs(t, err, ErrInvalidPage)
+
+	_, err = concertService.ListConcertSessions(&ListConcertSessionsRequest{PageSize: -1})
+	assert.ErrorIs(t, err, ErrInvalidPageSize)
+
+	_, err = concertService.ListConcertSessions(&ListConcertSessionsRequest{PageToken: "garbage"})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestConcertService_ListConcertSessions_PageToken(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	concertService := NewConcertService(baseService)
+
+	// A venue no other test uses keeps the listing to this test's sessions
+	venue := fmt.Sprintf("Token Hall %d", time.Now().UnixNano())
+	sessionIDs := make(map[int]bool)
+	for i := 0; i < 5; i++ {
+		id := insertTestSession(t, baseRepo, fmt.Sprintf("%d.00", 10+i), 1)
+		_, err := baseRepo.GetDB().Exec("UPDATE concert_sessions SET venue = $1 WHERE id = $2", venue, id)
+		require.NoError(t, err)
+		sessionIDs[id] = true
+	}
+
+	req := &ListConcertSessionsRequest{Venue: venue, PageSize: 2, OrderBy: "price desc"}
+	seen := make(map[int]bool)
+	var prices []string
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "paging did not terminate")
+		resp, err := concertService.ListConcertSessions(req)
+		require.NoError(t, err)
+		assert.Equal(t, 5, resp.TotalCount)
+		for _, session := range resp.Sessions {
+			assert.False(t, seen[session.ID], "session %d returned twice", session.ID)
+			seen[session.ID] = true
+			prices = append(prices, session.Price.StringFixed(2))
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	assert.Equal(t, sessionIDs, seen)
+	assert.Equal(t, []string{"14.00", "13.00", "12.00", "11.00", "10.00"}, prices)
+
+	// A token cannot be replayed with different filters or ordering
+	first, err := concertService.ListConcertSessions(&ListConcertSessionsRequest{Venue: venue, PageSize: 2})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.NextPageToken)
+
+	_, err = concertService.ListConcertSessions(&ListConcertSessionsRequest{
+		Venue: venue, PageSize: 2, OrderBy: "price", PageToken: first.NextPageToken,
+	})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestConcertService_GetAvailableTickets(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	concertService := NewConcertService(baseService)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "30.00", 6)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+
+	resp, err := concertService.GetAvailableTickets(&GetAvailableTicketsRequest{SessionID: sessionID, Limit: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 4, resp.TotalAvailable)
+	require.Len(t, resp.Tickets, 3)
+	for _, ticket := range resp.Tickets {
+		assert.Equal(t, "available", ticket.Status)
+		assert.NotContains(t, created.TicketIDs, ticket.ID.String())
+	}
+
+	// The default limit returns every available ticket here
+	resp, err = concertService.GetAvailableTickets(&GetAvailableTicketsRequest{SessionID: sessionID})
+	require.NoError(t, err)
+	assert.Len(t, resp.Tickets, 4)
+}
+
+func TestConcertService_GetAvailableTickets_InvalidRequest(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	concertService := NewConcertService(baseService)
+
+	_, err := concertService.GetAvailableTickets(nil)
+	assert.ErrorIs(t, err, ErrNilRequest)
+
+	_, err = concertService.GetAvailableTickets(&GetAvailableTicketsRequest{SessionID: 0})
+	assert.ErrorIs(t, err, ErrInvalidSessionID)
+
+	_, err = concertService.GetAvailableTickets(&GetAvailableTicketsRequest{SessionID: 1, Limit: -1})
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+
+	_, err = concertService.GetAvailableTickets(&GetAvailableTicketsRequest{SessionID: 999999})
+	assert.ErrorIs(t, err, ErrConcertSessionNotFound)
+}
+
+func TestConcertService_GetSeatMap(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	concertService := NewConcertService(NewBaseService(baseRepo))
+
+	// General admission sessions have no seat map
+	sessionID := insertTestSession(t, baseRepo, "30.00", 3)
+	resp, err := concertService.GetSeatMap(&GetSeatMapRequest{SessionID: sessionID})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Seats)
+	assert.Equal(t, 0, resp.Available)
+
+	_, err = concertService.GetSeatMap(nil)
+	assert.ErrorIs(t, err, ErrNilRequest)
+
+	_, err = concertService.GetSeatMap(&GetSeatMapRequest{SessionID: 0})
+	assert.ErrorIs(t, err, ErrInvalidSessionID)
+
+	_, err = concertService.GetSeatMap(&GetSeatMapRequest{SessionID: 999999})
+	assert.ErrorIs(t, err, ErrConcertSessionNotFound)
+}
+
+func TestSessionFilter(t *testing.T) {
+	testCases := []struct {
+		orderBy    string
+		sortBy     string
+		descending bool
+	}{
+		{"", "", false},
+		{"start_time", "start_time", false},
+		{"price", "price", false},
+		{"  Price DESC ", "price", true},
+		{"start_time asc", "start_time", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.orderBy, func(t *testing.T) {
+			filter, err := sessionFilter(&ListConcertSessionsRequest{OrderBy: tc.orderBy})
+			require.NoError(t, err)
+			assert.Equal(t, tc.sortBy, filter.SortBy)
+			assert.Equal(t, tc.descending, filter.Descending)
+		})
+	}
+
+	for _, orderBy := range []string{"venue", "price sideways", "price desc extra"} {
+		_, err := sessionFilter(&ListConcertSessionsRequest{OrderBy: orderBy})
+		assert.ErrorIs(t, err, ErrInvalidOrderBy, orderBy)
+	}
+
+	_, err := sessionFilter(&ListConcertSessionsRequest{StartTimeFrom: 2000, StartTimeTo: 1000})
+	assert.ErrorIs(t, err, ErrInvalidTimeRange)
+
+	_, err = sessionFilter(&ListConcertSessionsRequest{MinPrice: decimal.NewFromInt(50), MaxPrice: decimal.NewFromInt(10)})
+	assert.ErrorIs(t, err, ErrInvalidPriceRange)
+
+	_, err = sessionFilter(&ListConcertSessionsRequest{MinPrice: decimal.NewFromInt(-1)})
+	assert.ErrorIs(t, err, ErrInvalidPriceRange)
+
+	filter, err := sessionFilter(&ListConcertSessionsRequest{Venue: " Test Arena ", OnlyAvailable: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Test Arena", filter.Venue)
+	assert.True(t, filter.OnlyAvailable)
+}
+
+func TestConcertService_ListConcertSessions_Filters(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	concertService := NewConcertService(baseService)
+
+	soldOut := insertTestSession(t, baseRepo, "15.00", 0)
+	available := insertTestSession(t, baseRepo, "15.00", 2)
+
+	resp, err := concertService.ListConcertSessions(&ListConcertSessionsRequest{
+		PageSize:      MaxPageSize,
+		MinPrice:      decimal.RequireFromString("15.00"),
+		MaxPrice:      decimal.RequireFromString("15.00"),
+		OnlyAvailable: true,
+	})
+	require.NoError(t, err)
+
+	ids := make([]int, len(resp.Sessions))
+	for i, session := range resp.Sessions {
+		ids[i] = session.ID
+		assert.Positive(t, session.RemainingSeats)
+		assert.True(t, session.Price.Equal(decimal.RequireFromString("15.00")))
+	}
+	assert.Contains(t, ids, available)
+	assert.NotContains(t, ids, soldOut)
+}
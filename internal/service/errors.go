@@ -0,0 +1,271 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	models "tickets/internal/models/domain"
+)
+
+// Error kinds classify service failures independently of the transport.
+// Match them with errors.Is, e.g. errors.Is(err, ErrNotFound).
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrLimitExceeded      = errors.New("limit exceeded")
+	ErrSoldOut            = errors.New("sold out")
+	ErrConflict           = errors.New("conflict")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrUnavailable        = errors.New("unavailable")
+)
+
+// Error is a classified service error. Kind is one of the error kinds above, Reason is a
+// stable UPPER_SNAKE_CASE identifier for clients and Field names the offending request
+// field, if any. Violations itemise failed preconditions, such as each seat that is taken.
+// Use errors.As to inspect it.
+type Error struct {
+	Kind       error
+	Reason     string
+	Field      string
+	Message    string
+	Violations []Violation
+}
+
+// Violation describes one failed precondition of a request. Subject identifies what failed,
+// e.g. a seat's ticket ID.
+type Violation struct {
+	Subject     string
+	Description string
+}
+
+// Error returns the human-readable message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error kind so errors.Is matches it
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// Is reports whether target is a service error with the same reason, so errors built at
+// runtime, such as ErrSeatsUnavailable with its violations, match their sentinel
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Reason == e.Reason
+}
+
+// NewInvalidArgumentError creates an invalid argument error for a request field
+func NewInvalidArgumentError(field, message string) *Error {
+	return &Error{Kind: ErrInvalidArgument, Reason: "INVALID_ARGUMENT", Field: field, Message: message}
+}
+
+// Errors returned by the order, concert and admin services
+var (
+	ErrNilRequest = &Error{Kind: ErrInvalidArgument, Reason: "NIL_REQUEST",
+		Message: "request cannot be nil"}
+	ErrInvalidTicketCount = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_TICKET_COUNT", Field: "number_of_tickets",
+		Message: "number of tickets must be greater than 0"}
+	ErrTicketLimitExceeded = &Error{Kind: ErrLimitExceeded, Reason: "TICKET_LIMIT_EXCEEDED", Field: "number_of_tickets",
+		Message: "maximum 3 tickets allowed per order"}
+	ErrIdempotencyKeyTooLong = &Error{Kind: ErrInvalidArgument, Reason: "IDEMPOTENCY_KEY_TOO_LONG", Field: "idempotency_key",
+		Message: "idempotency key is too long"}
+	ErrIdempotencyKeyReused = &Error{Kind: ErrConflict, Reason: "IDEMPOTENCY_KEY_REUSED", Field: "idempotency_key",
+		Message: "idempotency key already used for a different request"}
+	ErrInvalidSessionID = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_SESSION_ID", Field: "session_id",
+		Message: "session id must be positive"}
+	ErrConcertSessionNotFound = &Error{Kind: ErrNotFound, Reason: "CONCERT_SESSION_NOT_FOUND",
+		Message: "concert session not found"}
+	ErrNoTicketsAvailable = &Error{Kind: ErrSoldOut, Reason: "SOLD_OUT",
+		Message: "no tickets available"}
+	ErrInvalidOrderID = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_ORDER_ID", Field: "order_id",
+		Message: "order id must be positive"}
+	ErrOrderNotFound = &Error{Kind: ErrNotFound, Reason: "ORDER_NOT_FOUND",
+		Message: "order not found"}
+	ErrInvalidUserID = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_USER_ID", Field: "user_id",
+		Message: "user id must be positive"}
+	ErrInvalidPage = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PAGE", Field: "page",
+		Message: "page must not be negative"}
+	ErrInvalidPageSize = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PAGE_SIZE", Field: "page_size",
+		Message: "page size must not be negative"}
+	ErrInvalidPageToken = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PAGE_TOKEN", Field: "page_token",
+		Message: "page token is invalid or does not match the request"}
+	ErrPageWithPageToken = &Error{Kind: ErrInvalidArgument, Reason: "PAGE_WITH_PAGE_TOKEN", Field: "page",
+		Message: "page cannot be combined with page_token"}
+	ErrInvalidLimit = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_LIMIT", Field: "limit",
+		Message: "limit must not be negative"}
+	ErrInvalidTimeRange = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_TIME_RANGE", Field: "start_time_from",
+		Message: "start time range is invalid"}
+	ErrInvalidPriceRange = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PRICE_RANGE", Field: "min_price",
+		Message: "price range is invalid"}
+	ErrInvalidOrderBy = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_ORDER_BY", Field: "order_by",
+		Message: "order_by must be start_time or price, optionally followed by asc or desc"}
+	ErrOrderExpired = &Error{Kind: ErrFailedPrecondition, Reason: "ORDER_EXPIRED",
+		Message: "order has expired"}
+	ErrOrderNotConfirmable = &Error{Kind: ErrFailedPrecondition, Reason: "ORDER_NOT_CONFIRMABLE",
+		Message: "order cannot be confirmed in its current status"}
+	ErrOrderNotCancellable = &Error{Kind: ErrFailedPrecondition, Reason: "ORDER_NOT_CANCELLABLE",
+		Message: "order cannot be cancelled in its current status"}
+	ErrCancellationReasonTooLong = &Error{Kind: ErrInvalidArgument, Reason: "CANCELLATION_REASON_TOO_LONG", Field: "reason",
+		Message: "cancellation reason is too long"}
+	ErrInvalidConcertID = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_CONCERT_ID", Field: "concert_id",
+		Message: "concert id must be positive"}
+	ErrConcertNotFound = &Error{Kind: ErrNotFound, Reason: "CONCERT_NOT_FOUND",
+		Message: "concert not found"}
+	ErrInvalidConcertName = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_CONCERT_NAME", Field: "name",
+		Message: "name is required and must be at most 255 characters"}
+	ErrInvalidConcertLocation = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_CONCERT_LOCATION", Field: "location",
+		Message: "location is required and must be at most 255 characters"}
+	ErrConcertHasSessions = &Error{Kind: ErrFailedPrecondition, Reason: "CONCERT_HAS_SESSIONS",
+		Message: "concert has sessions; delete them first"}
+	ErrInvalidStartTime = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_START_TIME", Field: "start_time",
+		Message: "start time is required"}
+	ErrInvalidEndTime = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_END_TIME", Field: "end_time",
+		Message: "end time must be after start time"}
+	ErrInvalidVenue = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_VENUE", Field: "venue",
+		Message: "venue is required and must be at most 255 characters"}
+	ErrInvalidPrice = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PRICE", Field: "price",
+		Message: "price must be positive"}
+	ErrInvalidNumberOfSeats = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_NUMBER_OF_SEATS", Field: "number_of_seats",
+		Message: "number of seats must be between 1 and 100000"}
+	ErrCapacityBelowCommitted = &Error{Kind: ErrFailedPrecondition, Reason: "CAPACITY_BELOW_COMMITTED_TICKETS", Field: "number_of_seats",
+		Message: "number of seats cannot drop below the tickets that are sold, held or referenced by orders"}
+	ErrSeatCountMismatch = &Error{Kind: ErrInvalidArgument, Reason: "SEAT_COUNT_MISMATCH", Field: "number_of_tickets",
+		Message: "number of tickets must match the number of seats requested"}
+	ErrDuplicateSeat = &Error{Kind: ErrInvalidArgument, Reason: "DUPLICATE_SEAT", Field: "seat_ids",
+		Message: "a seat may only be requested once"}
+	ErrSeatsUnavailable = &Error{Kind: ErrFailedPrecondition, Reason: "SEATS_UNAVAILABLE", Field: "seat_ids",
+		Message: "requested seats are not available"}
+	ErrAdjacentSeatsUnavailable = &Error{Kind: ErrFailedPrecondition, Reason: "ADJACENT_SEATS_UNAVAILABLE",
+		Message: "not enough adjacent seats are available; allow split seating or pick seats"}
+	ErrInvalidSeatingLayout = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_SEATING_LAYOUT", Field: "sections",
+		Message: "each section needs a unique name of at most 50 characters, 1 to 702 rows and 1 to 1000 seats per row"}
+	ErrLayoutCapacityMismatch = &Error{Kind: ErrInvalidArgument, Reason: "LAYOUT_CAPACITY_MISMATCH", Field: "number_of_seats",
+		Message: "number of seats must match the seating layout"}
+	ErrReservedSeatingCapacity = &Error{Kind: ErrFailedPrecondition, Reason: "RESERVED_SEATING_CAPACITY", Field: "number_of_seats",
+		Message: "capacity of a reserved seating session is fixed by its layout"}
+	ErrConcertSessionHasOrders = &Error{Kind: ErrFailedPrecondition, Reason: "CONCERT_SESSION_HAS_ORDERS",
+		Message: "concert session has orders and cannot be deleted"}
+	ErrInvalidTicketTypeID = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_TICKET_TYPE_ID", Field: "ticket_type_id",
+		Message: "ticket type id must be positive"}
+	ErrTicketTypeNotFound = &Error{Kind: ErrNotFound, Reason: "TICKET_TYPE_NOT_FOUND",
+		Message: "ticket type not found"}
+	ErrInvalidTicketTypeName = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_TICKET_TYPE_NAME", Field: "name",
+		Message: "name is required and must be at most 100 characters"}
+	ErrInvalidQuota = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_QUOTA", Field: "quota",
+		Message: "quota must be between 1 and 100000"}
+	ErrTicketTypeNameTaken = &Error{Kind: ErrConflict, Reason: "TICKET_TYPE_NAME_TAKEN", Field: "name",
+		Message: "the session already has a ticket type with this name"}
+	ErrQuotaBelowAllocated = &Error{Kind: ErrFailedPrecondition, Reason: "QUOTA_BELOW_ALLOCATED", Field: "quota",
+		Message: "quota cannot drop below the tickets of the type held by pending and paid orders"}
+	ErrTicketTypeHasOrders = &Error{Kind: ErrFailedPrecondition, Reason: "TICKET_TYPE_HAS_ORDERS",
+		Message: "ticket type has orders and cannot be deleted"}
+	ErrInvalidTicketSelection = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_TICKET_SELECTION", Field: "ticket_types",
+		Message: "each ticket type must be listed once with a positive quantity"}
+	ErrTicketTypeCountMismatch = &Error{Kind: ErrInvalidArgument, Reason: "TICKET_TYPE_COUNT_MISMATCH", Field: "number_of_tickets",
+		Message: "number of tickets must match the quantities of the ticket types requested"}
+	ErrTicketTypeRequired = &Error{Kind: ErrInvalidArgument, Reason: "TICKET_TYPE_REQUIRED", Field: "ticket_types",
+		Message: "this session sells tickets by type; choose ticket types"}
+	ErrTicketTypeSoldOut = &Error{Kind: ErrSoldOut, Reason: "TICKET_TYPE_SOLD_OUT", Field: "ticket_types",
+		Message: "not enough tickets of the requested type are left"}
+	ErrNotEnoughTickets = &Error{Kind: ErrSoldOut, Reason: "NOT_ENOUGH_TICKETS",
+		Message: "not enough tickets are available to fill the order"}
+	ErrInvalidPromoCode = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PROMO_CODE", Field: "promo_code",
+		Message: "promo code must be 1 to 50 letters, digits, hyphens or underscores"}
+	ErrInvalidPromoCodeID = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PROMO_CODE_ID", Field: "promo_code_id",
+		Message: "promo code id must be positive"}
+	ErrPromoCodeNotFound = &Error{Kind: ErrNotFound, Reason: "PROMO_CODE_NOT_FOUND", Field: "promo_code",
+		Message: "promo code not found"}
+	ErrPromoCodeNotActive = &Error{Kind: ErrFailedPrecondition, Reason: "PROMO_CODE_NOT_ACTIVE", Field: "promo_code",
+		Message: "promo code is not valid at this time"}
+	ErrPromoCodeNotApplicable = &Error{Kind: ErrFailedPrecondition, Reason: "PROMO_CODE_NOT_APPLICABLE", Field: "promo_code",
+		Message: "promo code does not apply to this concert session"}
+	ErrPromoCodeFullyRedeemed = &Error{Kind: ErrFailedPrecondition, Reason: "PROMO_CODE_FULLY_REDEEMED", Field: "promo_code",
+		Message: "promo code has reached its redemption limit"}
+	ErrPromoCodeUserLimitReached = &Error{Kind: ErrFailedPrecondition, Reason: "PROMO_CODE_USER_LIMIT_REACHED", Field: "promo_code",
+		Message: "promo code has already been redeemed the maximum number of times by this user"}
+	ErrInvalidDiscountType = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_DISCOUNT_TYPE", Field: "discount_type",
+		Message: "discount type must be percentage or fixed"}
+	ErrInvalidDiscountValue = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_DISCOUNT_VALUE", Field: "discount_value",
+		Message: "discount value must be positive and at most 100 for percentages"}
+	ErrInvalidValidityWindow = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_VALIDITY_WINDOW", Field: "valid_until",
+		Message: "validity window must not be negative and must end after it starts"}
+	ErrInvalidRedemptionLimit = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_REDEMPTION_LIMIT", Field: "max_redemptions",
+		Message: "redemption limits must not be negative"}
+	ErrInvalidPromoScope = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PROMO_SCOPE", Field: "session_id",
+		Message: "promo code may be limited to a concert or a session, not both"}
+	ErrPromoCodeTaken = &Error{Kind: ErrConflict, Reason: "PROMO_CODE_TAKEN", Field: "code",
+		Message: "promo code already exists"}
+	ErrPromoCodeHasOrders = &Error{Kind: ErrFailedPrecondition, Reason: "PROMO_CODE_HAS_ORDERS",
+		Message: "promo code has been redeemed and cannot be deleted"}
+	ErrInvalidPaymentMethod = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PAYMENT_METHOD", Field: "payment_method",
+		Message: "payment method is required and must be at most 255 characters"}
+	ErrOrderNotPayable = &Error{Kind: ErrFailedPrecondition, Reason: "ORDER_NOT_PAYABLE",
+		Message: "order cannot be paid in its current status"}
+	ErrPaymentInProgress = &Error{Kind: ErrConflict, Reason: "PAYMENT_IN_PROGRESS",
+		Message: "a payment for this order is already in progress"}
+	ErrPaymentDeclined = &Error{Kind: ErrFailedPrecondition, Reason: "PAYMENT_DECLINED",
+		Message: "payment was declined"}
+	ErrPaymentUnavailable = &Error{Kind: ErrUnavailable, Reason: "PAYMENT_UNAVAILABLE",
+		Message: "payment provider did not respond, try again"}
+	ErrPaymentsNotConfigured = &Error{Kind: ErrUnavailable, Reason: "PAYMENTS_NOT_CONFIGURED",
+		Message: "payments are not configured"}
+	ErrPaymentNotFound = &Error{Kind: ErrNotFound, Reason: "PAYMENT_NOT_FOUND",
+		Message: "payment not found"}
+	ErrPaymentNotSettleable = &Error{Kind: ErrFailedPrecondition, Reason: "PAYMENT_NOT_SETTLEABLE",
+		Message: "payment has already failed or been refunded"}
+	ErrInvalidPaymentEventID = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PAYMENT_EVENT_ID", Field: "id",
+		Message: "event id is required and must be at most 255 characters"}
+	ErrInvalidPaymentEventType = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PAYMENT_EVENT_TYPE", Field: "type",
+		Message: "event type must be payment.captured or payment.failed"}
+	ErrPaymentEventInProgress = &Error{Kind: ErrConflict, Reason: "PAYMENT_EVENT_IN_PROGRESS",
+		Message: "event is already being handled; retry later"}
+	ErrInvalidPaymentReference = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_PAYMENT_REFERENCE", Field: "payment_reference",
+		Message: "payment reference is required"}
+	ErrInvalidRefundReason = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_REFUND_REASON", Field: "reason",
+		Message: "refund reason is required and must be at most 500 characters"}
+	ErrInvalidRefundedBy = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_REFUNDED_BY", Field: "refunded_by",
+		Message: "refunded_by is required and must be at most 100 characters"}
+	ErrInvalidTicketIDs = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_TICKET_IDS", Field: "ticket_ids",
+		Message: "at least one ticket is required and each may only be listed once"}
+	ErrTicketNotInOrder = &Error{Kind: ErrInvalidArgument, Reason: "TICKET_NOT_IN_ORDER", Field: "ticket_ids",
+		Message: "ticket is not part of the order"}
+	ErrTicketAlreadyRefunded = &Error{Kind: ErrFailedPrecondition, Reason: "TICKET_ALREADY_REFUNDED", Field: "ticket_ids",
+		Message: "ticket has already been refunded or a refund of it is in progress"}
+	ErrOrderNotRefundable = &Error{Kind: ErrFailedPrecondition, Reason: "ORDER_NOT_REFUNDABLE",
+		Message: "only paid orders with tickets left to refund can be refunded"}
+	ErrRefundRejected = &Error{Kind: ErrFailedPrecondition, Reason: "REFUND_REJECTED",
+		Message: "payment provider rejected the refund"}
+	ErrRefundInProgress = &Error{Kind: ErrConflict, Reason: "REFUND_IN_PROGRESS",
+		Message: "a refund of this order is already in progress"}
+	ErrInvalidLedgerAccount = &Error{Kind: ErrInvalidArgument, Reason: "INVALID_LEDGER_ACCOUNT", Field: "account",
+		Message: "account must be one of the ledger accounts"}
+)
+
+// newTicketTypeSoldOutError returns ErrTicketTypeSoldOut naming the type and what is left of it
+func newTicketTypeSoldOutError(ticketType *models.TicketType) *Error {
+	err := *ErrTicketTypeSoldOut
+	err.Message = fmt.Sprintf("%s: %d %s tickets left", ErrTicketTypeSoldOut.Message, ticketType.Remaining, ticketType.Name)
+	return &err
+}
+
+// newPaymentDeclinedError returns ErrPaymentDeclined with the provider's decline code
+func newPaymentDeclinedError(code string) *Error {
+	err := *ErrPaymentDeclined
+	err.Message = fmt.Sprintf("%s (%s)", ErrPaymentDeclined.Message, code)
+	return &err
+}
+
+// newSeatsUnavailableError returns ErrSeatsUnavailable itemising the seats that cannot be sold
+func newSeatsUnavailableError(violations []Violation) *Error {
+	descriptions := make([]string, len(violations))
+	for i, v := range violations {
+		descriptions[i] = v.Description
+	}
+
+	err := *ErrSeatsUnavailable
+	err.Message = fmt.Sprintf("%s: %s", ErrSeatsUnavailable.Message, strings.Join(descriptions, "; "))
+	err.Violations = violations
+	return &err
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError_Is(t *testing.T) {
+	assert.True(t, errors.Is(ErrOrderNotFound, ErrNotFound))
+	assert.True(t, errors.Is(ErrNoTicketsAvailable, ErrSoldOut))
+	assert.True(t, errors.Is(ErrTicketLimitExceeded, ErrLimitExceeded))
+	assert.True(t, errors.Is(ErrIdempotencyKeyReused, ErrConflict))
+	assert.True(t, errors.Is(ErrOrderExpired, ErrFailedPrecondition))
+	assert.False(t, errors.Is(ErrOrderNotFound, ErrInvalidArgument))
+
+	// Wrapping keeps both the specific error and its kind
+	wrapped := fmt.Errorf("loading order: %w", ErrOrderNotFound)
+	assert.True(t, errors.Is(wrapped, ErrOrderNotFound))
+	assert.True(t, errors.Is(wrapped, ErrNotFound))
+	assert.Equal(t, "loading order: order not found", wrapped.Error())
+}
+
+func TestError_As(t *testing.T) {
+	err := fmt.Errorf("validating: %w", NewInvalidArgumentError("user_id", "user_id must be positive"))
+
+	var svcErr *Error
+	require.True(t, errors.As(err, &svcErr))
+	assert.Equal(t, "user_id", svcErr.Field)
+	assert.Equal(t, "INVALID_ARGUMENT", svcErr.Reason)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func TestNewSeatsUnavailableError(t *testing.T) {
+	err := newSeatsUnavailableError([]Violation{
+		{Subject: "a", Description: "seat Stalls A1 is sold"},
+		{Subject: "b", Description: "seat does not exist in this session"},
+	})
+
+	// The itemised error still matches its sentinel and kind
+	assert.True(t, errors.Is(err, ErrSeatsUnavailable))
+	assert.True(t, errors.Is(err, ErrFailedPrecondition))
+	assert.False(t, errors.Is(err, ErrDuplicateSeat))
+	assert.Equal(t, "requested seats are not available: seat Stalls A1 is sold; seat does not exist in this session",
+		err.Error())
+	assert.Len(t, err.Violations, 2)
+	assert.Empty(t, ErrSeatsUnavailable.Violations)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	models "tickets/internal/models/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// postOrderPlaced books a new order's total as owed by its customer: its list price as
+// revenue, less its discount, plus its service fee and the tax collected on behalf of its
+// jurisdiction
+func (s *OrderService) postOrderPlaced(tx *sqlx.Tx, order *models.Order) error {
+	txn := (&models.LedgerTransaction{Kind: models.LedgerOrderPlaced, OrderID: order.ID}).
+		Debit(models.AccountCustomerReceivable, order.TotalPrice).
+		Debit(models.AccountDiscounts, order.Discount).
+		Credit(models.AccountRevenue, order.Subtotal).
+		Credit(models.AccountFees, order.ServiceFee).
+		Credit(models.AccountTaxPayable, order.Tax)
+	return s.post(tx, txn)
+}
+
+// postOrderReleased reverses postOrderPlaced for an order that expired or was cancelled before
+// it was paid
+func (s *OrderService) postOrderReleased(tx *sqlx.Tx, order *models.Order) error {
+	txn := (&models.LedgerTransaction{Kind: models.LedgerOrderReleased, OrderID: order.ID}).
+		Debit(models.AccountRevenue, order.Subtotal).
+		Debit(models.AccountFees, order.ServiceFee).
+		Debit(models.AccountTaxPayable, order.Tax).
+		Credit(models.AccountDiscounts, order.Discount).
+		Credit(models.AccountCustomerReceivable, order.TotalPrice)
+	return s.post(tx, txn)
+}
+
+// postOrderPaid books an order's total as collected by pmt, or outside the service if pmt is
+// nil, settling what the customer owed
+func (s *OrderService) postOrderPaid(tx *sqlx.Tx, order *models.Order, pmt *models.Payment) error {
+	txn := (&models.LedgerTransaction{Kind: models.LedgerOrderPaid, OrderID: order.ID}).
+		Debit(models.AccountCash, order.TotalPrice).
+		Credit(models.AccountCustomerReceivable, order.TotalPrice)
+	if pmt != nil {
+		txn.PaymentID = pmt.ID
+	}
+	return s.post(tx, txn)
+}
+
+// postRefund books a refund as owed to the customer when it is issued, as paid back when the
+// provider pays it, or reverses it when the provider fails it. What an issued refund gives back
+// is split between the order's fees, the tax it collected, and refunds of the ticket prices.
+func (s *OrderService) postRefund(tx *sqlx.Tx, order *models.Order, refund *models.Refund, kind string) error {
+	fee, tax := order.RefundShares(refund.Amount)
+	prices := refund.Amount.Sub(fee).Sub(tax)
+
+	txn := &models.LedgerTransaction{Kind: kind, OrderID: refund.OrderID, PaymentID: refund.PaymentID, RefundID: refund.ID}
+	switch kind {
+	case models.LedgerRefundIssued:
+		txn.Debit(models.AccountRefunds, prices).
+			Debit(models.AccountFees, fee).
+			Debit(models.AccountTaxPayable, tax).
+			Credit(models.AccountRefundsPayable, refund.Amount)
+	case models.LedgerRefundPaid:
+		txn.Debit(models.AccountRefundsPayable, refund.Amount).Credit(models.AccountCash, refund.Amount)
+	case models.LedgerRefundFailed:
+		txn.Debit(models.AccountRefundsPayable, refund.Amount).
+			Credit(models.AccountRefunds, prices).
+			Credit(models.AccountFees, fee).
+			Credit(models.AccountTaxPayable, tax)
+	}
+	return s.post(tx, txn)
+}
+
+// post appends a transaction to the ledger within tx unless it moves no money, as for a free
+// order
+func (s *OrderService) post(tx *sqlx.Tx, txn *models.LedgerTransaction) error {
+	if len(txn.Entries) == 0 {
+		return nil
+	}
+	return s.ledgerRepo.PostTransaction(tx, txn)
+}
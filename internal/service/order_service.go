@@ -1,19 +1,64 @@
 package service
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"tickets/internal/logger"
 	models "tickets/internal/models/domain"
+	"tickets/internal/payment"
+	"tickets/internal/pricing"
 	"tickets/internal/repository"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 )
 
+// DefaultHoldTTL is how long a pending order holds its tickets unless configured otherwise
+const DefaultHoldTTL = 10 * time.Minute
+
+// DefaultIdempotencyTTL is how long idempotency keys are remembered unless configured otherwise
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// DefaultPaymentTimeout bounds each call to the payment provider unless configured otherwise
+const DefaultPaymentTimeout = 30 * time.Second
+
+// MaxPaymentMethodLength bounds the payment method token passed to the payment provider
+const MaxPaymentMethodLength = 255
+
+// MaxIdempotencyKeyLength matches the idempotency_keys.idempotency_key column
+const MaxIdempotencyKeyLength = 255
+
+// expiryBatchSize bounds how many expired orders are released per transaction
+const expiryBatchSize = 500
+
+// seatBlockAttempts bounds how many blocks of adjacent seats CreateOrder tries to lock before
+// falling back to split seating
+const seatBlockAttempts = 10
+
 // OrderService handles order-related business logic
 type OrderService struct {
 	orderRepo          *repository.OrderRepository
 	concertSessionRepo *repository.ConcertSessionRepository
 	ticketRepo         *repository.TicketRepository
+	ticketTypeRepo     *repository.TicketTypeRepository
+	promoCodeRepo      *repository.PromoCodeRepository
+	idempotencyRepo    *repository.IdempotencyRepository
+	paymentRepo        *repository.PaymentRepository
+	refundRepo         *repository.RefundRepository
+	ledgerRepo         *repository.LedgerRepository
+	pricing            *pricing.Rules
+	paymentProvider    payment.Provider
+	holdTTL            time.Duration
+	idempotencyTTL     time.Duration
+	paymentTimeout     time.Duration
 }
 
 // NewOrderService creates a new order service
@@ -23,68 +68,265 @@ func NewOrderService(base *BaseService) *OrderService {
 		orderRepo:          repository.NewOrderRepository(baseRepo),
 		concertSessionRepo: repository.NewConcertSessionRepository(baseRepo),
 		ticketRepo:         repository.NewTicketRepository(baseRepo),
+		ticketTypeRepo:     repository.NewTicketTypeRepository(baseRepo),
+		promoCodeRepo:      repository.NewPromoCodeRepository(baseRepo),
+		idempotencyRepo:    repository.NewIdempotencyRepository(baseRepo),
+		paymentRepo:        repository.NewPaymentRepository(baseRepo),
+		refundRepo:         repository.NewRefundRepository(baseRepo),
+		ledgerRepo:         repository.NewLedgerRepository(baseRepo),
+		pricing:            &pricing.Rules{},
+		holdTTL:            DefaultHoldTTL,
+		idempotencyTTL:     DefaultIdempotencyTTL,
+		paymentTimeout:     DefaultPaymentTimeout,
 	}
 }
 
+// SetHoldTTL sets how long new pending orders hold their tickets
+func (s *OrderService) SetHoldTTL(ttl time.Duration) {
+	s.holdTTL = ttl
+}
+
+// SetIdempotencyTTL sets how long CreateOrder idempotency keys are remembered
+func (s *OrderService) SetIdempotencyTTL(ttl time.Duration) {
+	s.idempotencyTTL = ttl
+}
+
+// SetPricing sets the service fees and tax rates new orders are charged. Without them orders
+// cost the price of their tickets less any discount.
+func (s *OrderService) SetPricing(rules *pricing.Rules) {
+	s.pricing = rules
+}
+
+// SetPaymentProvider sets the provider PayOrder charges and refunds are paid back through.
+// Without one PayOrder fails with ErrPaymentsNotConfigured.
+func (s *OrderService) SetPaymentProvider(provider payment.Provider) {
+	s.paymentProvider = provider
+}
+
+// SetPaymentTimeout sets how long PayOrder and refunds wait for each call to the payment
+// provider
+func (s *OrderService) SetPaymentTimeout(timeout time.Duration) {
+	s.paymentTimeout = timeout
+}
+
 // CreateOrderRequest represents the request structure for creating an order
 type CreateOrderRequest struct {
 	UserID           int `json:"user_id" binding:"required"`
 	ConcertSessionID int `json:"concert_session_id" binding:"required"`
 	NumberOfTickets  int `json:"number_of_tickets" binding:"required"`
+	// SeatIDs, when set, are the tickets of the exact seats to buy, as listed by GetSeatMap.
+	// NumberOfTickets may then be omitted.
+	SeatIDs []uuid.UUID `json:"seat_ids,omitempty"`
+	// TicketTypes, when set, buys a mix of the session's ticket types, each at its own price.
+	// NumberOfTickets may then be omitted. Sessions with ticket types require it.
+	TicketTypes []TicketSelection `json:"ticket_types,omitempty"`
+	// AllowSplitSeating lets best-available allocation in a reserved seating session return
+	// seats that are not next to each other when no block of adjacent seats is free
+	AllowSplitSeating bool `json:"allow_split_seating,omitempty"`
+	// PromoCode, when set, discounts the order; it is matched case-insensitively
+	PromoCode string `json:"promo_code,omitempty"`
+	// IdempotencyKey, when set, makes retries of the same request return the original response
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// TicketSelection is a quantity of tickets of one ticket type
+type TicketSelection struct {
+	TicketTypeID int `json:"ticket_type_id" binding:"required"`
+	Quantity     int `json:"quantity" binding:"required"`
 }
 
-// CreateOrderResponse represents the response structure for creating an order
+// CreateOrderResponse represents the response structure for creating an order. TotalPrice is
+// Subtotal less the Discount of PromoCode, if one was applied, plus the ServiceFee and the Tax
+// charged at TaxRate percent in TaxJurisdiction.
 type CreateOrderResponse struct {
-	OrderID    int             `json:"order_id"`
-	Status     string          `json:"status"`
-	TicketIDs  []string        `json:"ticket_ids"`
-	TotalPrice decimal.Decimal `json:"total_price"`
-	CreatedAt  int64           `json:"created_at"`
+	OrderID         int             `json:"order_id"`
+	Status          string          `json:"status"`
+	TicketIDs       []string        `json:"ticket_ids"`
+	Subtotal        decimal.Decimal `json:"subtotal"`
+	Discount        decimal.Decimal `json:"discount"`
+	PromoCode       string          `json:"promo_code,omitempty"`
+	ServiceFee      decimal.Decimal `json:"service_fee"`
+	Tax             decimal.Decimal `json:"tax"`
+	TaxRate         decimal.Decimal `json:"tax_rate"`
+	TaxJurisdiction string          `json:"tax_jurisdiction,omitempty"`
+	TotalPrice      decimal.Decimal `json:"total_price"`
+	CreatedAt       int64           `json:"created_at"`
+	ExpiresAt       int64           `json:"expires_at"`
+}
+
+// ListOrdersRequest represents the request structure for listing a user's orders
+type ListOrdersRequest struct {
+	UserID    int    `json:"user_id" binding:"required"`
+	Page      int    `json:"page"`
+	PageSize  int    `json:"page_size"`
+	PageToken string `json:"page_token"`
+}
+
+// ListOrdersResponse represents a page of a user's orders. Page is 0 when the request
+// paged by token; NextPageToken is empty on the last page.
+type ListOrdersResponse struct {
+	Orders        []models.Order `json:"orders"`
+	TotalCount    int            `json:"total_count"`
+	Page          int            `json:"page"`
+	PageSize      int            `json:"page_size"`
+	NextPageToken string         `json:"next_page_token"`
 }
 
+const (
+	// DefaultPageSize is used when a list request does not specify a page size
+	DefaultPageSize = 20
+	// MaxPageSize caps the number of records returned by a single list request
+	MaxPageSize = 100
+)
+
 // CreateOrder creates a new order
 func (s *OrderService) CreateOrder(req *CreateOrderRequest) (*CreateOrderResponse, error) {
 	// Validate request is not nil
 	if req == nil {
-		return nil, errors.New("request cannot be nil")
+		return nil, ErrNilRequest
+	}
+
+	// Buyers choosing ticket types or picking seats may omit the number of tickets
+	numberOfTickets := req.NumberOfTickets
+	if len(req.TicketTypes) > 0 {
+		quantity, err := ticketSelectionQuantity(req.TicketTypes)
+		if err != nil {
+			return nil, err
+		}
+		if numberOfTickets == 0 {
+			numberOfTickets = quantity
+		}
+		if numberOfTickets != quantity {
+			return nil, ErrTicketTypeCountMismatch
+		}
+	}
+	if len(req.SeatIDs) > 0 {
+		if numberOfTickets == 0 {
+			numberOfTickets = len(req.SeatIDs)
+		}
+		if numberOfTickets != len(req.SeatIDs) {
+			return nil, ErrSeatCountMismatch
+		}
+		seen := make(map[uuid.UUID]bool, len(req.SeatIDs))
+		for _, id := range req.SeatIDs {
+			if seen[id] {
+				return nil, ErrDuplicateSeat
+			}
+			seen[id] = true
+		}
 	}
 
 	// Validate number of tickets is within valid range
-	if req.NumberOfTickets <= 0 {
-		return nil, errors.New("number of tickets must be greater than 0")
+	if numberOfTickets <= 0 {
+		return nil, ErrInvalidTicketCount
+	}
+	if numberOfTickets > 3 {
+		return nil, ErrTicketLimitExceeded
 	}
-	if req.NumberOfTickets > 3 {
-		return nil, errors.New("maximum 3 tickets allowed per order")
+	if len(req.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return nil, ErrIdempotencyKeyTooLong
+	}
+	var promoCode string
+	if req.PromoCode != "" {
+		var err error
+		if promoCode, err = normalizePromoCode(req.PromoCode); err != nil {
+			return nil, err
+		}
 	}
 
-	var order *models.Order
-	var tickets []models.Ticket
+	var resp *CreateOrderResponse
 
 	// Execute everything in a transaction
 	err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		// Replay the original response for a retried request
+		if req.IdempotencyKey != "" {
+			replay, err := s.claimIdempotencyKey(tx, req)
+			if err != nil {
+				return err
+			}
+			if replay != nil {
+				resp = replay
+				return nil
+			}
+		}
+
 		// Validate concert session exists
 		concertSession, err := s.concertSessionRepo.GetConcertSessionByID(req.ConcertSessionID)
 		if err != nil {
 			return err
 		}
 		if concertSession == nil {
-			return errors.New("concert session not found")
+			return ErrConcertSessionNotFound
 		}
 
-		// Validate tickets are available
-		tickets, err = s.ticketRepo.GetAvailableTicketsBySessionID(req.ConcertSessionID, req.NumberOfTickets)
+		// Lock the chosen ticket types before any tickets, so their quotas cannot be oversold
+		prices, err := s.ticketPrices(tx, concertSession, req.TicketTypes)
 		if err != nil {
 			return err
 		}
-		if len(tickets) == 0 {
-			return errors.New("no tickets available")
+
+		// Check the promo code before holding any tickets
+		var promo *models.PromoCode
+		if promoCode != "" {
+			promo, err = s.redeemPromoCode(tx, req.UserID, concertSession, promoCode)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Lock the chosen seats, or the best available tickets, within the order transaction
+		var tickets []models.Ticket
+		if len(req.SeatIDs) > 0 {
+			tickets, err = s.lockSeats(tx, req.ConcertSessionID, req.SeatIDs)
+			if err != nil {
+				return err
+			}
+		} else {
+			tickets, err = s.lockAvailableTickets(tx, req.ConcertSessionID, numberOfTickets, req.AllowSplitSeating)
+			if err != nil {
+				return err
+			}
+			if len(tickets) == 0 {
+				return ErrNoTicketsAvailable
+			}
+		}
+		// Orders are filled in full or not at all
+		if len(tickets) < numberOfTickets {
+			return ErrNotEnoughTickets
+		}
+
+		// Price each ticket at its type's price, or the session price for untyped orders
+		items := make([]models.OrderItem, len(tickets))
+		subtotal := decimal.Zero
+		for i, ticket := range tickets {
+			items[i] = models.OrderItem{TicketID: ticket.ID, Price: concertSession.Price}
+			if prices != nil {
+				items[i].TicketTypeID = prices[i].TicketTypeID
+				items[i].Price = prices[i].Price
+			}
+			subtotal = subtotal.Add(items[i].Price)
 		}
 
-		// Create order with basic information
-		order = &models.Order{
-			Status:     "pending",
-			TotalPrice: decimal.NewFromInt(int64(len(tickets))).Mul(concertSession.Price),
+		// Create order with basic information, discounted by the promo code if any and charged
+		// the service fee and the tax of the session's venue
+		order := &models.Order{
+			UserID:    req.UserID,
+			Status:    models.OrderStatusPending,
+			Subtotal:  subtotal,
+			Discount:  decimal.Zero,
+			ExpiresAt: time.Now().Add(s.holdTTL).UnixMilli(),
+		}
+		if promo != nil {
+			order.Discount = promo.Discount(subtotal)
+			order.PromoCodeID = promo.ID
+			order.PromoCode = promo.Code
 		}
+		price := s.pricing.Price(concertSession.Venue, len(items), subtotal, order.Discount)
+		order.ServiceFee = price.ServiceFee
+		order.Tax = price.Tax
+		order.TaxRate = price.TaxRate
+		order.TaxJurisdiction = price.Jurisdiction
+		order.TotalPrice = price.Total
 
 		// Create order in database
 		err = s.orderRepo.CreateOrder(tx, order)
@@ -92,28 +334,1362 @@ func (s *OrderService) CreateOrder(req *CreateOrderRequest) (*CreateOrderRespons
 			return err
 		}
 
-		// Update ticket statuses to 'pending'
-		err = s.ticketRepo.UpdateTicketStatuses(tx, tickets, "pending")
+		// Record one order item per reserved ticket
+		for i := range items {
+			items[i].OrderID = order.ID
+		}
+		order.Items = items
+		err = s.orderRepo.CreateOrderItems(tx, order.Items)
+		if err != nil {
+			return err
+		}
+
+		// Hold the tickets for the order
+		err = s.transitionTickets(tx, tickets, models.TicketStatusPending)
+		if err != nil {
+			return err
+		}
+
+		err = s.postOrderPlaced(tx, order)
 		if err != nil {
 			return err
 		}
 
+		ticketIDs := make([]string, len(tickets))
+		for i, ticket := range tickets {
+			ticketIDs[i] = ticket.ID.String()
+		}
+
+		resp = &CreateOrderResponse{
+			OrderID:         order.ID,
+			Status:          order.Status,
+			TicketIDs:       ticketIDs,
+			Subtotal:        order.Subtotal,
+			Discount:        order.Discount,
+			PromoCode:       order.PromoCode,
+			ServiceFee:      order.ServiceFee,
+			Tax:             order.Tax,
+			TaxRate:         order.TaxRate,
+			TaxJurisdiction: order.TaxJurisdiction,
+			TotalPrice:      order.TotalPrice,
+			CreatedAt:       order.CreatedAt,
+			ExpiresAt:       order.ExpiresAt,
+		}
+
+		// Remember the response so retries with the same key replay it
+		if req.IdempotencyKey != "" {
+			payload, err := json.Marshal(resp)
+			if err != nil {
+				return err
+			}
+			return s.idempotencyRepo.SaveIdempotencyResponse(tx, req.UserID, req.IdempotencyKey, payload)
+		}
+
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	ticketIDs := make([]string, len(tickets))
-	for i, ticket := range tickets {
-		ticketIDs[i] = ticket.ID.String()
+	return resp, nil
+}
+
+// ticketSelectionQuantity validates a mix of ticket types and returns the number of tickets in it
+func ticketSelectionQuantity(selections []TicketSelection) (int, error) {
+	quantity := 0
+	seen := make(map[int]bool, len(selections))
+	for _, selection := range selections {
+		if selection.TicketTypeID <= 0 || selection.Quantity <= 0 || seen[selection.TicketTypeID] {
+			return 0, ErrInvalidTicketSelection
+		}
+		seen[selection.TicketTypeID] = true
+		quantity += selection.Quantity
+	}
+	return quantity, nil
+}
+
+// ticketPrice is the ticket type and price of one ticket of an order
+type ticketPrice struct {
+	TicketTypeID int
+	Price        decimal.Decimal
+}
+
+// ticketPrices locks the selected ticket types of a session within tx, checks their quotas and
+// returns the type and price of each ticket to sell, in selection order. It returns nil for
+// orders without ticket types, which sessions that have ticket types refuse.
+func (s *OrderService) ticketPrices(tx *sqlx.Tx, session *models.ConcertSession, selections []TicketSelection) ([]ticketPrice, error) {
+	if len(selections) == 0 {
+		hasTypes, err := s.ticketTypeRepo.HasTicketTypes(tx, session.ID)
+		if err != nil {
+			return nil, err
+		}
+		if hasTypes {
+			return nil, ErrTicketTypeRequired
+		}
+		return nil, nil
+	}
+
+	ids := make([]int, len(selections))
+	for i, selection := range selections {
+		ids[i] = selection.TicketTypeID
+	}
+	locked, err := s.ticketTypeRepo.LockTicketTypes(tx, session.ID, ids)
+	if err != nil {
+		return nil, err
+	}
+	ticketTypes := make(map[int]*models.TicketType, len(locked))
+	for i := range locked {
+		ticketTypes[locked[i].ID] = &locked[i]
+	}
+
+	var prices []ticketPrice
+	for _, selection := range selections {
+		ticketType, ok := ticketTypes[selection.TicketTypeID]
+		if !ok {
+			return nil, ErrTicketTypeNotFound
+		}
+		if ticketType.Remaining < selection.Quantity {
+			return nil, newTicketTypeSoldOutError(ticketType)
+		}
+		for i := 0; i < selection.Quantity; i++ {
+			prices = append(prices, ticketPrice{TicketTypeID: ticketType.ID, Price: ticketType.Price})
+		}
+	}
+
+	return prices, nil
+}
+
+// redeemPromoCode locks a promo code within tx and checks that a user may redeem it for a session
+// now. The lock serialises redemptions of the code, so its limits cannot be exceeded.
+func (s *OrderService) redeemPromoCode(tx *sqlx.Tx, userID int, session *models.ConcertSession, code string) (*models.PromoCode, error) {
+	promo, err := s.promoCodeRepo.LockPromoCodeByCode(tx, code)
+	if err != nil {
+		return nil, err
+	}
+	if promo == nil {
+		return nil, ErrPromoCodeNotFound
+	}
+	if !promo.ActiveAt(time.Now().UnixMilli()) {
+		return nil, ErrPromoCodeNotActive
+	}
+	if !promo.AppliesTo(session) {
+		return nil, ErrPromoCodeNotApplicable
+	}
+	if promo.MaxRedemptions > 0 && promo.Redemptions >= promo.MaxRedemptions {
+		return nil, ErrPromoCodeFullyRedeemed
+	}
+	if promo.MaxRedemptionsPerUser > 0 {
+		redemptions, err := s.promoCodeRepo.CountUserRedemptions(tx, promo.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if redemptions >= promo.MaxRedemptionsPerUser {
+			return nil, ErrPromoCodeUserLimitReached
+		}
+	}
+
+	return promo, nil
+}
+
+// lockAvailableTickets locks up to numberOfTickets available tickets of a session within tx.
+// General admission sessions get any available tickets. Reserved seating sessions get the best
+// block of adjacent seats in one row; if none can be locked, scattered seats are returned only
+// when allowSplit is set. Seats held by concurrent orders are skipped, never waited on, so fewer
+// tickets than requested may be returned.
+func (s *OrderService) lockAvailableTickets(tx *sqlx.Tx, sessionID int, numberOfTickets int, allowSplit bool) ([]models.Ticket, error) {
+	seated, err := s.ticketRepo.HasSeats(tx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !seated {
+		return s.ticketRepo.LockAvailableTicketsBySessionID(tx, sessionID, numberOfTickets)
+	}
+
+	blocks, err := s.ticketRepo.FindSeatBlocks(tx, sessionID, numberOfTickets, seatBlockAttempts)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range blocks {
+		tickets, err := s.ticketRepo.LockSeatBlock(tx, sessionID, block.Section, block.Row, block.FirstSeat, numberOfTickets)
+		if err != nil {
+			return nil, err
+		}
+		if len(tickets) == numberOfTickets {
+			return tickets, nil
+		}
+	}
+
+	// Seats of blocks that were only partly locked stay locked until tx ends and are reused here
+	tickets, err := s.ticketRepo.LockBestAvailableSeats(tx, sessionID, numberOfTickets)
+	if err != nil {
+		return nil, err
+	}
+	if len(tickets) == numberOfTickets && !allowSplit {
+		return nil, ErrAdjacentSeatsUnavailable
+	}
+	return tickets, nil
+}
+
+// lockSeats locks the requested seats of a session within tx. If any seat does not exist in the
+// session or is not available, it returns ErrSeatsUnavailable listing every such seat.
+func (s *OrderService) lockSeats(tx *sqlx.Tx, sessionID int, seatIDs []uuid.UUID) ([]models.Ticket, error) {
+	locked, err := s.ticketRepo.LockTicketsByIDs(tx, sessionID, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]models.Ticket, len(locked))
+	for _, ticket := range locked {
+		byID[ticket.ID] = ticket
+	}
+
+	tickets := make([]models.Ticket, 0, len(seatIDs))
+	var violations []Violation
+	for _, id := range seatIDs {
+		ticket, ok := byID[id]
+		switch {
+		case !ok:
+			violations = append(violations, Violation{Subject: id.String(),
+				Description: "seat does not exist in this session"})
+		case ticket.Status == models.TicketStatusPending:
+			violations = append(violations, Violation{Subject: id.String(),
+				Description: fmt.Sprintf("seat %s is held by another order", ticket.SeatLabel())})
+		case ticket.Status != models.TicketStatusAvailable:
+			violations = append(violations, Violation{Subject: id.String(),
+				Description: fmt.Sprintf("seat %s is %s", ticket.SeatLabel(), ticket.Status)})
+		default:
+			tickets = append(tickets, ticket)
+		}
+	}
+	if len(violations) > 0 {
+		return nil, newSeatsUnavailableError(violations)
+	}
+
+	return tickets, nil
+}
+
+// claimIdempotencyKey reserves the request's idempotency key within tx. It returns the original
+// response if the key was already used for the same request within the retention window.
+func (s *OrderService) claimIdempotencyKey(tx *sqlx.Tx, req *CreateOrderRequest) (*CreateOrderResponse, error) {
+	now := time.Now()
+	key := &models.IdempotencyKey{
+		UserID:      req.UserID,
+		Key:         req.IdempotencyKey,
+		RequestHash: hashCreateOrderRequest(req),
+		CreatedAt:   now.UnixMilli(),
+	}
+
+	claimed, err := s.idempotencyRepo.ClaimIdempotencyKey(tx, key, now.Add(-s.idempotencyTTL).UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	existing, err := s.idempotencyRepo.GetIdempotencyKey(tx, req.UserID, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || existing.RequestHash != key.RequestHash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if len(existing.Response) == 0 {
+		return nil, fmt.Errorf("idempotency key %q has no stored response", req.IdempotencyKey)
+	}
+
+	var resp CreateOrderResponse
+	if err := json.Unmarshal(existing.Response, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// hashCreateOrderRequest fingerprints the parts of a request that an idempotent retry must repeat
+func hashCreateOrderRequest(req *CreateOrderRequest) string {
+	fingerprint := fmt.Sprintf("%d:%d:%d", req.UserID, req.ConcertSessionID, req.NumberOfTickets)
+	for _, id := range req.SeatIDs {
+		fingerprint += ":" + id.String()
+	}
+	for _, selection := range req.TicketTypes {
+		fingerprint += fmt.Sprintf(":type%dx%d", selection.TicketTypeID, selection.Quantity)
+	}
+	if req.AllowSplitSeating {
+		fingerprint += ":split"
+	}
+	if req.PromoCode != "" {
+		fingerprint += ":promo" + strings.ToUpper(strings.TrimSpace(req.PromoCode))
+	}
+	sum := sha256.Sum256([]byte(fingerprint))
+	return hex.EncodeToString(sum[:])
+}
+
+// PruneIdempotencyKeys deletes idempotency keys that are past the retention window at now
+func (s *OrderService) PruneIdempotencyKeys(now time.Time) (int64, error) {
+	return s.idempotencyRepo.DeleteIdempotencyKeysBefore(now.Add(-s.idempotencyTTL).UnixMilli())
+}
+
+// GetOrder retrieves an order together with its items and their tickets
+func (s *OrderService) GetOrder(orderID int) (*models.Order, error) {
+	if orderID <= 0 {
+		return nil, ErrInvalidOrderID
+	}
+
+	order, err := s.orderRepo.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, ErrOrderNotFound
+	}
+
+	order.Items, err = s.orderRepo.GetOrderItemsByOrderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ListOrders retrieves a page of a user's orders, newest first, with their items
+func (s *OrderService) ListOrders(req *ListOrdersRequest) (*ListOrdersResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.UserID <= 0 {
+		return nil, ErrInvalidUserID
+	}
+
+	paging, err := newPagination(s.orderRepo.PageTokens(), fmt.Sprintf("orders:%d", req.UserID),
+		req.Page, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := s.orderRepo.CountOrdersByUserID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.orderRepo.ListOrdersByUserID(req.UserID, paging.query)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextPageToken string
+	if paging.hasMore(len(orders)) {
+		orders = orders[:paging.pageSize]
+		last := orders[len(orders)-1]
+		nextPageToken = paging.nextPageToken(strconv.FormatInt(last.CreatedAt, 10), last.ID)
+	}
+
+	if len(orders) > 0 {
+		orderIDs := make([]int, len(orders))
+		for i, order := range orders {
+			orderIDs[i] = order.ID
+		}
+
+		items, err := s.orderRepo.GetOrderItemsByOrderIDs(orderIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		itemsByOrder := make(map[int][]models.OrderItem, len(orders))
+		for _, item := range items {
+			itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], item)
+		}
+		for i := range orders {
+			orders[i].Items = itemsByOrder[orders[i].ID]
+		}
 	}
 
-	return &CreateOrderResponse{
-		OrderID:    order.ID,
-		Status:     order.Status,
-		TicketIDs:  ticketIDs,
-		TotalPrice: order.TotalPrice,
-		CreatedAt:  order.CreatedAt,
+	return &ListOrdersResponse{
+		Orders:        orders,
+		TotalCount:    totalCount,
+		Page:          paging.page,
+		PageSize:      paging.pageSize,
+		NextPageToken: nextPageToken,
 	}, nil
 }
+
+// ExpirePendingOrders expires pending orders whose hold ended at or before now and returns
+// their tickets to inventory. Each batch is released in its own transaction; the number of
+// expired orders is returned. An order that cannot expire, such as one whose tickets were sold
+// meanwhile, is logged and skipped so it does not hold up the rest.
+func (s *OrderService) ExpirePendingOrders(now time.Time) (int, error) {
+	expired := 0
+	skipped := []int{}
+	for {
+		var orderIDs []int
+		err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+			var err error
+			orderIDs, err = s.orderRepo.LockExpiredPendingOrders(tx, now.UnixMilli(), skipped, expiryBatchSize)
+			if err != nil || len(orderIDs) == 0 {
+				return err
+			}
+
+			orders, err := s.orderRepo.GetOrdersByIDs(tx, orderIDs)
+			if err != nil {
+				return err
+			}
+			var expiring []models.Order
+			var expiringIDs []int
+			var tickets []models.Ticket
+			for _, order := range orders {
+				held, err := expiringTickets(order)
+				if err != nil {
+					logger.WithError(err).WithField("order_id", order.ID).Error("Skipping order that cannot expire")
+					skipped = append(skipped, order.ID)
+					continue
+				}
+				expiring = append(expiring, order)
+				expiringIDs = append(expiringIDs, order.ID)
+				tickets = append(tickets, held...)
+			}
+			if len(expiring) == 0 {
+				return nil
+			}
+
+			if err = s.orderRepo.UpdateOrderStatuses(tx, expiringIDs, models.OrderStatusExpired); err != nil {
+				return err
+			}
+			if err = s.transitionTickets(tx, tickets, models.TicketStatusAvailable); err != nil {
+				return err
+			}
+
+			for i := range expiring {
+				if err = s.postOrderReleased(tx, &expiring[i]); err != nil {
+					return err
+				}
+			}
+			expired += len(expiring)
+			return nil
+		})
+		if err != nil {
+			return expired, err
+		}
+
+		if len(orderIDs) < expiryBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// expiringTickets checks that an order may expire and returns the tickets it holds, which go
+// back on sale. An expiring order holds only pending tickets.
+func expiringTickets(order models.Order) ([]models.Ticket, error) {
+	if err := models.ValidateOrderTransition(order.Status, models.OrderStatusExpired); err != nil {
+		return nil, err
+	}
+	tickets := make([]models.Ticket, 0, len(order.Items))
+	for _, item := range order.Items {
+		if item.Ticket.Status != models.TicketStatusPending {
+			return nil, fmt.Errorf("ticket %s is %s, not held", item.TicketID, item.Ticket.Status)
+		}
+		tickets = append(tickets, *item.Ticket)
+	}
+	return tickets, nil
+}
+
+// ConfirmOrder marks a pending order as paid and sells its tickets in a single transaction.
+// Confirming an order that is already paid returns it unchanged.
+func (s *OrderService) ConfirmOrder(orderID int) (*models.Order, error) {
+	if orderID <= 0 {
+		return nil, ErrInvalidOrderID
+	}
+
+	var order *models.Order
+	err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		var err error
+		order, err = s.orderRepo.GetOrderForUpdate(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+
+		if order.Status == models.OrderStatusPaid {
+			return nil
+		}
+
+		if holdEnded(order, time.Now()) {
+			return ErrOrderExpired
+		}
+		if !models.CanTransitionOrder(order.Status, models.OrderStatusPaid) {
+			return ErrOrderNotConfirmable
+		}
+
+		return s.markOrderPaid(tx, order, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// holdEnded reports whether an order has expired. A hold that ended before the expiry worker
+// ran is still expired.
+func holdEnded(order *models.Order, now time.Time) bool {
+	return order.Status == models.OrderStatusExpired ||
+		(order.Status == models.OrderStatusPending && order.ExpiresAt > 0 && order.ExpiresAt <= now.UnixMilli())
+}
+
+// markOrderPaid sells the tickets of an order locked in tx, marks it paid and books its total
+// as collected by pmt, or outside the service if pmt is nil
+func (s *OrderService) markOrderPaid(tx *sqlx.Tx, order *models.Order, pmt *models.Payment) error {
+	tickets := make([]models.Ticket, len(order.Items))
+	for i, item := range order.Items {
+		tickets[i] = *item.Ticket
+	}
+	if err := s.transitionTickets(tx, tickets, models.TicketStatusSold); err != nil {
+		return err
+	}
+
+	if err := s.orderRepo.UpdateOrderStatuses(tx, []int{order.ID}, models.OrderStatusPaid); err != nil {
+		return err
+	}
+	if err := s.postOrderPaid(tx, order, pmt); err != nil {
+		return err
+	}
+
+	order.Status = models.OrderStatusPaid
+	for i := range order.Items {
+		order.Items[i].Ticket.Status = models.TicketStatusSold
+	}
+	return nil
+}
+
+// PayOrderRequest represents the request structure for paying an order. PaymentMethod is the
+// token the payment provider charges, such as a tokenised card.
+type PayOrderRequest struct {
+	OrderID       int
+	PaymentMethod string
+}
+
+// PayOrderResponse is a paid order and the payment that paid it. Payment is nil for a free
+// order, which is confirmed without charging the provider.
+type PayOrderResponse struct {
+	Order   *models.Order
+	Payment *models.Payment
+}
+
+// paymentDeclinedReason is the cancellation reason of orders whose payment was declined
+const paymentDeclinedReason = "payment declined"
+
+// PayOrder charges a pending order's total with the payment provider and confirms the order
+// once the payment is captured. The attempt is recorded as a pending payment before the
+// provider is called, outside any transaction. A declined payment cancels the order and
+// releases its tickets; a provider that does not answer leaves the order pending so the
+// buyer can try again while the hold lasts. If the hold ends while the payment is being
+// captured, the payment is refunded and ErrOrderExpired is returned.
+func (s *OrderService) PayOrder(ctx context.Context, req *PayOrderRequest) (*PayOrderResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.OrderID <= 0 {
+		return nil, ErrInvalidOrderID
+	}
+	if req.PaymentMethod == "" || len(req.PaymentMethod) > MaxPaymentMethodLength {
+		return nil, ErrInvalidPaymentMethod
+	}
+	if s.paymentProvider == nil {
+		return nil, ErrPaymentsNotConfigured
+	}
+
+	var order *models.Order
+	var pmt *models.Payment
+	err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		var err error
+		order, err = s.orderRepo.GetOrderForUpdate(tx, req.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		if err = checkPayable(order, time.Now()); err != nil {
+			return err
+		}
+
+		// Nothing to charge, e.g. when a promo code covers the whole order
+		if !order.TotalPrice.IsPositive() {
+			return s.markOrderPaid(tx, order, nil)
+		}
+
+		pmt = &models.Payment{
+			OrderID:  order.ID,
+			Provider: s.paymentProvider.Name(),
+			Amount:   order.TotalPrice,
+			Status:   models.PaymentStatusPending,
+		}
+		created, err := s.paymentRepo.CreatePayment(tx, pmt)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return ErrPaymentInProgress
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pmt == nil {
+		return &PayOrderResponse{Order: order}, nil
+	}
+
+	if err = s.collectPayment(ctx, pmt, req.PaymentMethod); err != nil {
+		return nil, s.failPayment(pmt, err)
+	}
+
+	return s.settlePayment(ctx, pmt)
+}
+
+// checkPayable returns an error unless an order is pending and its hold has not ended
+func checkPayable(order *models.Order, now time.Time) error {
+	if holdEnded(order, now) {
+		return ErrOrderExpired
+	}
+	if order.Status != models.OrderStatusPending {
+		return ErrOrderNotPayable
+	}
+	return nil
+}
+
+// collectPayment authorizes and captures a pending payment, recording the authorization
+// before capturing it. An authorization that cannot be captured is voided.
+func (s *OrderService) collectPayment(ctx context.Context, pmt *models.Payment, paymentMethod string) error {
+	authCtx, cancel := context.WithTimeout(ctx, s.paymentTimeout)
+	defer cancel()
+	auth, err := s.paymentProvider.Authorize(authCtx, payment.AuthorizeRequest{
+		OrderID:        pmt.OrderID,
+		Amount:         pmt.Amount,
+		PaymentMethod:  paymentMethod,
+		IdempotencyKey: fmt.Sprintf("payment-%d", pmt.ID),
+	})
+	if err != nil {
+		return err
+	}
+
+	pmt.ProviderReference = auth.Reference
+	pmt.Status = models.PaymentStatusAuthorized
+	err = s.paymentRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		return s.paymentRepo.UpdatePayment(tx, pmt)
+	})
+	if err == nil {
+		captureCtx, cancel := context.WithTimeout(ctx, s.paymentTimeout)
+		defer cancel()
+		_, err = s.paymentProvider.Capture(captureCtx, pmt.ProviderReference, pmt.Amount)
+	}
+	if err != nil {
+		// The void outlives the request so the buyer's funds are not left reserved
+		voidCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.paymentTimeout)
+		defer cancel()
+		if _, voidErr := s.paymentProvider.Void(voidCtx, pmt.ProviderReference); voidErr != nil {
+			return errors.Join(err, fmt.Errorf("voiding payment %d: %w", pmt.ID, voidErr))
+		}
+		pmt.Status = models.PaymentStatusVoided
+	}
+	return err
+}
+
+// failPayment records why a payment failed and returns the error for the caller. A declined
+// payment cancels its order, releasing the held tickets; after a timeout or any other failure
+// the order stays pending. A payment that was settled meanwhile, e.g. by a webhook, is left
+// as it is.
+func (s *OrderService) failPayment(pmt *models.Payment, cause error) error {
+	result := cause
+	declined := errors.Is(cause, payment.ErrDeclined)
+	switch {
+	case declined:
+		pmt.FailureCode, pmt.FailureMessage = "declined", cause.Error()
+		var decline *payment.DeclineError
+		if errors.As(cause, &decline) {
+			pmt.FailureCode, pmt.FailureMessage = decline.Code, decline.Message
+		}
+		result = newPaymentDeclinedError(pmt.FailureCode)
+	case errors.Is(cause, payment.ErrTimeout), errors.Is(cause, context.DeadlineExceeded):
+		pmt.FailureCode, pmt.FailureMessage = "timeout", cause.Error()
+		result = ErrPaymentUnavailable
+	default:
+		pmt.FailureCode, pmt.FailureMessage = "provider_error", cause.Error()
+	}
+	if declined {
+		pmt.Status = models.PaymentStatusDeclined
+	} else if pmt.Status != models.PaymentStatusVoided {
+		pmt.Status = models.PaymentStatusFailed
+	}
+
+	err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		order, current, err := s.lockOrderPayment(tx, pmt)
+		if err != nil || !paymentOpen(current) {
+			return err
+		}
+
+		if err = s.paymentRepo.UpdatePayment(tx, pmt); err != nil {
+			return err
+		}
+		if !declined || order.Status != models.OrderStatusPending {
+			return nil
+		}
+		return s.markOrderCancelled(tx, order, paymentDeclinedReason)
+	})
+	if err != nil {
+		return errors.Join(cause, err)
+	}
+	return result
+}
+
+// settlePayment confirms the order of a captured payment. If the order stopped being payable
+// while the payment was captured, the payment is refunded instead. Settling a payment that is
+// already captured returns its order unchanged.
+func (s *OrderService) settlePayment(ctx context.Context, pmt *models.Payment) (*PayOrderResponse, error) {
+	var order *models.Order
+	var notPayable error
+	err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		var current *models.Payment
+		var err error
+		order, current, err = s.lockOrderPayment(tx, pmt)
+		if err != nil {
+			return err
+		}
+		if current.Status == models.PaymentStatusCaptured {
+			*pmt = *current
+			return nil
+		}
+		if !paymentOpen(current) {
+			return ErrPaymentNotSettleable
+		}
+		if notPayable = checkPayable(order, time.Now()); notPayable != nil {
+			return nil
+		}
+
+		pmt.Status = models.PaymentStatusCaptured
+		if err = s.paymentRepo.UpdatePayment(tx, pmt); err != nil {
+			return err
+		}
+		return s.markOrderPaid(tx, order, pmt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if notPayable == nil {
+		return &PayOrderResponse{Order: order, Payment: pmt}, nil
+	}
+
+	refundCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.paymentTimeout)
+	defer cancel()
+	if _, err = s.paymentProvider.Refund(refundCtx, pmt.ProviderReference, pmt.Amount); err != nil {
+		return nil, errors.Join(notPayable, fmt.Errorf("refunding payment %d: %w", pmt.ID, err))
+	}
+
+	pmt.Status = models.PaymentStatusRefunded
+	err = s.paymentRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		return s.paymentRepo.UpdatePayment(tx, pmt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nil, notPayable
+}
+
+// lockOrderPayment locks a payment's order and then the payment within tx, in the order
+// PayOrder locks them, and returns both as stored
+func (s *OrderService) lockOrderPayment(tx *sqlx.Tx, pmt *models.Payment) (*models.Order, *models.Payment, error) {
+	order, err := s.orderRepo.GetOrderForUpdate(tx, pmt.OrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if order == nil {
+		return nil, nil, ErrOrderNotFound
+	}
+
+	current, err := s.paymentRepo.GetPaymentForUpdate(tx, pmt.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if current == nil {
+		return nil, nil, ErrPaymentNotFound
+	}
+	return order, current, nil
+}
+
+// paymentOpen reports whether a payment still awaits its outcome
+func paymentOpen(pmt *models.Payment) bool {
+	return pmt.Status == models.PaymentStatusPending || pmt.Status == models.PaymentStatusAuthorized
+}
+
+// Payment provider webhook event types
+const (
+	PaymentEventCaptured = "payment.captured"
+	PaymentEventFailed   = "payment.failed"
+)
+
+// MaxPaymentEventIDLength matches the payment_events.event_id column
+const MaxPaymentEventIDLength = 255
+
+// PaymentEventRequest is a payment provider's notification that a payment identified by its
+// provider Reference was captured or failed. EventID is the provider's id for the
+// notification; FailureCode and FailureMessage explain a failure.
+type PaymentEventRequest struct {
+	EventID        string
+	Type           string
+	Reference      string
+	FailureCode    string
+	FailureMessage string
+}
+
+// HandlePaymentEvent applies a payment provider's asynchronous notification through the same
+// logic as PayOrder: a captured payment confirms its order, or is refunded if the order can no
+// longer be paid, and a failed payment is declined, cancelling its order. Payments that are
+// already settled are left as they are. It reports false, without doing anything, if the event
+// was handled before, and fails with ErrPaymentEventInProgress while it is being handled.
+func (s *OrderService) HandlePaymentEvent(ctx context.Context, req *PaymentEventRequest) (bool, error) {
+	if req == nil {
+		return false, ErrNilRequest
+	}
+	if req.EventID == "" || len(req.EventID) > MaxPaymentEventIDLength {
+		return false, ErrInvalidPaymentEventID
+	}
+	if req.Type != PaymentEventCaptured && req.Type != PaymentEventFailed {
+		return false, ErrInvalidPaymentEventType
+	}
+	if req.Reference == "" {
+		return false, ErrInvalidPaymentReference
+	}
+	if s.paymentProvider == nil {
+		return false, ErrPaymentsNotConfigured
+	}
+
+	provider := s.paymentProvider.Name()
+	pmt, err := s.paymentRepo.GetPaymentByReference(provider, req.Reference)
+	if err != nil {
+		return false, err
+	}
+	if pmt == nil {
+		return false, ErrPaymentNotFound
+	}
+
+	var applied bool
+	err = s.paymentRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		locked, err := s.paymentRepo.LockPaymentEvent(tx, provider, req.EventID)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return ErrPaymentEventInProgress
+		}
+		recorded, err := s.paymentRepo.PaymentEventRecorded(tx, provider, req.EventID)
+		if err != nil || recorded {
+			return err
+		}
+
+		// Applied in transactions of its own, since a capture calls the provider, and recorded
+		// only once that succeeds. A redelivery after a crash in between finds the payment
+		// settled and changes nothing.
+		if err = s.applyPaymentEvent(ctx, pmt, req); err != nil {
+			return err
+		}
+		applied, err = s.paymentRepo.RecordPaymentEvent(tx, &models.PaymentEvent{
+			Provider:  provider,
+			EventID:   req.EventID,
+			PaymentID: pmt.ID,
+			Type:      req.Type,
+		})
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+// applyPaymentEvent settles or fails an open payment as a webhook event reports. Outcomes that
+// PayOrder reports to the buyer as errors, such as a decline, are expected here.
+func (s *OrderService) applyPaymentEvent(ctx context.Context, pmt *models.Payment, req *PaymentEventRequest) error {
+	if !paymentOpen(pmt) {
+		return nil
+	}
+
+	if req.Type == PaymentEventCaptured {
+		// The order could no longer be paid and the payment was refunded, or PayOrder failed
+		// the payment meanwhile. Compared directly since a failed refund joins these errors.
+		_, err := s.settlePayment(ctx, pmt)
+		switch err {
+		case ErrOrderExpired, ErrOrderNotPayable, ErrPaymentNotSettleable:
+			return nil
+		}
+		return err
+	}
+
+	code := req.FailureCode
+	if code == "" {
+		code = "declined"
+	}
+	err := s.failPayment(pmt, &payment.DeclineError{Code: code, Message: req.FailureMessage})
+	if errors.Is(err, ErrPaymentDeclined) {
+		return nil
+	}
+	return err
+}
+
+// MaxCancellationReasonLength bounds the free-text reason stored on a cancelled order
+const MaxCancellationReasonLength = 500
+
+// cancellationRefundedBy is recorded as who issued the refund that cancels a paid order
+const cancellationRefundedBy = "cancellation"
+
+// CancelOrder cancels a pending or paid order and returns its tickets to inventory. A pending
+// order is cancelled in a single transaction. A paid order goes through the refund path: what
+// is left of it is refunded as RefundOrder does, and once the provider has paid that back its
+// tickets are released and it is cancelled; if the refund fails the order stays paid.
+// Cancelling an order that is already cancelled returns it unchanged.
+func (s *OrderService) CancelOrder(ctx context.Context, orderID int, reason string) (*models.Order, error) {
+	if orderID <= 0 {
+		return nil, ErrInvalidOrderID
+	}
+	if len(reason) > MaxCancellationReasonLength {
+		return nil, ErrCancellationReasonTooLong
+	}
+
+	var order *models.Order
+	err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		var err error
+		order, err = s.orderRepo.GetOrderForUpdate(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+
+		if order.Status == models.OrderStatusCancelled || order.Status == models.OrderStatusPaid {
+			return nil
+		}
+		if !models.CanTransitionOrder(order.Status, models.OrderStatusCancelled) {
+			return ErrOrderNotCancellable
+		}
+
+		return s.markOrderCancelled(tx, order, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.OrderStatusPaid {
+		return order, nil
+	}
+
+	refundReason := reason
+	if strings.TrimSpace(refundReason) == "" {
+		refundReason = "order cancelled"
+	}
+	resp, err := s.refund(ctx, orderID, nil, refundReason, cancellationRefundedBy, &orderCancellation{reason: reason})
+	if errors.Is(err, ErrOrderNotRefundable) {
+		// The order was refunded or cancelled meanwhile
+		return nil, ErrOrderNotCancellable
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Order, nil
+}
+
+// markOrderCancelled returns the tickets of a pending order locked in tx to inventory and
+// marks it cancelled. Its total is no longer owed.
+func (s *OrderService) markOrderCancelled(tx *sqlx.Tx, order *models.Order, reason string) error {
+	if err := s.postOrderReleased(tx, order); err != nil {
+		return err
+	}
+
+	tickets := make([]models.Ticket, 0, len(order.Items))
+	for _, item := range order.Items {
+		tickets = append(tickets, *item.Ticket)
+	}
+	if err := s.transitionTickets(tx, tickets, models.TicketStatusAvailable); err != nil {
+		return err
+	}
+
+	cancelledAt := time.Now().UnixMilli()
+	if err := s.orderRepo.CancelOrder(tx, order.ID, reason, cancelledAt); err != nil {
+		return err
+	}
+
+	order.Status = models.OrderStatusCancelled
+	order.CancelledAt = cancelledAt
+	order.CancellationReason = reason
+	for i := range order.Items {
+		order.Items[i].Ticket.Status = models.TicketStatusAvailable
+	}
+	return nil
+}
+
+// MaxRefundReasonLength bounds the free-text reason recorded on a refund
+const MaxRefundReasonLength = 500
+
+// MaxRefundedByLength matches the refunds.refunded_by column
+const MaxRefundedByLength = 100
+
+// RefundOrderRequest represents the request structure for refunding every ticket of a paid
+// order that is not refunded yet. RefundedBy identifies who issues the refund, such as a
+// member of staff, for the refund ledger.
+type RefundOrderRequest struct {
+	OrderID    int
+	Reason     string
+	RefundedBy string
+}
+
+// RefundTicketsRequest represents the request structure for refunding some tickets of a paid
+// order
+type RefundTicketsRequest struct {
+	OrderID    int
+	TicketIDs  []uuid.UUID
+	Reason     string
+	RefundedBy string
+}
+
+// RefundResponse is a refund and the order it was issued for
+type RefundResponse struct {
+	Order  *models.Order
+	Refund *models.Refund
+}
+
+// RefundOrder refunds every ticket of a paid order that is not refunded yet. See
+// RefundTickets.
+func (s *OrderService) RefundOrder(ctx context.Context, req *RefundOrderRequest) (*RefundResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	return s.refund(ctx, req.OrderID, nil, req.Reason, req.RefundedBy, nil)
+}
+
+// RefundTickets refunds some tickets of a paid order. Each ticket gives back its price less its
+// share of the order's discount; refunding the last tickets gives back the rest of the total.
+// The refund is recorded as pending before the captured payment, if any, is refunded with the
+// payment provider outside any transaction. Once the provider has paid it back the tickets
+// return to inventory, or are voided if their session has started, and an order with every
+// ticket refunded becomes refunded. A refund the provider fails is recorded as failed and
+// leaves the tickets with the order.
+func (s *OrderService) RefundTickets(ctx context.Context, req *RefundTicketsRequest) (*RefundResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if len(req.TicketIDs) == 0 {
+		return nil, ErrInvalidTicketIDs
+	}
+	seen := make(map[uuid.UUID]bool, len(req.TicketIDs))
+	for _, id := range req.TicketIDs {
+		if seen[id] {
+			return nil, ErrInvalidTicketIDs
+		}
+		seen[id] = true
+	}
+	return s.refund(ctx, req.OrderID, req.TicketIDs, req.Reason, req.RefundedBy, nil)
+}
+
+// orderCancellation asks refund to cancel the order for reason once every ticket is refunded,
+// instead of marking it refunded
+type orderCancellation struct {
+	reason string
+}
+
+// refund refunds the given tickets of an order, or all that are not refunded yet if ticketIDs
+// is empty. The order is cancelled rather than refunded in the end if cancel is set.
+func (s *OrderService) refund(ctx context.Context, orderID int, ticketIDs []uuid.UUID, reason, refundedBy string, cancel *orderCancellation) (*RefundResponse, error) {
+	if orderID <= 0 {
+		return nil, ErrInvalidOrderID
+	}
+	if strings.TrimSpace(reason) == "" || len(reason) > MaxRefundReasonLength {
+		return nil, ErrInvalidRefundReason
+	}
+	if strings.TrimSpace(refundedBy) == "" || len(refundedBy) > MaxRefundedByLength {
+		return nil, ErrInvalidRefundedBy
+	}
+
+	var refund *models.Refund
+	var pmt *models.Payment
+	err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		order, err := s.orderRepo.GetOrderForUpdate(tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		if order.Status != models.OrderStatusPaid {
+			return ErrOrderNotRefundable
+		}
+		// One refund at a time, so each one knows what the others gave back
+		pending, err := s.refundRepo.HasPendingRefund(tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return ErrRefundInProgress
+		}
+
+		items, err := refundableItems(order, ticketIDs)
+		if err != nil {
+			return err
+		}
+
+		refund = &models.Refund{
+			OrderID:    order.ID,
+			Amount:     order.RefundAmount(items),
+			Reason:     reason,
+			RefundedBy: refundedBy,
+			Status:     models.RefundStatusPending,
+		}
+		pmt, err = s.paymentRepo.GetCapturedPaymentForUpdate(tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if pmt != nil {
+			if s.paymentProvider == nil {
+				return ErrPaymentsNotConfigured
+			}
+			refund.PaymentID = pmt.ID
+		}
+		if err = s.refundRepo.CreateRefund(tx, refund); err != nil {
+			return err
+		}
+
+		itemIDs := make([]int, len(items))
+		for i, item := range items {
+			itemIDs[i] = item.ID
+			refund.TicketIDs = append(refund.TicketIDs, item.TicketID)
+		}
+		if err = s.orderRepo.SetOrderItemsRefund(tx, itemIDs, refund.ID); err != nil {
+			return err
+		}
+		return s.postRefund(tx, order, refund, models.LedgerRefundIssued)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders confirmed without a payment, or refunds of nothing, are only recorded
+	if pmt != nil && refund.Amount.IsPositive() {
+		// The refund outlives the request so its outcome is always recorded
+		refundCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.paymentTimeout)
+		defer cancel()
+		if _, err = s.paymentProvider.Refund(refundCtx, pmt.ProviderReference, refund.Amount); err != nil {
+			return nil, s.failRefund(refund, err)
+		}
+	}
+
+	return s.completeRefund(refund, cancel)
+}
+
+// refundableItems returns the items of an order holding the given tickets, or every item not
+// refunded yet if ticketIDs is empty
+func refundableItems(order *models.Order, ticketIDs []uuid.UUID) ([]models.OrderItem, error) {
+	if len(ticketIDs) == 0 {
+		var items []models.OrderItem
+		for _, item := range order.Items {
+			if item.RefundID == 0 {
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
+			return nil, ErrOrderNotRefundable
+		}
+		return items, nil
+	}
+
+	byTicket := make(map[uuid.UUID]models.OrderItem, len(order.Items))
+	for _, item := range order.Items {
+		byTicket[item.TicketID] = item
+	}
+	items := make([]models.OrderItem, len(ticketIDs))
+	for i, id := range ticketIDs {
+		item, ok := byTicket[id]
+		if !ok {
+			return nil, ErrTicketNotInOrder
+		}
+		if item.RefundID != 0 {
+			return nil, ErrTicketAlreadyRefunded
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// completeRefund records that a pending refund was paid back: its tickets return to inventory,
+// or are voided if their session has started, and its amount is added to the order's refunded
+// amount. The order and its payment become refunded once every ticket is refunded, or the order
+// is cancelled instead if cancel is set. If the order is no longer paid by then, the money has
+// still gone back, so only the refund is recorded and the order and its tickets are left as they
+// are.
+func (s *OrderService) completeRefund(refund *models.Refund, cancel *orderCancellation) (*RefundResponse, error) {
+	var order *models.Order
+	err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		var err error
+		order, err = s.orderRepo.GetOrderForUpdate(tx, refund.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+
+		refund.Status = models.RefundStatusSucceeded
+		if err = s.refundRepo.UpdateRefund(tx, refund); err != nil {
+			return err
+		}
+		if err = s.postRefund(tx, order, refund, models.LedgerRefundPaid); err != nil {
+			return err
+		}
+		if order.Status != models.OrderStatusPaid {
+			if err = s.orderRepo.AddRefundedAmount(tx, order.ID, refund.Amount, order.Status); err != nil {
+				return err
+			}
+			order.RefundedAmount = order.RefundedAmount.Add(refund.Amount)
+			return nil
+		}
+
+		var tickets []models.Ticket
+		remaining := 0
+		for _, item := range order.Items {
+			if item.RefundID == refund.ID {
+				tickets = append(tickets, *item.Ticket)
+			} else if item.RefundID == 0 {
+				remaining++
+			}
+		}
+		ticketStatus, err := s.refundedTicketStatus(tickets)
+		if err != nil {
+			return err
+		}
+		if err = s.transitionTickets(tx, tickets, ticketStatus); err != nil {
+			return err
+		}
+
+		orderStatus := order.Status
+		if remaining == 0 {
+			orderStatus = models.OrderStatusRefunded
+			if cancel != nil {
+				orderStatus = models.OrderStatusCancelled
+			}
+			if err = models.ValidateOrderTransition(order.Status, orderStatus); err != nil {
+				return err
+			}
+			if err = s.markPaymentRefunded(tx, refund); err != nil {
+				return err
+			}
+		}
+		if err = s.orderRepo.AddRefundedAmount(tx, order.ID, refund.Amount, orderStatus); err != nil {
+			return err
+		}
+		if orderStatus == models.OrderStatusCancelled {
+			order.CancelledAt = time.Now().UnixMilli()
+			order.CancellationReason = cancel.reason
+			if err = s.orderRepo.CancelOrder(tx, order.ID, cancel.reason, order.CancelledAt); err != nil {
+				return err
+			}
+		}
+
+		order.Status = orderStatus
+		order.RefundedAmount = order.RefundedAmount.Add(refund.Amount)
+		for i := range order.Items {
+			if order.Items[i].RefundID == refund.ID {
+				order.Items[i].Ticket.Status = ticketStatus
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefundResponse{Order: order, Refund: refund}, nil
+}
+
+// refundedTicketStatus returns the status refunded tickets move to: available so they can be
+// sold again, or void if their session has already started
+func (s *OrderService) refundedTicketStatus(tickets []models.Ticket) (string, error) {
+	if len(tickets) == 0 {
+		return models.TicketStatusAvailable, nil
+	}
+
+	session, err := s.concertSessionRepo.GetConcertSessionByID(tickets[0].SessionID)
+	if err != nil {
+		return "", err
+	}
+	if session == nil {
+		return "", ErrConcertSessionNotFound
+	}
+	if session.StartTime <= time.Now().UnixMilli() {
+		return models.TicketStatusVoid, nil
+	}
+	return models.TicketStatusAvailable, nil
+}
+
+// markPaymentRefunded marks the payment a refund was paid back from as refunded, once its
+// order is refunded in full
+func (s *OrderService) markPaymentRefunded(tx *sqlx.Tx, refund *models.Refund) error {
+	if refund.PaymentID == 0 {
+		return nil
+	}
+
+	pmt, err := s.paymentRepo.GetPaymentForUpdate(tx, refund.PaymentID)
+	if err != nil {
+		return err
+	}
+	if pmt == nil {
+		return ErrPaymentNotFound
+	}
+	pmt.Status = models.PaymentStatusRefunded
+	return s.paymentRepo.UpdatePayment(tx, pmt)
+}
+
+// failRefund records why the payment provider did not pay a refund back and returns the error
+// for the caller. The refund's tickets stay with the order so it can be retried. After a
+// timeout the provider may still have paid it; check before retrying.
+func (s *OrderService) failRefund(refund *models.Refund, cause error) error {
+	result := cause
+	switch {
+	case errors.Is(cause, payment.ErrTimeout), errors.Is(cause, context.DeadlineExceeded):
+		result = ErrPaymentUnavailable
+	case errors.Is(cause, payment.ErrDeclined), errors.Is(cause, payment.ErrInvalidTransaction):
+		result = ErrRefundRejected
+	}
+
+	refund.Status = models.RefundStatusFailed
+	refund.FailureMessage = cause.Error()
+	refund.TicketIDs = nil
+	err := s.orderRepo.BaseRepository.WithTransaction(func(tx *sqlx.Tx) error {
+		// Locked like every other change to the order's items
+		order, err := s.orderRepo.GetOrderForUpdate(tx, refund.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		if err := s.refundRepo.UpdateRefund(tx, refund); err != nil {
+			return err
+		}
+		if err := s.postRefund(tx, order, refund, models.LedgerRefundFailed); err != nil {
+			return err
+		}
+		return s.orderRepo.ClearOrderItemsRefund(tx, refund.ID)
+	})
+	if err != nil {
+		return errors.Join(cause, err)
+	}
+	return result
+}
+
+// transitionTickets moves tickets to a new status, enforcing the ticket state machine
+func (s *OrderService) transitionTickets(tx *sqlx.Tx, tickets []models.Ticket, status string) error {
+	for _, ticket := range tickets {
+		if err := models.ValidateTicketTransition(ticket.Status, status); err != nil {
+			return err
+		}
+	}
+
+	return s.ticketRepo.UpdateTicketStatuses(tx, tickets, status)
+}
@@ -1,10 +1,21 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	models "tickets/internal/models/domain"
+	"tickets/internal/payment"
+	"tickets/internal/pricing"
 	"tickets/internal/repository"
 
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -96,6 +107,24 @@ func TestOrderService_CreateOrder_NoTicketsAvailable(t *testing.T) {
 	assert.Greater(t, resp.OrderID, 0)
 }
 
+func TestOrderService_CreateOrder_NotEnoughTickets(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 1)
+
+	// A buyer asking for more tickets than are left gets none rather than fewer
+	_, err := orderService.CreateOrder(&CreateOrderRequest{UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 2})
+	assert.ErrorIs(t, err, ErrNotEnoughTickets)
+
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 1})
+	require.NoError(t, err)
+	assert.Len(t, resp.TicketIDs, 1)
+}
+
 func TestOrderService_CreateOrder_InvalidRequest(t *testing.T) {
 	baseRepo, cleanup := repository.SetupTestDB(t)
 	defer cleanup()
@@ -334,3 +363,2041 @@ func TestOrderService_CreateOrder_ErrorHandling(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, resp)
 }
+
+func TestOrderService_GetOrder(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	// Invalid order IDs are rejected before hitting the database
+	order, err := orderService.GetOrder(0)
+	assert.Error(t, err)
+	assert.Nil(t, order)
+	assert.Contains(t, err.Error(), "order id must be positive")
+
+	// Unknown orders are reported as not found
+	order, err = orderService.GetOrder(999999)
+	assert.Error(t, err)
+	assert.Nil(t, order)
+	assert.Contains(t, err.Error(), "order not found")
+}
+
+func TestOrderService_CreateOrder_PersistsOrderItems(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "25.50", 5)
+
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  3,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.TicketIDs, 3)
+
+	order, err := orderService.GetOrder(resp.OrderID)
+	require.NoError(t, err)
+	require.Len(t, order.Items, 3)
+
+	for i, item := range order.Items {
+		assert.Equal(t, resp.OrderID, item.OrderID)
+		assert.Equal(t, resp.TicketIDs[i], item.TicketID.String())
+		assert.True(t, item.Price.Equal(decimal.RequireFromString("25.50")))
+		require.NotNil(t, item.Ticket)
+		assert.Equal(t, "pending", item.Ticket.Status)
+	}
+	assert.True(t, order.TotalPrice.Equal(decimal.RequireFromString("76.50")))
+}
+
+// insertTestSession creates a concert session with the given price and number of available tickets
+func insertTestSession(t *testing.T, baseRepo *repository.BaseRepository, price string, tickets int) int {
+	var concertID int
+	err := baseRepo.GetDB().QueryRow(`
+		INSERT INTO concerts (name, location, description) 
+		VALUES ($1, $2, $3) 
+		RETURNING id`,
+		"Test Concert", "Test Location", "Test Description").Scan(&concertID)
+	require.NoError(t, err)
+
+	var sessionID int
+	err = baseRepo.GetDB().QueryRow(`
+		INSERT INTO concert_sessions (concert_id, start_time, end_time, venue, number_of_seats, price) 
+		VALUES ($1, $2, $3, $4, $5, $6) 
+		RETURNING id`,
+		concertID, 1735689600000, 1735700400000, "Test Arena", tickets, price).Scan(&sessionID)
+	require.NoError(t, err)
+
+	_, err = baseRepo.GetDB().Exec(`
+		INSERT INTO tickets (session_id, status) 
+		SELECT $1, 'available' FROM generate_series(1, $2)`,
+		sessionID, tickets)
+	require.NoError(t, err)
+
+	return sessionID
+}
+
+func TestOrderService_ListOrders(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	// Use a user ID that no other test writes to
+	const userID = 515151
+	_, err := baseRepo.GetDB().Exec("DELETE FROM orders WHERE user_id = $1", userID)
+	require.NoError(t, err)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 10)
+	orderIDs := make([]int, 3)
+	for i := range orderIDs {
+		resp, err := orderService.CreateOrder(&CreateOrderRequest{
+			UserID:           userID,
+			ConcertSessionID: sessionID,
+			NumberOfTickets:  i + 1,
+		})
+		require.NoError(t, err)
+		orderIDs[i] = resp.OrderID
+	}
+
+	resp, err := orderService.ListOrders(&ListOrdersRequest{UserID: userID, Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.TotalCount)
+	assert.Equal(t, 1, resp.Page)
+	assert.Equal(t, 2, resp.PageSize)
+	require.Len(t, resp.Orders, 2)
+	assert.Equal(t, orderIDs[2], resp.Orders[0].ID)
+	assert.Equal(t, userID, resp.Orders[0].UserID)
+	assert.Len(t, resp.Orders[0].Items, 3)
+	assert.Equal(t, orderIDs[1], resp.Orders[1].ID)
+	assert.Len(t, resp.Orders[1].Items, 2)
+
+	resp, err = orderService.ListOrders(&ListOrdersRequest{UserID: userID, Page: 2, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, resp.Orders, 1)
+	assert.Equal(t, orderIDs[0], resp.Orders[0].ID)
+	assert.Len(t, resp.Orders[0].Items, 1)
+	assert.Empty(t, resp.NextPageToken)
+
+	// Token paging walks the same orders and survives new orders arriving in between
+	resp, err = orderService.ListOrders(&ListOrdersRequest{UserID: userID, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, resp.Orders, 2)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	_, err = orderService.CreateOrder(&CreateOrderRequest{UserID: userID, ConcertSessionID: sessionID, NumberOfTickets: 1})
+	require.NoError(t, err)
+
+	resp, err = orderService.ListOrders(&ListOrdersRequest{UserID: userID, PageSize: 2, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.Page)
+	require.Len(t, resp.Orders, 1)
+	assert.Equal(t, orderIDs[0], resp.Orders[0].ID)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func TestOrderService_ListOrders_PageToken(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	const userID = 525252
+	_, err := baseRepo.GetDB().Exec("DELETE FROM orders WHERE user_id = $1", userID)
+	require.NoError(t, err)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 2)
+	for i := 0; i < 2; i++ {
+		_, err := orderService.CreateOrder(&CreateOrderRequest{UserID: userID, ConcertSessionID: sessionID, NumberOfTickets: 1})
+		require.NoError(t, err)
+	}
+
+	resp, err := orderService.ListOrders(&ListOrdersRequest{UserID: userID, PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	// Page and page_token are mutually exclusive
+	_, err = orderService.ListOrders(&ListOrdersRequest{UserID: userID, Page: 2, PageToken: resp.NextPageToken})
+	assert.ErrorIs(t, err, ErrPageWithPageToken)
+
+	// A token is bound to the user it was issued for
+	_, err = orderService.ListOrders(&ListOrdersRequest{UserID: userID + 1, PageToken: resp.NextPageToken})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, err = orderService.ListOrders(&ListOrdersRequest{UserID: userID, PageToken: "garbage"})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestOrderService_ListOrders_Defaults(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	resp, err := orderService.ListOrders(&ListOrdersRequest{UserID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Page)
+	assert.Equal(t, DefaultPageSize, resp.PageSize)
+
+	resp, err = orderService.ListOrders(&ListOrdersRequest{UserID: 1, PageSize: MaxPageSize + 1})
+	require.NoError(t, err)
+	assert.Equal(t, MaxPageSize, resp.PageSize)
+
+	invalid := []*ListOrdersRequest{
+		nil,
+		{UserID: 0},
+		{UserID: 1, Page: -1},
+		{UserID: 1, PageSize: -1},
+	}
+	for _, req := range invalid {
+		resp, err := orderService.ListOrders(req)
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+	}
+}
+
+func TestOrderService_CreateOrder_NoTicketSoldTwice(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping concurrency stress test in short mode")
+	}
+
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	// Keep the pool below PostgreSQL's default connection limit
+	baseRepo.GetDB().SetMaxOpenConns(20)
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	const (
+		numTickets = 150
+		numBuyers  = 300
+	)
+	sessionID := insertTestSession(t, baseRepo, "10.00", numTickets)
+
+	type result struct {
+		requested int
+		resp      *CreateOrderResponse
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan result, numBuyers)
+	failures := make(chan error, numBuyers)
+
+	for i := 0; i < numBuyers; i++ {
+		wg.Add(1)
+		go func(buyer int) {
+			defer wg.Done()
+
+			requested := buyer%3 + 1
+			resp, err := orderService.CreateOrder(&CreateOrderRequest{
+				UserID:           buyer + 1,
+				ConcertSessionID: sessionID,
+				NumberOfTickets:  requested,
+			})
+			if err != nil {
+				failures <- err
+				return
+			}
+			results <- result{requested, resp}
+		}(i)
+	}
+	wg.Wait()
+	close(results)
+	close(failures)
+
+	// The only acceptable failure is running out of tickets
+	for err := range failures {
+		assert.ErrorIs(t, err, ErrSoldOut)
+	}
+
+	// Every buyer gets what they asked for, and every ticket handed out belongs to exactly one order
+	sold := make(map[string]int)
+	for r := range results {
+		assert.Len(t, r.resp.TicketIDs, r.requested)
+		for _, ticketID := range r.resp.TicketIDs {
+			sold[ticketID]++
+		}
+	}
+	for ticketID, count := range sold {
+		assert.Equal(t, 1, count, "ticket %s was sold %d times", ticketID, count)
+	}
+
+	// The database agrees with what the buyers were told
+	var duplicated int
+	err := baseRepo.GetDB().Get(&duplicated, `
+		SELECT COUNT(*) FROM (
+			SELECT oi.ticket_id
+			FROM order_items oi
+			JOIN tickets t ON t.id = oi.ticket_id
+			WHERE t.session_id = $1
+			GROUP BY oi.ticket_id
+			HAVING COUNT(*) > 1
+		) d`, sessionID)
+	require.NoError(t, err)
+	assert.Zero(t, duplicated)
+
+	var available int
+	err = baseRepo.GetDB().Get(&available,
+		"SELECT COUNT(*) FROM tickets WHERE session_id = $1 AND status = 'available'", sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, numTickets, len(sold)+available)
+}
+
+func TestOrderService_CreateOrder_SetsHoldExpiry(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetHoldTTL(5 * time.Minute)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 1)
+
+	before := time.Now()
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+	})
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, resp.ExpiresAt, before.Add(5*time.Minute).UnixMilli())
+	assert.LessOrEqual(t, resp.ExpiresAt, time.Now().Add(5*time.Minute).UnixMilli())
+
+	order, err := orderService.GetOrder(resp.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, resp.ExpiresAt, order.ExpiresAt)
+}
+
+func TestOrderService_ExpirePendingOrders(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 4)
+
+	// An abandoned checkout whose hold has already ended
+	orderService.SetHoldTTL(-time.Minute)
+	abandoned, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+
+	// A checkout that is still within its hold
+	orderService.SetHoldTTL(time.Hour)
+	active, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           2,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+
+	expired, err := orderService.ExpirePendingOrders(time.Now())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, expired, 1)
+
+	order, err := orderService.GetOrder(abandoned.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "expired", order.Status)
+	for _, item := range order.Items {
+		assert.Equal(t, "available", item.Ticket.Status)
+	}
+
+	order, err = orderService.GetOrder(active.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", order.Status)
+	for _, item := range order.Items {
+		assert.Equal(t, "pending", item.Ticket.Status)
+	}
+
+	// Released tickets can be bought again
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           3,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, abandoned.TicketIDs, resp.TicketIDs)
+}
+
+func TestExpiringTickets(t *testing.T) {
+	held := models.Ticket{ID: uuid.New(), Status: models.TicketStatusPending}
+	sold := models.Ticket{ID: uuid.New(), Status: models.TicketStatusSold}
+
+	tickets, err := expiringTickets(models.Order{
+		ID: 1, Status: models.OrderStatusPending, Items: []models.OrderItem{{TicketID: held.ID, Ticket: &held}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Ticket{held}, tickets)
+
+	// Only pending orders expire, and only with the tickets they hold
+	_, err = expiringTickets(models.Order{ID: 2, Status: models.OrderStatusPaid})
+	assert.Error(t, err)
+	_, err = expiringTickets(models.Order{
+		ID: 3, Status: models.OrderStatusPending, Items: []models.OrderItem{{TicketID: sold.ID, Ticket: &sold}},
+	})
+	assert.Error(t, err)
+}
+
+func TestOrderService_ExpirePendingOrders_SkipsBrokenOrder(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetHoldTTL(-time.Minute)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 2)
+	broken, err := orderService.CreateOrder(&CreateOrderRequest{UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 1})
+	require.NoError(t, err)
+	abandoned, err := orderService.CreateOrder(&CreateOrderRequest{UserID: 2, ConcertSessionID: sessionID, NumberOfTickets: 1})
+	require.NoError(t, err)
+
+	// The oldest order's ticket was sold behind its back, so it cannot expire
+	_, err = baseRepo.GetDB().Exec(`UPDATE tickets SET status = 'sold' WHERE id = $1`, broken.TicketIDs[0])
+	require.NoError(t, err)
+	_, err = baseRepo.GetDB().Exec(`UPDATE orders SET expires_at = expires_at - 60000 WHERE id = $1`, broken.OrderID)
+	require.NoError(t, err)
+
+	_, err = orderService.ExpirePendingOrders(time.Now())
+	require.NoError(t, err)
+
+	// The broken order is left for someone to look at; the rest still expire
+	order, err := orderService.GetOrder(broken.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", order.Status)
+	assert.Equal(t, "sold", order.Items[0].Ticket.Status)
+
+	order, err = orderService.GetOrder(abandoned.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "expired", order.Status)
+	assert.Equal(t, "available", order.Items[0].Ticket.Status)
+}
+
+func TestOrderService_ConfirmOrder(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 2)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+
+	order, err := orderService.ConfirmOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+	require.Len(t, order.Items, 2)
+
+	// The change is persisted for both the order and its tickets
+	order, err = orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+	for _, item := range order.Items {
+		assert.Equal(t, "sold", item.Ticket.Status)
+	}
+
+	// Confirming again is a no-op
+	order, err = orderService.ConfirmOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+
+	// Paid orders are never expired
+	_, err = orderService.ExpirePendingOrders(time.Now().Add(24 * time.Hour))
+	require.NoError(t, err)
+	order, err = orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+}
+
+func TestOrderService_ConfirmOrder_Expired(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetHoldTTL(-time.Minute)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 2)
+
+	// Hold ended but the expiry worker has not run yet
+	lapsed, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+	})
+	require.NoError(t, err)
+
+	order, err := orderService.ConfirmOrder(lapsed.OrderID)
+	assert.Nil(t, order)
+	require.Error(t, err)
+	assert.Equal(t, "order has expired", err.Error())
+
+	// Hold ended and the expiry worker already released the tickets
+	_, err = orderService.ExpirePendingOrders(time.Now())
+	require.NoError(t, err)
+
+	order, err = orderService.ConfirmOrder(lapsed.OrderID)
+	assert.Nil(t, order)
+	require.Error(t, err)
+	assert.Equal(t, "order has expired", err.Error())
+}
+
+func TestOrderService_ConfirmOrder_InvalidOrder(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	order, err := orderService.ConfirmOrder(0)
+	assert.Nil(t, order)
+	assert.EqualError(t, err, "order id must be positive")
+
+	order, err = orderService.ConfirmOrder(999999)
+	assert.Nil(t, order)
+	assert.EqualError(t, err, "order not found")
+}
+
+func TestOrderService_CancelOrder(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 4)
+	// Tickets of a session that has not started go back on sale when refunded
+	_, err := baseRepo.GetDB().Exec(`UPDATE concert_sessions SET start_time = $1 WHERE id = $2`,
+		time.Now().Add(24*time.Hour).UnixMilli(), sessionID)
+	require.NoError(t, err)
+
+	pending, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+
+	paid, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           2,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+	_, err = orderService.ConfirmOrder(paid.OrderID)
+	require.NoError(t, err)
+
+	for _, orderID := range []int{pending.OrderID, paid.OrderID} {
+		order, err := orderService.CancelOrder(context.Background(), orderID, "changed plans")
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled", order.Status)
+		assert.Equal(t, "changed plans", order.CancellationReason)
+		assert.Greater(t, order.CancelledAt, int64(0))
+
+		// The change is persisted for both the order and its tickets
+		order, err = orderService.GetOrder(orderID)
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled", order.Status)
+		assert.Equal(t, "changed plans", order.CancellationReason)
+		require.Len(t, order.Items, 2)
+		for _, item := range order.Items {
+			assert.Equal(t, "available", item.Ticket.Status)
+		}
+	}
+
+	// Cancelling again is a no-op that keeps the original details
+	order, err := orderService.CancelOrder(context.Background(), pending.OrderID, "another reason")
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", order.Status)
+	assert.Equal(t, "changed plans", order.CancellationReason)
+
+	// Cancelled orders can no longer be confirmed
+	_, err = orderService.ConfirmOrder(pending.OrderID)
+	assert.EqualError(t, err, "order cannot be confirmed in its current status")
+
+	// Released tickets can be bought again
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           3,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  3,
+	})
+	require.NoError(t, err)
+	assert.Len(t, resp.TicketIDs, 3)
+}
+
+func TestOrderService_CancelOrder_Paid(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+	orderService.SetPaymentProvider(newFakePaymentProvider(t, payment.FakeApprove))
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 2)
+	// Tickets of a session that has not started go back on sale when refunded
+	_, err := baseRepo.GetDB().Exec(`UPDATE concert_sessions SET start_time = $1 WHERE id = $2`,
+		time.Now().Add(24*time.Hour).UnixMilli(), sessionID)
+	require.NoError(t, err)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+	paid, err := orderService.PayOrder(ctx, &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+	_, err = orderService.RefundTickets(ctx, &RefundTicketsRequest{
+		OrderID: created.OrderID, TicketIDs: []uuid.UUID{paid.Order.Items[0].TicketID}, Reason: "cannot attend", RefundedBy: "support",
+	})
+	require.NoError(t, err)
+
+	// Cancelling refunds what is left of the payment and releases the remaining ticket
+	order, err := orderService.CancelOrder(ctx, created.OrderID, "event moved")
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", order.Status)
+	assert.Equal(t, "event moved", order.CancellationReason)
+	assert.True(t, decimal.RequireFromString("80.00").Equal(order.RefundedAmount), "got %s", order.RefundedAmount)
+
+	order, err = orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", order.Status)
+	for _, item := range order.Items {
+		assert.Equal(t, "available", item.Ticket.Status)
+	}
+
+	refunds, err := repository.NewRefundRepository(baseRepo).ListRefundsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 2)
+	assert.Equal(t, "succeeded", refunds[1].Status)
+	assert.Equal(t, "event moved", refunds[1].Reason)
+	assert.Equal(t, "cancellation", refunds[1].RefundedBy)
+	assert.True(t, decimal.RequireFromString("40.00").Equal(refunds[1].Amount))
+
+	payments, err := repository.NewPaymentRepository(baseRepo).ListPaymentsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, "refunded", payments[0].Status)
+
+	check, err := adminService.CheckLedger()
+	require.NoError(t, err)
+	assert.True(t, check.Balanced(), "unbalanced transactions %v", check.UnbalancedTransactionIDs)
+
+	// A refund the provider rejects leaves the order paid with its tickets
+	rejected, err := orderService.CreateOrder(&CreateOrderRequest{UserID: 2, ConcertSessionID: sessionID, NumberOfTickets: 1})
+	require.NoError(t, err)
+	_, err = orderService.PayOrder(ctx, &PayOrderRequest{OrderID: rejected.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+	orderService.SetPaymentProvider(&failingRefundProvider{FakeProvider: newFakePaymentProvider(t, payment.FakeApprove)})
+
+	_, err = orderService.CancelOrder(ctx, rejected.OrderID, "")
+	assert.ErrorIs(t, err, ErrRefundRejected)
+	order, err = orderService.GetOrder(rejected.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+	assert.Equal(t, "sold", order.Items[0].Ticket.Status)
+}
+
+func TestOrderService_CancelOrder_Expired(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetHoldTTL(-time.Minute)
+
+	sessionID := insertTestSession(t, baseRepo, "10.00", 1)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+	})
+	require.NoError(t, err)
+
+	_, err = orderService.ExpirePendingOrders(time.Now())
+	require.NoError(t, err)
+
+	order, err := orderService.CancelOrder(context.Background(), created.OrderID, "")
+	assert.Nil(t, order)
+	assert.EqualError(t, err, "order cannot be cancelled in its current status")
+}
+
+func TestOrderService_CancelOrder_InvalidRequest(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	order, err := orderService.CancelOrder(context.Background(), 0, "")
+	assert.Nil(t, order)
+	assert.EqualError(t, err, "order id must be positive")
+
+	order, err = orderService.CancelOrder(context.Background(), 1, strings.Repeat("x", MaxCancellationReasonLength+1))
+	assert.Nil(t, order)
+	assert.EqualError(t, err, "cancellation reason is too long")
+
+	order, err = orderService.CancelOrder(context.Background(), 999999, "")
+	assert.Nil(t, order)
+	assert.EqualError(t, err, "order not found")
+}
+
+func TestOrderService_CreateOrder_IdempotencyKey(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "25.00", 5)
+	req := &CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+		IdempotencyKey:   "checkout-123",
+	}
+
+	first, err := orderService.CreateOrder(req)
+	require.NoError(t, err)
+
+	// A retry replays the original response without reserving more tickets
+	retry, err := orderService.CreateOrder(req)
+	require.NoError(t, err)
+	assert.Equal(t, first.OrderID, retry.OrderID)
+	assert.Equal(t, first.TicketIDs, retry.TicketIDs)
+	assert.True(t, first.TotalPrice.Equal(retry.TotalPrice))
+	assert.Equal(t, first.CreatedAt, retry.CreatedAt)
+	assert.Equal(t, first.ExpiresAt, retry.ExpiresAt)
+
+	orders, err := orderService.ListOrders(&ListOrdersRequest{UserID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, orders.TotalCount)
+
+	// A different payload under the same key is rejected
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+		IdempotencyKey:   "checkout-123",
+	})
+	assert.EqualError(t, err, "idempotency key already used for a different request")
+
+	// Keys are scoped to the user
+	other, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           2,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+		IdempotencyKey:   "checkout-123",
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.OrderID, other.OrderID)
+
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+		IdempotencyKey:   strings.Repeat("k", MaxIdempotencyKeyLength+1),
+	})
+	assert.EqualError(t, err, "idempotency key is too long")
+}
+
+func TestOrderService_CreateOrder_IdempotencyKeyRetention(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetIdempotencyTTL(-time.Minute)
+
+	sessionID := insertTestSession(t, baseRepo, "25.00", 4)
+	req := &CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+		IdempotencyKey:   "checkout-456",
+	}
+
+	first, err := orderService.CreateOrder(req)
+	require.NoError(t, err)
+
+	// Once the key is past retention the request is treated as new
+	second, err := orderService.CreateOrder(req)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.OrderID, second.OrderID)
+
+	pruned, err := orderService.PruneIdempotencyKeys(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pruned)
+}
+
+func TestOrderService_CreateOrder_ConcurrentIdempotentRetries(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "25.00", 30)
+	req := &CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  3,
+		IdempotencyKey:   "checkout-789",
+	}
+
+	const retries = 10
+	orderIDs := make([]int, retries)
+	var wg sync.WaitGroup
+	for i := 0; i < retries; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := orderService.CreateOrder(req)
+			if assert.NoError(t, err) {
+				orderIDs[i] = resp.OrderID
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, orderID := range orderIDs {
+		assert.Equal(t, orderIDs[0], orderID)
+	}
+}
+
+// insertSeatedTestSession creates a concert session with one available ticket per seat of layout
+func insertSeatedTestSession(t *testing.T, baseRepo *repository.BaseRepository, price string, layout models.SectionLayout) int {
+	sessionID := insertTestSession(t, baseRepo, price, 0)
+
+	_, err := baseRepo.GetDB().Exec(`UPDATE concert_sessions SET number_of_seats = $1 WHERE id = $2`,
+		layout.Capacity(), sessionID)
+	require.NoError(t, err)
+
+	ticketRepo := repository.NewTicketRepository(baseRepo)
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		return ticketRepo.CreateSeatedTickets(tx, sessionID, layout, 0)
+	})
+	require.NoError(t, err)
+
+	return sessionID
+}
+
+func TestOrderService_CreateOrder_SeatSelection(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	concertService := NewConcertService(baseService)
+
+	sessionID := insertSeatedTestSession(t, baseRepo, "40.00", models.SectionLayout{Name: "Stalls", Rows: 2, SeatsPerRow: 3})
+	seatMap, err := concertService.GetSeatMap(&GetSeatMapRequest{SessionID: sessionID})
+	require.NoError(t, err)
+	require.Len(t, seatMap.Seats, 6)
+	assert.Equal(t, 6, seatMap.Available)
+
+	// Buy B2 and A3, omitting the number of tickets
+	picked := []uuid.UUID{seatMap.Seats[4].ID, seatMap.Seats[2].ID}
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{UserID: 1, ConcertSessionID: sessionID, SeatIDs: picked})
+	require.NoError(t, err)
+	assert.Equal(t, []string{picked[0].String(), picked[1].String()}, resp.TicketIDs)
+	assert.True(t, resp.TotalPrice.Equal(decimal.RequireFromString("80.00")))
+
+	order, err := orderService.GetOrder(resp.OrderID)
+	require.NoError(t, err)
+	require.Len(t, order.Items, 2)
+	assert.Equal(t, "Stalls B2", order.Items[0].Ticket.SeatLabel())
+	assert.Equal(t, "Stalls A3", order.Items[1].Ticket.SeatLabel())
+
+	seatMap, err = concertService.GetSeatMap(&GetSeatMapRequest{SessionID: sessionID})
+	require.NoError(t, err)
+	assert.Equal(t, 4, seatMap.Available)
+	assert.Equal(t, "pending", seatMap.Seats[4].Status)
+
+	// A taken seat fails the whole order and every unavailable seat is listed
+	unknown := uuid.New()
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 2, ConcertSessionID: sessionID, SeatIDs: []uuid.UUID{seatMap.Seats[0].ID, picked[0], unknown},
+	})
+	require.ErrorIs(t, err, ErrSeatsUnavailable)
+
+	var svcErr *Error
+	require.True(t, errors.As(err, &svcErr))
+	require.Len(t, svcErr.Violations, 2)
+	assert.Equal(t, picked[0].String(), svcErr.Violations[0].Subject)
+	assert.Contains(t, svcErr.Violations[0].Description, "Stalls B2")
+	assert.Equal(t, unknown.String(), svcErr.Violations[1].Subject)
+
+	// The free seat of the failed order is still available
+	seatMap, err = concertService.GetSeatMap(&GetSeatMapRequest{SessionID: sessionID})
+	require.NoError(t, err)
+	assert.Equal(t, "available", seatMap.Seats[0].Status)
+}
+
+func TestOrderService_CreateOrder_InvalidSeatSelection(t *testing.T) {
+	// Seat selection is validated before any database access
+	orderService := &OrderService{}
+	seat := uuid.New()
+
+	_, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: 1, NumberOfTickets: 2, SeatIDs: []uuid.UUID{seat},
+	})
+	assert.ErrorIs(t, err, ErrSeatCountMismatch)
+
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: 1, SeatIDs: []uuid.UUID{seat, seat},
+	})
+	assert.ErrorIs(t, err, ErrDuplicateSeat)
+
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: 1, SeatIDs: []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()},
+	})
+	assert.ErrorIs(t, err, ErrTicketLimitExceeded)
+}
+
+func TestOrderService_CreateOrder_BestAvailableSeats(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	concertService := NewConcertService(baseService)
+
+	sessionID := insertSeatedTestSession(t, baseRepo, "20.00", models.SectionLayout{Name: "Stalls", Rows: 2, SeatsPerRow: 4})
+	seatMap, err := concertService.GetSeatMap(&GetSeatMapRequest{SessionID: sessionID})
+	require.NoError(t, err)
+
+	// Take A2 and B3 so no row has three adjacent seats left
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID, SeatIDs: []uuid.UUID{seatMap.Seats[1].ID, seatMap.Seats[6].ID},
+	})
+	require.NoError(t, err)
+
+	seatLabels := func(resp *CreateOrderResponse) []string {
+		order, err := orderService.GetOrder(resp.OrderID)
+		require.NoError(t, err)
+		labels := make([]string, len(order.Items))
+		for i, item := range order.Items {
+			labels[i] = item.Ticket.SeatLabel()
+		}
+		return labels
+	}
+
+	// A party of three is not split up unless the buyer agrees
+	_, err = orderService.CreateOrder(&CreateOrderRequest{UserID: 2, ConcertSessionID: sessionID, NumberOfTickets: 3})
+	assert.ErrorIs(t, err, ErrAdjacentSeatsUnavailable)
+
+	// A pair gets the first adjacent block in the front row
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{UserID: 2, ConcertSessionID: sessionID, NumberOfTickets: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Stalls A3", "Stalls A4"}, seatLabels(resp))
+
+	resp, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 3, ConcertSessionID: sessionID, NumberOfTickets: 3, AllowSplitSeating: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Stalls A1", "Stalls B1", "Stalls B2"}, seatLabels(resp))
+
+	// Only B4 is left, so a split party of two is not given a single seat
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 4, ConcertSessionID: sessionID, NumberOfTickets: 2, AllowSplitSeating: true,
+	})
+	assert.ErrorIs(t, err, ErrNotEnoughTickets)
+	_, err = orderService.CreateOrder(&CreateOrderRequest{UserID: 4, ConcertSessionID: sessionID, NumberOfTickets: 2})
+	assert.ErrorIs(t, err, ErrNotEnoughTickets)
+}
+
+func TestOrderService_CreateOrder_TicketTypes(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "50.00", 10)
+	vip, err := adminService.CreateTicketType(&CreateTicketTypeRequest{
+		SessionID: sessionID, Name: "VIP", Price: decimal.RequireFromString("120.00"), Quota: 1,
+	})
+	require.NoError(t, err)
+	student, err := adminService.CreateTicketType(&CreateTicketTypeRequest{
+		SessionID: sessionID, Name: "Student", Price: decimal.RequireFromString("30.50"), Quota: 5,
+	})
+	require.NoError(t, err)
+
+	// Sessions with ticket types do not sell at the session price
+	_, err = orderService.CreateOrder(&CreateOrderRequest{UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 1})
+	assert.ErrorIs(t, err, ErrTicketTypeRequired)
+
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID,
+		TicketTypes: []TicketSelection{{TicketTypeID: vip.ID, Quantity: 1}, {TicketTypeID: student.ID, Quantity: 2}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, resp.TicketIDs, 3)
+	assert.True(t, decimal.RequireFromString("181.00").Equal(resp.TotalPrice), "got %s", resp.TotalPrice)
+
+	order, err := orderService.GetOrder(resp.OrderID)
+	require.NoError(t, err)
+	require.Len(t, order.Items, 3)
+	assert.Equal(t, vip.ID, order.Items[0].TicketTypeID)
+	assert.Equal(t, "VIP", order.Items[0].Ticket.TicketType)
+	assert.True(t, decimal.RequireFromString("30.50").Equal(order.Items[2].Price))
+
+	// The VIP quota is used up even though the session has tickets left
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 2, ConcertSessionID: sessionID, TicketTypes: []TicketSelection{{TicketTypeID: vip.ID, Quantity: 1}},
+	})
+	assert.ErrorIs(t, err, ErrTicketTypeSoldOut)
+
+	// Ticket types of other sessions cannot be bought
+	otherSessionID := insertTestSession(t, baseRepo, "50.00", 1)
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 2, ConcertSessionID: otherSessionID, TicketTypes: []TicketSelection{{TicketTypeID: student.ID, Quantity: 1}},
+	})
+	assert.ErrorIs(t, err, ErrTicketTypeNotFound)
+}
+
+func TestOrderService_CreateOrder_InvalidTicketSelection(t *testing.T) {
+	// Ticket type selections are validated before any database access
+	orderService := &OrderService{}
+
+	testCases := []struct {
+		name            string
+		numberOfTickets int
+		selections      []TicketSelection
+		err             error
+	}{
+		{"missing type", 0, []TicketSelection{{Quantity: 1}}, ErrInvalidTicketSelection},
+		{"zero quantity", 0, []TicketSelection{{TicketTypeID: 1}}, ErrInvalidTicketSelection},
+		{"duplicate type", 0, []TicketSelection{{TicketTypeID: 1, Quantity: 1}, {TicketTypeID: 1, Quantity: 1}}, ErrInvalidTicketSelection},
+		{"count mismatch", 3, []TicketSelection{{TicketTypeID: 1, Quantity: 2}}, ErrTicketTypeCountMismatch},
+		{"too many tickets", 0, []TicketSelection{{TicketTypeID: 1, Quantity: 2}, {TicketTypeID: 2, Quantity: 2}}, ErrTicketLimitExceeded},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := orderService.CreateOrder(&CreateOrderRequest{
+				UserID: 1, ConcertSessionID: 1, NumberOfTickets: tc.numberOfTickets, TicketTypes: tc.selections,
+			})
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestOrderService_CreateOrder_PromoCode(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "50.00", 10)
+	_, err := adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "ORDER-SAVE20", DiscountType: "percentage", DiscountValue: decimal.NewFromInt(20),
+		MaxRedemptions: 2, MaxRedemptionsPerUser: 1,
+	})
+	require.NoError(t, err)
+
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 3, PromoCode: "order-save20",
+	})
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("150.00").Equal(resp.Subtotal), "got %s", resp.Subtotal)
+	assert.True(t, decimal.RequireFromString("30.00").Equal(resp.Discount), "got %s", resp.Discount)
+	assert.True(t, decimal.RequireFromString("120.00").Equal(resp.TotalPrice), "got %s", resp.TotalPrice)
+	assert.Equal(t, "ORDER-SAVE20", resp.PromoCode)
+
+	order, err := orderService.GetOrder(resp.OrderID)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("30.00").Equal(order.Discount))
+	assert.Equal(t, "ORDER-SAVE20", order.PromoCode)
+
+	// Each user may redeem the code once, and it runs out after two orders
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 1, PromoCode: "ORDER-SAVE20",
+	})
+	assert.ErrorIs(t, err, ErrPromoCodeUserLimitReached)
+
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 2, ConcertSessionID: sessionID, NumberOfTickets: 1, PromoCode: "ORDER-SAVE20",
+	})
+	require.NoError(t, err)
+
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 3, ConcertSessionID: sessionID, NumberOfTickets: 1, PromoCode: "ORDER-SAVE20",
+	})
+	assert.ErrorIs(t, err, ErrPromoCodeFullyRedeemed)
+
+	// Cancelling an order gives its redemption back
+	_, err = orderService.CancelOrder(context.Background(), resp.OrderID, "")
+	require.NoError(t, err)
+	_, err = orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 3, ConcertSessionID: sessionID, NumberOfTickets: 1, PromoCode: "ORDER-SAVE20",
+	})
+	require.NoError(t, err)
+}
+
+func TestOrderService_CreateOrder_PromoCodeRestrictions(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "30.00", 5)
+	otherSessionID := insertTestSession(t, baseRepo, "30.00", 5)
+	now := time.Now().UnixMilli()
+
+	_, err := adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "ORDER-SESSION", DiscountType: "fixed", DiscountValue: decimal.NewFromInt(100), SessionID: otherSessionID,
+	})
+	require.NoError(t, err)
+	_, err = adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "ORDER-LATER", DiscountType: "fixed", DiscountValue: decimal.NewFromInt(5), ValidFrom: now + time.Hour.Milliseconds(),
+	})
+	require.NoError(t, err)
+	_, err = adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "ORDER-ENDED", DiscountType: "fixed", DiscountValue: decimal.NewFromInt(5), ValidUntil: now - 1,
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		code string
+		err  error
+	}{
+		{"ORDER-SESSION", ErrPromoCodeNotApplicable},
+		{"ORDER-LATER", ErrPromoCodeNotActive},
+		{"ORDER-ENDED", ErrPromoCodeNotActive},
+		{"ORDER-UNKNOWN", ErrPromoCodeNotFound},
+		{"not a code!", ErrInvalidPromoCode},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.code, func(t *testing.T) {
+			_, err := orderService.CreateOrder(&CreateOrderRequest{
+				UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 1, PromoCode: tc.code,
+			})
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	// A fixed discount larger than the subtotal makes the order free
+	resp, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: otherSessionID, NumberOfTickets: 1, PromoCode: "ORDER-SESSION",
+	})
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("30.00").Equal(resp.Discount), "got %s", resp.Discount)
+	assert.True(t, resp.TotalPrice.IsZero())
+}
+
+// newFakePaymentProvider creates a fake payment provider with the given behaviour
+func newFakePaymentProvider(t *testing.T, behavior string) *payment.FakeProvider {
+	provider, err := payment.NewFakeProvider(payment.FakeConfig{Behavior: behavior})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestOrderService_PayOrder(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetPaymentProvider(newFakePaymentProvider(t, payment.FakeApprove))
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 2)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+
+	resp, err := orderService.PayOrder(context.Background(), &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+	assert.Equal(t, "paid", resp.Order.Status)
+	require.NotNil(t, resp.Payment)
+	assert.Equal(t, "captured", resp.Payment.Status)
+	assert.Equal(t, "fake", resp.Payment.Provider)
+	assert.NotEmpty(t, resp.Payment.ProviderReference)
+	assert.True(t, decimal.RequireFromString("80.00").Equal(resp.Payment.Amount))
+
+	// The order, its tickets and its payment are persisted
+	order, err := orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+	for _, item := range order.Items {
+		assert.Equal(t, "sold", item.Ticket.Status)
+	}
+	payments, err := repository.NewPaymentRepository(baseRepo).ListPaymentsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, "captured", payments[0].Status)
+
+	// A paid order cannot be paid again
+	_, err = orderService.PayOrder(context.Background(), &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	assert.ErrorIs(t, err, ErrOrderNotPayable)
+}
+
+func TestOrderService_PayOrder_Declined(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetPaymentProvider(newFakePaymentProvider(t, payment.FakeApprove))
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 2)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+
+	resp, err := orderService.PayOrder(context.Background(), &PayOrderRequest{
+		OrderID: created.OrderID, PaymentMethod: payment.FakeMethodInsufficientFunds,
+	})
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, ErrPaymentDeclined)
+	assert.EqualError(t, err, "payment was declined (insufficient_funds)")
+
+	// The order is cancelled and its tickets are back on sale
+	order, err := orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", order.Status)
+	assert.Equal(t, "payment declined", order.CancellationReason)
+	for _, item := range order.Items {
+		assert.Equal(t, "available", item.Ticket.Status)
+	}
+
+	payments, err := repository.NewPaymentRepository(baseRepo).ListPaymentsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, "declined", payments[0].Status)
+	assert.Equal(t, "insufficient_funds", payments[0].FailureCode)
+}
+
+func TestOrderService_PayOrder_Timeout(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetPaymentProvider(newFakePaymentProvider(t, payment.FakeApprove))
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 1)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+	})
+	require.NoError(t, err)
+
+	_, err = orderService.PayOrder(context.Background(), &PayOrderRequest{
+		OrderID: created.OrderID, PaymentMethod: payment.FakeMethodTimeout,
+	})
+	assert.ErrorIs(t, err, ErrPaymentUnavailable)
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	// The order keeps its tickets, and paying again succeeds
+	order, err := orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", order.Status)
+
+	resp, err := orderService.PayOrder(context.Background(), &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+	assert.Equal(t, "paid", resp.Order.Status)
+
+	payments, err := repository.NewPaymentRepository(baseRepo).ListPaymentsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, payments, 2)
+	assert.Equal(t, "failed", payments[0].Status)
+	assert.Equal(t, "timeout", payments[0].FailureCode)
+	assert.Equal(t, "captured", payments[1].Status)
+}
+
+// cancellingProvider runs cancel before capturing, as if the order were cancelled while the
+// provider was collecting the payment
+type cancellingProvider struct {
+	*payment.FakeProvider
+	cancel func()
+}
+
+func (p *cancellingProvider) Capture(ctx context.Context, reference string, amount decimal.Decimal) (*payment.Result, error) {
+	p.cancel()
+	return p.FakeProvider.Capture(ctx, reference, amount)
+}
+
+func TestOrderService_PayOrder_CancelledDuringCapture(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 1)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+	})
+	require.NoError(t, err)
+
+	orderService.SetPaymentProvider(&cancellingProvider{
+		FakeProvider: newFakePaymentProvider(t, payment.FakeApprove),
+		cancel: func() {
+			_, err := orderService.CancelOrder(context.Background(), created.OrderID, "changed my mind")
+			require.NoError(t, err)
+		},
+	})
+
+	_, err = orderService.PayOrder(context.Background(), &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	assert.ErrorIs(t, err, ErrOrderNotPayable)
+
+	// The captured payment is refunded
+	payments, err := repository.NewPaymentRepository(baseRepo).ListPaymentsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, "refunded", payments[0].Status)
+}
+
+func TestOrderService_PayOrder_FreeOrder(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+	// Every authorization times out, so the order must be confirmed without the provider
+	orderService.SetPaymentProvider(newFakePaymentProvider(t, payment.FakeTimeout))
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 1)
+	_, err := adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "PAY-FREE", DiscountType: "percentage", DiscountValue: decimal.NewFromInt(100),
+	})
+	require.NoError(t, err)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 1, PromoCode: "PAY-FREE",
+	})
+	require.NoError(t, err)
+
+	resp, err := orderService.PayOrder(context.Background(), &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+	assert.Equal(t, "paid", resp.Order.Status)
+	assert.Nil(t, resp.Payment)
+}
+
+func TestOrderService_PayOrder_InvalidRequest(t *testing.T) {
+	// Requests are validated before any database access
+	orderService := &OrderService{}
+
+	_, err := orderService.PayOrder(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrNilRequest)
+
+	_, err = orderService.PayOrder(context.Background(), &PayOrderRequest{PaymentMethod: "card"})
+	assert.ErrorIs(t, err, ErrInvalidOrderID)
+
+	_, err = orderService.PayOrder(context.Background(), &PayOrderRequest{OrderID: 1})
+	assert.ErrorIs(t, err, ErrInvalidPaymentMethod)
+
+	_, err = orderService.PayOrder(context.Background(), &PayOrderRequest{
+		OrderID: 1, PaymentMethod: strings.Repeat("x", MaxPaymentMethodLength+1),
+	})
+	assert.ErrorIs(t, err, ErrInvalidPaymentMethod)
+
+	_, err = orderService.PayOrder(context.Background(), &PayOrderRequest{OrderID: 1, PaymentMethod: "card"})
+	assert.ErrorIs(t, err, ErrPaymentsNotConfigured)
+}
+
+func TestOrderService_HandlePaymentEvent(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetPaymentProvider(newFakePaymentProvider(t, payment.FakeApprove))
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 2)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+	})
+	require.NoError(t, err)
+
+	paid, err := orderService.PayOrder(ctx, &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+
+	// The provider's notification of a payment PayOrder already captured changes nothing
+	applied, err := orderService.HandlePaymentEvent(ctx, &PaymentEventRequest{
+		EventID: "evt_1", Type: PaymentEventCaptured, Reference: paid.Payment.ProviderReference,
+	})
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	// A late failure for the same payment does not undo it
+	applied, err = orderService.HandlePaymentEvent(ctx, &PaymentEventRequest{
+		EventID: "evt_2", Type: PaymentEventFailed, Reference: paid.Payment.ProviderReference,
+	})
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	order, err := orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+
+	// Redelivered events are reported as such
+	applied, err = orderService.HandlePaymentEvent(ctx, &PaymentEventRequest{
+		EventID: "evt_1", Type: PaymentEventCaptured, Reference: paid.Payment.ProviderReference,
+	})
+	require.NoError(t, err)
+	assert.False(t, applied)
+
+	_, err = orderService.HandlePaymentEvent(ctx, &PaymentEventRequest{
+		EventID: "evt_3", Type: PaymentEventCaptured, Reference: "fake_missing",
+	})
+	assert.ErrorIs(t, err, ErrPaymentNotFound)
+}
+
+// redeliveringProvider runs redeliver while the provider captures a payment
+type redeliveringProvider struct {
+	*payment.FakeProvider
+	redeliver func()
+}
+
+func (p *redeliveringProvider) Capture(ctx context.Context, reference string, amount decimal.Decimal) (*payment.Result, error) {
+	p.redeliver()
+	return p.FakeProvider.Capture(ctx, reference, amount)
+}
+
+func TestOrderService_HandlePaymentEvent_ConcurrentRedelivery(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 1)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+	})
+	require.NoError(t, err)
+
+	// A payment the provider authorized but has not yet reported on
+	err = baseRepo.WithTransaction(func(tx *sqlx.Tx) error {
+		_, err := repository.NewPaymentRepository(baseRepo).CreatePayment(tx, &models.Payment{
+			OrderID: created.OrderID, Provider: "fake", ProviderReference: "fake_redelivered",
+			Amount: decimal.NewFromInt(40), Status: models.PaymentStatusAuthorized,
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	event := &PaymentEventRequest{EventID: "evt_redelivered", Type: PaymentEventCaptured, Reference: "fake_redelivered"}
+	orderService.SetPaymentProvider(&redeliveringProvider{
+		FakeProvider: newFakePaymentProvider(t, payment.FakeApprove),
+		redeliver: func() {
+			// A redelivery while the event is applied is told to retry, not that it is a duplicate
+			applied, err := orderService.HandlePaymentEvent(ctx, event)
+			assert.ErrorIs(t, err, ErrPaymentEventInProgress)
+			assert.False(t, applied)
+		},
+	})
+
+	applied, err := orderService.HandlePaymentEvent(ctx, event)
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	order, err := orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+
+	// Once applied, the event is a duplicate
+	applied, err = orderService.HandlePaymentEvent(ctx, event)
+	require.NoError(t, err)
+	assert.False(t, applied)
+}
+
+func TestOrderService_HandlePaymentEvent_InvalidRequest(t *testing.T) {
+	// Events are validated before any database access
+	orderService := &OrderService{}
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		req  *PaymentEventRequest
+		err  error
+	}{
+		{"nil request", nil, ErrNilRequest},
+		{"missing event id", &PaymentEventRequest{Type: PaymentEventCaptured, Reference: "fake_1"}, ErrInvalidPaymentEventID},
+		{"event id too long", &PaymentEventRequest{
+			EventID: strings.Repeat("e", MaxPaymentEventIDLength+1), Type: PaymentEventCaptured, Reference: "fake_1",
+		}, ErrInvalidPaymentEventID},
+		{"unknown type", &PaymentEventRequest{EventID: "evt_1", Type: "payment.pending", Reference: "fake_1"}, ErrInvalidPaymentEventType},
+		{"missing reference", &PaymentEventRequest{EventID: "evt_1", Type: PaymentEventFailed}, ErrInvalidPaymentReference},
+		{"payments not configured", &PaymentEventRequest{EventID: "evt_1", Type: PaymentEventFailed, Reference: "fake_1"}, ErrPaymentsNotConfigured},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			applied, err := orderService.HandlePaymentEvent(ctx, tc.req)
+			assert.False(t, applied)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestOrderService_RefundTickets(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetPaymentProvider(newFakePaymentProvider(t, payment.FakeApprove))
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 3)
+	_, err := baseRepo.GetDB().Exec(`UPDATE concert_sessions SET start_time = $1 WHERE id = $2`,
+		time.Now().Add(24*time.Hour).UnixMilli(), sessionID)
+	require.NoError(t, err)
+
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  3,
+	})
+	require.NoError(t, err)
+
+	// Pending orders have nothing to refund
+	_, err = orderService.RefundOrder(ctx, &RefundOrderRequest{OrderID: created.OrderID, Reason: "changed mind", RefundedBy: "support"})
+	assert.ErrorIs(t, err, ErrOrderNotRefundable)
+
+	paid, err := orderService.PayOrder(ctx, &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+	ticketIDs := []uuid.UUID{paid.Order.Items[0].TicketID, paid.Order.Items[1].TicketID, paid.Order.Items[2].TicketID}
+
+	// A ticket of an upcoming session goes back on sale
+	resp, err := orderService.RefundTickets(ctx, &RefundTicketsRequest{
+		OrderID: created.OrderID, TicketIDs: ticketIDs[:1], Reason: "cannot attend", RefundedBy: "support",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "paid", resp.Order.Status)
+	assert.True(t, decimal.RequireFromString("40.00").Equal(resp.Order.RefundedAmount))
+	assert.Equal(t, "succeeded", resp.Refund.Status)
+	assert.Equal(t, paid.Payment.ID, resp.Refund.PaymentID)
+	assert.True(t, decimal.RequireFromString("40.00").Equal(resp.Refund.Amount))
+	assert.Equal(t, ticketIDs[:1], resp.Refund.TicketIDs)
+	assert.Equal(t, "available", resp.Order.Items[0].Ticket.Status)
+	assert.Equal(t, resp.Refund.ID, resp.Order.Items[0].RefundID)
+
+	_, err = orderService.RefundTickets(ctx, &RefundTicketsRequest{
+		OrderID: created.OrderID, TicketIDs: ticketIDs[:1], Reason: "cannot attend", RefundedBy: "support",
+	})
+	assert.ErrorIs(t, err, ErrTicketAlreadyRefunded)
+	_, err = orderService.RefundTickets(ctx, &RefundTicketsRequest{
+		OrderID: created.OrderID, TicketIDs: []uuid.UUID{uuid.New()}, Reason: "cannot attend", RefundedBy: "support",
+	})
+	assert.ErrorIs(t, err, ErrTicketNotInOrder)
+
+	// Once the session has started, the rest of the order is refunded and its tickets voided
+	_, err = baseRepo.GetDB().Exec(`UPDATE concert_sessions SET start_time = $1 WHERE id = $2`,
+		time.Now().Add(-time.Hour).UnixMilli(), sessionID)
+	require.NoError(t, err)
+
+	resp, err = orderService.RefundOrder(ctx, &RefundOrderRequest{OrderID: created.OrderID, Reason: "show cancelled", RefundedBy: "ops"})
+	require.NoError(t, err)
+	assert.Equal(t, "refunded", resp.Order.Status)
+	assert.True(t, decimal.RequireFromString("120.00").Equal(resp.Order.RefundedAmount))
+	assert.True(t, decimal.RequireFromString("80.00").Equal(resp.Refund.Amount))
+	assert.ElementsMatch(t, ticketIDs[1:], resp.Refund.TicketIDs)
+
+	order, err := orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "refunded", order.Status)
+	assert.Equal(t, "available", order.Items[0].Ticket.Status)
+	assert.Equal(t, "void", order.Items[1].Ticket.Status)
+	assert.Equal(t, "void", order.Items[2].Ticket.Status)
+
+	payments, err := repository.NewPaymentRepository(baseRepo).ListPaymentsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, "refunded", payments[0].Status)
+
+	refunds, err := repository.NewRefundRepository(baseRepo).ListRefundsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 2)
+	assert.Equal(t, "cannot attend", refunds[0].Reason)
+	assert.Equal(t, "support", refunds[0].RefundedBy)
+	assert.Equal(t, "ops", refunds[1].RefundedBy)
+
+	// A refunded order cannot be refunded or cancelled again
+	_, err = orderService.RefundOrder(ctx, &RefundOrderRequest{OrderID: created.OrderID, Reason: "again", RefundedBy: "ops"})
+	assert.ErrorIs(t, err, ErrOrderNotRefundable)
+	_, err = orderService.CancelOrder(ctx, created.OrderID, "")
+	assert.ErrorIs(t, err, ErrOrderNotCancellable)
+}
+
+func TestOrderService_RefundTickets_Discounted(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "50.00", 3)
+	_, err := adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "REFUND-TENOFF", DiscountType: "fixed", DiscountValue: decimal.NewFromInt(10),
+	})
+	require.NoError(t, err)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 3, PromoCode: "REFUND-TENOFF",
+	})
+	require.NoError(t, err)
+
+	// Confirmed without a payment, so refunds are only recorded
+	confirmed, err := orderService.ConfirmOrder(created.OrderID)
+	require.NoError(t, err)
+
+	// Each ticket gives back its price less a third of the discount
+	resp, err := orderService.RefundTickets(ctx, &RefundTicketsRequest{
+		OrderID: created.OrderID, TicketIDs: []uuid.UUID{confirmed.Items[0].TicketID}, Reason: "cannot attend", RefundedBy: "support",
+	})
+	require.NoError(t, err)
+	assert.Zero(t, resp.Refund.PaymentID)
+	assert.True(t, decimal.RequireFromString("46.67").Equal(resp.Refund.Amount), "got %s", resp.Refund.Amount)
+
+	// The last tickets give back the rest of the total
+	resp, err = orderService.RefundOrder(ctx, &RefundOrderRequest{OrderID: created.OrderID, Reason: "cannot attend", RefundedBy: "support"})
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("93.33").Equal(resp.Refund.Amount), "got %s", resp.Refund.Amount)
+	assert.True(t, decimal.RequireFromString("140.00").Equal(resp.Order.RefundedAmount))
+}
+
+// failingRefundProvider rejects every refund
+type failingRefundProvider struct {
+	*payment.FakeProvider
+}
+
+func (p *failingRefundProvider) Refund(ctx context.Context, reference string, amount decimal.Decimal) (*payment.Result, error) {
+	return nil, payment.ErrInvalidTransaction
+}
+
+func TestOrderService_RefundTickets_ProviderFailure(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	orderService.SetPaymentProvider(&failingRefundProvider{FakeProvider: newFakePaymentProvider(t, payment.FakeApprove)})
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 1)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+	})
+	require.NoError(t, err)
+	_, err = orderService.PayOrder(ctx, &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+
+	resp, err := orderService.RefundOrder(ctx, &RefundOrderRequest{OrderID: created.OrderID, Reason: "cannot attend", RefundedBy: "support"})
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, ErrRefundRejected)
+
+	// The failure is recorded and the ticket stays sold with the order
+	order, err := orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", order.Status)
+	assert.True(t, order.RefundedAmount.IsZero())
+	assert.Zero(t, order.Items[0].RefundID)
+	assert.Equal(t, "sold", order.Items[0].Ticket.Status)
+
+	refunds, err := repository.NewRefundRepository(baseRepo).ListRefundsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 1)
+	assert.Equal(t, "failed", refunds[0].Status)
+	assert.NotEmpty(t, refunds[0].FailureMessage)
+}
+
+// interleavingRefundProvider runs during while the provider pays a refund back
+type interleavingRefundProvider struct {
+	*payment.FakeProvider
+	during func()
+}
+
+func (p *interleavingRefundProvider) Refund(ctx context.Context, reference string, amount decimal.Decimal) (*payment.Result, error) {
+	p.during()
+	return p.FakeProvider.Refund(ctx, reference, amount)
+}
+
+func TestOrderService_RefundTickets_Overlapping(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 2)
+	_, err := baseRepo.GetDB().Exec(`UPDATE concert_sessions SET start_time = $1 WHERE id = $2`,
+		time.Now().Add(24*time.Hour).UnixMilli(), sessionID)
+	require.NoError(t, err)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  2,
+	})
+	require.NoError(t, err)
+
+	var second *RefundTicketsRequest
+	orderService.SetPaymentProvider(&interleavingRefundProvider{
+		FakeProvider: newFakePaymentProvider(t, payment.FakeApprove),
+		during: func() {
+			// A refund of the other ticket while the first is paid back must wait its turn
+			_, err := orderService.RefundTickets(ctx, second)
+			assert.ErrorIs(t, err, ErrRefundInProgress)
+		},
+	})
+	paid, err := orderService.PayOrder(ctx, &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+	second = &RefundTicketsRequest{
+		OrderID: created.OrderID, TicketIDs: []uuid.UUID{paid.Order.Items[1].TicketID}, Reason: "cannot attend", RefundedBy: "support",
+	}
+
+	first, err := orderService.RefundTickets(ctx, &RefundTicketsRequest{
+		OrderID: created.OrderID, TicketIDs: []uuid.UUID{paid.Order.Items[0].TicketID}, Reason: "cannot attend", RefundedBy: "support",
+	})
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("40.00").Equal(first.Refund.Amount), "got %s", first.Refund.Amount)
+	assert.Equal(t, "paid", first.Order.Status)
+
+	// Once the first settles, the second gives back only the rest
+	resp, err := orderService.RefundTickets(ctx, second)
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("40.00").Equal(resp.Refund.Amount), "got %s", resp.Refund.Amount)
+	assert.Equal(t, "refunded", resp.Order.Status)
+	assert.True(t, decimal.RequireFromString("80.00").Equal(resp.Order.RefundedAmount))
+	for _, item := range resp.Order.Items {
+		assert.Equal(t, "available", item.Ticket.Status)
+	}
+
+	refunds, err := repository.NewRefundRepository(baseRepo).ListRefundsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 2)
+	for _, refund := range refunds {
+		assert.Equal(t, "succeeded", refund.Status)
+	}
+
+	check, err := adminService.CheckLedger()
+	require.NoError(t, err)
+	assert.True(t, check.Balanced(), "unbalanced transactions %v", check.UnbalancedTransactionIDs)
+}
+
+func TestOrderService_RefundOrder_OrderChangedDuringRefund(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 1)
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID:           1,
+		ConcertSessionID: sessionID,
+		NumberOfTickets:  1,
+	})
+	require.NoError(t, err)
+
+	orderService.SetPaymentProvider(&interleavingRefundProvider{
+		FakeProvider: newFakePaymentProvider(t, payment.FakeApprove),
+		during: func() {
+			// A paid order cannot be cancelled while its refund is pending
+			_, err := orderService.CancelOrder(ctx, created.OrderID, "changed my mind")
+			assert.ErrorIs(t, err, ErrRefundInProgress)
+
+			// Should the order leave paid anyway, the refund must still be recorded
+			_, err = baseRepo.GetDB().Exec(`UPDATE orders SET status = 'cancelled' WHERE id = $1`, created.OrderID)
+			require.NoError(t, err)
+		},
+	})
+	_, err = orderService.PayOrder(ctx, &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+
+	resp, err := orderService.RefundOrder(ctx, &RefundOrderRequest{OrderID: created.OrderID, Reason: "cannot attend", RefundedBy: "support"})
+	require.NoError(t, err)
+	assert.Equal(t, "succeeded", resp.Refund.Status)
+
+	// The money is recorded as paid back; the order and its ticket are left alone
+	order, err := orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", order.Status)
+	assert.True(t, decimal.RequireFromString("40.00").Equal(order.RefundedAmount), "got %s", order.RefundedAmount)
+	assert.Equal(t, "sold", order.Items[0].Ticket.Status)
+
+	refunds, err := repository.NewRefundRepository(baseRepo).ListRefundsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 1)
+	assert.Equal(t, "succeeded", refunds[0].Status)
+
+	txns, err := repository.NewLedgerRepository(baseRepo).ListTransactionsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.NotEmpty(t, txns)
+	assert.Equal(t, models.LedgerRefundPaid, txns[len(txns)-1].Kind)
+
+	check, err := adminService.CheckLedger()
+	require.NoError(t, err)
+	assert.True(t, check.Balanced(), "unbalanced transactions %v", check.UnbalancedTransactionIDs)
+}
+
+func TestOrderService_RefundTickets_InvalidRequest(t *testing.T) {
+	// Requests are validated before any database access
+	orderService := &OrderService{}
+	ctx := context.Background()
+	ticketID := uuid.New()
+
+	_, err := orderService.RefundOrder(ctx, nil)
+	assert.ErrorIs(t, err, ErrNilRequest)
+	_, err = orderService.RefundTickets(ctx, nil)
+	assert.ErrorIs(t, err, ErrNilRequest)
+
+	_, err = orderService.RefundOrder(ctx, &RefundOrderRequest{Reason: "r", RefundedBy: "support"})
+	assert.ErrorIs(t, err, ErrInvalidOrderID)
+
+	_, err = orderService.RefundOrder(ctx, &RefundOrderRequest{OrderID: 1, Reason: " ", RefundedBy: "support"})
+	assert.ErrorIs(t, err, ErrInvalidRefundReason)
+
+	_, err = orderService.RefundOrder(ctx, &RefundOrderRequest{
+		OrderID: 1, Reason: strings.Repeat("x", MaxRefundReasonLength+1), RefundedBy: "support",
+	})
+	assert.ErrorIs(t, err, ErrInvalidRefundReason)
+
+	_, err = orderService.RefundOrder(ctx, &RefundOrderRequest{OrderID: 1, Reason: "r"})
+	assert.ErrorIs(t, err, ErrInvalidRefundedBy)
+
+	_, err = orderService.RefundTickets(ctx, &RefundTicketsRequest{OrderID: 1, Reason: "r", RefundedBy: "support"})
+	assert.ErrorIs(t, err, ErrInvalidTicketIDs)
+
+	_, err = orderService.RefundTickets(ctx, &RefundTicketsRequest{
+		OrderID: 1, TicketIDs: []uuid.UUID{ticketID, ticketID}, Reason: "r", RefundedBy: "support",
+	})
+	assert.ErrorIs(t, err, ErrInvalidTicketIDs)
+}
+
+func TestOrderService_Ledger(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+	orderService.SetPaymentProvider(newFakePaymentProvider(t, payment.FakeApprove))
+	ledgerRepo := repository.NewLedgerRepository(baseRepo)
+
+	sessionID := insertTestSession(t, baseRepo, "50.00", 3)
+	_, err := adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "LEDGER-TENOFF", DiscountType: "fixed", DiscountValue: decimal.NewFromInt(10),
+	})
+	require.NoError(t, err)
+
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 2, PromoCode: "LEDGER-TENOFF",
+	})
+	require.NoError(t, err)
+	paid, err := orderService.PayOrder(ctx, &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+	refunded, err := orderService.RefundTickets(ctx, &RefundTicketsRequest{
+		OrderID: created.OrderID, TicketIDs: []uuid.UUID{paid.Order.Items[0].TicketID}, Reason: "cannot attend", RefundedBy: "support",
+	})
+	require.NoError(t, err)
+
+	txns, err := ledgerRepo.ListTransactionsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, txns, 4)
+
+	type posting struct {
+		account       string
+		debit, credit string
+	}
+	postings := func(txn models.LedgerTransaction) []posting {
+		out := make([]posting, len(txn.Entries))
+		for i, e := range txn.Entries {
+			out[i] = posting{e.Account, e.Debit.StringFixed(2), e.Credit.StringFixed(2)}
+		}
+		return out
+	}
+
+	// Placing the order books the discounted total as owed
+	assert.Equal(t, models.LedgerOrderPlaced, txns[0].Kind)
+	assert.ElementsMatch(t, []posting{
+		{models.AccountCustomerReceivable, "90.00", "0.00"},
+		{models.AccountDiscounts, "10.00", "0.00"},
+		{models.AccountRevenue, "0.00", "100.00"},
+	}, postings(txns[0]))
+
+	assert.Equal(t, models.LedgerOrderPaid, txns[1].Kind)
+	assert.Equal(t, paid.Payment.ID, txns[1].PaymentID)
+	assert.ElementsMatch(t, []posting{
+		{models.AccountCash, "90.00", "0.00"},
+		{models.AccountCustomerReceivable, "0.00", "90.00"},
+	}, postings(txns[1]))
+
+	// A refund is owed once granted and paid once the provider returns it
+	assert.Equal(t, models.LedgerRefundIssued, txns[2].Kind)
+	assert.Equal(t, refunded.Refund.ID, txns[2].RefundID)
+	assert.ElementsMatch(t, []posting{
+		{models.AccountRefunds, "45.00", "0.00"},
+		{models.AccountRefundsPayable, "0.00", "45.00"},
+	}, postings(txns[2]))
+
+	assert.Equal(t, models.LedgerRefundPaid, txns[3].Kind)
+	assert.ElementsMatch(t, []posting{
+		{models.AccountRefundsPayable, "45.00", "0.00"},
+		{models.AccountCash, "0.00", "45.00"},
+	}, postings(txns[3]))
+
+	// Cancelling an unpaid order reverses what placing it booked
+	cancelled, err := orderService.CreateOrder(&CreateOrderRequest{UserID: 2, ConcertSessionID: sessionID, NumberOfTickets: 1})
+	require.NoError(t, err)
+	_, err = orderService.CancelOrder(ctx, cancelled.OrderID, "")
+	require.NoError(t, err)
+
+	txns, err = ledgerRepo.ListTransactionsByOrderID(cancelled.OrderID)
+	require.NoError(t, err)
+	require.Len(t, txns, 2)
+	assert.Equal(t, models.LedgerOrderReleased, txns[1].Kind)
+	assert.ElementsMatch(t, []posting{
+		{models.AccountRevenue, "50.00", "0.00"},
+		{models.AccountCustomerReceivable, "0.00", "50.00"},
+	}, postings(txns[1]))
+
+	check, err := adminService.CheckLedger()
+	require.NoError(t, err)
+	assert.True(t, check.Balanced(), "unbalanced transactions %v", check.UnbalancedTransactionIDs)
+}
+
+func TestOrderService_CreateOrder_FeesAndTax(t *testing.T) {
+	baseRepo, cleanup := repository.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	baseService := NewBaseService(baseRepo)
+	orderService := NewOrderService(baseService)
+	adminService := NewAdminService(baseService)
+	orderService.SetPaymentProvider(newFakePaymentProvider(t, payment.FakeApprove))
+	rules, err := pricing.New(pricing.Config{
+		FeePerTicket: "1.50",
+		FeePerOrder:  "2.00",
+		TaxRates:     map[string]string{"arena-city": "8.875"},
+		Venues:       map[string]string{"test arena": "arena-city"},
+	})
+	require.NoError(t, err)
+	orderService.SetPricing(rules)
+
+	sessionID := insertTestSession(t, baseRepo, "40.00", 2)
+	_, err = adminService.CreatePromoCode(&CreatePromoCodeRequest{
+		Code: "FEES-TENOFF", DiscountType: "fixed", DiscountValue: decimal.NewFromInt(10),
+	})
+	require.NoError(t, err)
+
+	// 80.00 - 10.00 + 2 * 1.50 + 2.00 = 75.00, taxed at 8.875% = 6.65625
+	created, err := orderService.CreateOrder(&CreateOrderRequest{
+		UserID: 1, ConcertSessionID: sessionID, NumberOfTickets: 2, PromoCode: "FEES-TENOFF",
+	})
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("80.00").Equal(created.Subtotal))
+	assert.True(t, decimal.RequireFromString("10.00").Equal(created.Discount))
+	assert.True(t, decimal.RequireFromString("5.00").Equal(created.ServiceFee), "got %s", created.ServiceFee)
+	assert.True(t, decimal.RequireFromString("6.66").Equal(created.Tax), "got %s", created.Tax)
+	assert.True(t, decimal.RequireFromString("8.875").Equal(created.TaxRate))
+	assert.Equal(t, "arena-city", created.TaxJurisdiction)
+	assert.True(t, decimal.RequireFromString("81.66").Equal(created.TotalPrice), "got %s", created.TotalPrice)
+
+	order, err := orderService.GetOrder(created.OrderID)
+	require.NoError(t, err)
+	assert.True(t, created.ServiceFee.Equal(order.ServiceFee))
+	assert.True(t, created.Tax.Equal(order.Tax))
+	assert.True(t, created.TaxRate.Equal(order.TaxRate))
+	assert.Equal(t, created.TaxJurisdiction, order.TaxJurisdiction)
+	assert.True(t, created.TotalPrice.Equal(order.TotalPrice))
+
+	// The fee and tax are booked apart from ticket revenue
+	txns, err := repository.NewLedgerRepository(baseRepo).ListTransactionsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	credits := make(map[string]string)
+	for _, e := range txns[0].Entries {
+		if e.Credit.IsPositive() {
+			credits[e.Account] = e.Credit.StringFixed(2)
+		}
+	}
+	assert.Equal(t, map[string]string{
+		models.AccountRevenue:    "80.00",
+		models.AccountFees:       "5.00",
+		models.AccountTaxPayable: "6.66",
+	}, credits)
+
+	// The whole total is charged, and each ticket refunds half of it
+	paid, err := orderService.PayOrder(ctx, &PayOrderRequest{OrderID: created.OrderID, PaymentMethod: "card"})
+	require.NoError(t, err)
+	assert.True(t, created.TotalPrice.Equal(paid.Payment.Amount))
+
+	refunded, err := orderService.RefundTickets(ctx, &RefundTicketsRequest{
+		OrderID: created.OrderID, TicketIDs: []uuid.UUID{paid.Order.Items[0].TicketID}, Reason: "cannot attend", RefundedBy: "support",
+	})
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("40.83").Equal(refunded.Refund.Amount), "got %s", refunded.Refund.Amount)
+
+	// The refund takes its share of the fee and tax back from where they were booked
+	txns, err = repository.NewLedgerRepository(baseRepo).ListTransactionsByOrderID(created.OrderID)
+	require.NoError(t, err)
+	require.Len(t, txns, 4)
+	assert.Equal(t, models.LedgerRefundIssued, txns[2].Kind)
+	debits := make(map[string]string)
+	for _, e := range txns[2].Entries {
+		if e.Debit.IsPositive() {
+			debits[e.Account] = e.Debit.StringFixed(2)
+		}
+	}
+	assert.Equal(t, map[string]string{
+		models.AccountRefunds:    "35.00",
+		models.AccountFees:       "2.50",
+		models.AccountTaxPayable: "3.33",
+	}, debits)
+
+	check, err := adminService.CheckLedger()
+	require.NoError(t, err)
+	assert.True(t, check.Balanced(), "unbalanced transactions %v", check.UnbalancedTransactionIDs)
+}
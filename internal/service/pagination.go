@@ -0,0 +1,65 @@
+package service
+
+import (
+	"tickets/internal/repository"
+)
+
+// pagination holds the resolved paging parameters of a list request. Requests page either by
+// page_token (keyset) or, for backward compatibility, by page number (offset).
+type pagination struct {
+	codec    *repository.PageTokenCodec
+	scope    string
+	page     int
+	pageSize int
+	query    repository.Page
+}
+
+// newPagination validates the paging fields of a list request. scope identifies the query so
+// a token issued for one query is rejected by another.
+func newPagination(codec *repository.PageTokenCodec, scope string, page, pageSize int, pageToken string) (*pagination, error) {
+	if page < 0 {
+		return nil, ErrInvalidPage
+	}
+	if pageSize < 0 {
+		return nil, ErrInvalidPageSize
+	}
+	if pageToken != "" && page > 0 {
+		return nil, ErrPageWithPageToken
+	}
+
+	// Apply paging defaults
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	// One extra row is fetched to tell whether another page follows
+	p := &pagination{codec: codec, scope: scope, pageSize: pageSize, query: repository.Page{Limit: pageSize + 1}}
+	if pageToken == "" {
+		p.page = page
+		if p.page == 0 {
+			p.page = 1
+		}
+		p.query.Offset = (p.page - 1) * pageSize
+		return p, nil
+	}
+
+	cursor, err := codec.Decode(pageToken)
+	if err != nil || cursor.Scope != scope {
+		return nil, ErrInvalidPageToken
+	}
+	p.query.After = cursor
+	return p, nil
+}
+
+// hasMore reports whether more rows follow a fetched page of n rows
+func (p *pagination) hasMore(n int) bool {
+	return n > p.pageSize
+}
+
+// nextPageToken returns the token for the page after the row with the given sort key and id
+func (p *pagination) nextPageToken(key string, id int) string {
+	return p.codec.Encode(repository.Cursor{Key: key, ID: id, Scope: p.scope})
+}
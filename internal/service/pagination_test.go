@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+
+	"tickets/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPagination_Offset(t *testing.T) {
+	codec := repository.NewPageTokenCodec([]byte("secret"))
+
+	p, err := newPagination(codec, "scope", 0, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, p.page)
+	assert.Equal(t, DefaultPageSize, p.pageSize)
+	assert.Equal(t, repository.Page{Limit: DefaultPageSize + 1}, p.query)
+
+	p, err = newPagination(codec, "scope", 3, MaxPageSize+1, "")
+	require.NoError(t, err)
+	assert.Equal(t, MaxPageSize, p.pageSize)
+	assert.Equal(t, repository.Page{Limit: MaxPageSize + 1, Offset: 2 * MaxPageSize}, p.query)
+	assert.False(t, p.hasMore(MaxPageSize))
+	assert.True(t, p.hasMore(MaxPageSize+1))
+}
+
+func TestNewPagination_Token(t *testing.T) {
+	codec := repository.NewPageTokenCodec([]byte("secret"))
+
+	first, err := newPagination(codec, "scope", 0, 5, "")
+	require.NoError(t, err)
+	token := first.nextPageToken("1760000000000", 9)
+
+	p, err := newPagination(codec, "scope", 0, 5, token)
+	require.NoError(t, err)
+	assert.Equal(t, 0, p.page)
+	require.NotNil(t, p.query.After)
+	assert.Equal(t, "1760000000000", p.query.After.Key)
+	assert.Equal(t, 9, p.query.After.ID)
+
+	_, err = newPagination(codec, "other scope", 0, 5, token)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, err = newPagination(repository.NewPageTokenCodec([]byte("rotated")), "scope", 0, 5, token)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, err = newPagination(codec, "scope", 2, 5, token)
+	assert.ErrorIs(t, err, ErrPageWithPageToken)
+
+	_, err = newPagination(codec, "scope", -1, 5, "")
+	assert.ErrorIs(t, err, ErrInvalidPage)
+
+	_, err = newPagination(codec, "scope", 0, -1, "")
+	assert.ErrorIs(t, err, ErrInvalidPageSize)
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"tickets/internal/logger"
+)
+
+// OrderExpirer expires pending orders whose hold ended at or before now and
+// forgets idempotency keys that are past their retention window
+type OrderExpirer interface {
+	ExpirePendingOrders(now time.Time) (int, error)
+	PruneIdempotencyKeys(now time.Time) (int64, error)
+}
+
+// DefaultInterval is how often the worker runs when it is given an interval that is not positive
+const DefaultInterval = time.Minute
+
+// OrderExpiryWorker periodically releases the tickets held by expired pending orders
+// and prunes stale idempotency keys
+type OrderExpiryWorker struct {
+	expirer  OrderExpirer
+	interval time.Duration
+}
+
+// NewOrderExpiryWorker creates a new order expiry worker. An interval that is not positive is
+// replaced by DefaultInterval, since a ticker cannot run on it.
+func NewOrderExpiryWorker(expirer OrderExpirer, interval time.Duration) *OrderExpiryWorker {
+	if interval <= 0 {
+		logger.Warnf("Order expiry interval %s is not positive; using %s", interval, DefaultInterval)
+		interval = DefaultInterval
+	}
+	return &OrderExpiryWorker{
+		expirer:  expirer,
+		interval: interval,
+	}
+}
+
+// Run expires pending orders every interval until ctx is cancelled
+func (w *OrderExpiryWorker) Run(ctx context.Context) {
+	logger.Infof("Order expiry worker started (interval %s)", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.RunOnce()
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Order expiry worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce expires all pending orders whose hold has ended and prunes stale idempotency keys
+func (w *OrderExpiryWorker) RunOnce() {
+	now := time.Now()
+
+	expired, err := w.expirer.ExpirePendingOrders(now)
+	if err != nil {
+		logger.WithError(err).Error("Failed to expire pending orders")
+	}
+	if expired > 0 {
+		logger.WithField("orders", expired).Info("Expired pending orders and released their tickets")
+	}
+
+	pruned, err := w.expirer.PruneIdempotencyKeys(now)
+	if err != nil {
+		logger.WithError(err).Error("Failed to prune idempotency keys")
+	}
+	if pruned > 0 {
+		logger.WithField("keys", pruned).Debug("Pruned expired idempotency keys")
+	}
+}
@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeExpirer records how often it was asked to expire orders and prune keys
+type fakeExpirer struct {
+	mu         sync.Mutex
+	calls      int
+	pruneCalls int
+	err        error
+}
+
+func (f *fakeExpirer) ExpirePendingOrders(now time.Time) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return 1, f.err
+}
+
+func (f *fakeExpirer) PruneIdempotencyKeys(now time.Time) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pruneCalls++
+	return 0, f.err
+}
+
+func (f *fakeExpirer) PruneCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pruneCalls
+}
+
+func (f *fakeExpirer) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestOrderExpiryWorker_RunOnce(t *testing.T) {
+	expirer := &fakeExpirer{}
+	worker := NewOrderExpiryWorker(expirer, time.Minute)
+
+	worker.RunOnce()
+	assert.Equal(t, 1, expirer.Calls())
+	assert.Equal(t, 1, expirer.PruneCalls())
+
+	// Errors are logged and do not stop the worker
+	expirer.err = errors.New("database unavailable")
+	worker.RunOnce()
+	assert.Equal(t, 2, expirer.Calls())
+	assert.Equal(t, 2, expirer.PruneCalls())
+}
+
+func TestOrderExpiryWorker_Run(t *testing.T) {
+	expirer := &fakeExpirer{}
+	worker := NewOrderExpiryWorker(expirer, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		worker.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return expirer.Calls() >= 3 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after context cancellation")
+	}
+}
+
+func TestNewOrderExpiryWorker_NonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Minute} {
+		worker := NewOrderExpiryWorker(&fakeExpirer{}, interval)
+		assert.Equal(t, DefaultInterval, worker.interval)
+	}
+}
@@ -0,0 +1,6 @@
+-- Rollback: create_order_items
+-- Version: 3
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_order_items_order_id;
+DROP TABLE IF EXISTS order_items CASCADE;
@@ -0,0 +1,16 @@
+-- Migration: create_order_items
+-- Version: 3
+-- Created: 2026-10-16
+
+-- Create order_items table linking orders to the tickets they reserved
+CREATE TABLE IF NOT EXISTS order_items (
+  id SERIAL PRIMARY KEY,
+  order_id INTEGER NOT NULL,
+  ticket_id UUID NOT NULL,
+  price DECIMAL(10,2) NOT NULL,
+  FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+  FOREIGN KEY (ticket_id) REFERENCES tickets(id) ON DELETE CASCADE
+);
+
+-- Order items are always loaded by their order (used in GetOrderItemsByOrderID)
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id);
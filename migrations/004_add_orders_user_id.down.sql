@@ -0,0 +1,6 @@
+-- Rollback: add_orders_user_id
+-- Version: 4
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_orders_user_id_created_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,10 @@
+-- Migration: add_orders_user_id
+-- Version: 4
+-- Created: 2026-10-16
+
+-- Record the purchasing user on each order
+-- Orders placed before this migration have no recorded user and keep user_id 0
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS user_id INTEGER NOT NULL DEFAULT 0;
+
+-- Orders are listed per user, newest first (used in ListOrdersByUserID)
+CREATE INDEX IF NOT EXISTS idx_orders_user_id_created_at ON orders(user_id, created_at DESC, id DESC);
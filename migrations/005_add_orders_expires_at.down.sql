@@ -0,0 +1,6 @@
+-- Rollback: add_orders_expires_at
+-- Version: 5
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_orders_pending_expires_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS expires_at;
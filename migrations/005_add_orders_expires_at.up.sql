@@ -0,0 +1,9 @@
+-- Migration: add_orders_expires_at
+-- Version: 5
+-- Created: 2026-10-16
+
+-- Pending orders hold their tickets until expires_at (Unix milliseconds)
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS expires_at BIGINT;
+
+-- The expiry worker only scans pending orders (used in LockExpiredPendingOrders)
+CREATE INDEX IF NOT EXISTS idx_orders_pending_expires_at ON orders(expires_at) WHERE status = 'pending';
@@ -0,0 +1,6 @@
+-- Rollback: add_order_cancellation
+-- Version: 6
+-- Created: 2026-10-16
+
+ALTER TABLE orders DROP COLUMN IF EXISTS cancellation_reason;
+ALTER TABLE orders DROP COLUMN IF EXISTS cancelled_at;
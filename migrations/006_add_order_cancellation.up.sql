@@ -0,0 +1,7 @@
+-- Migration: add_order_cancellation
+-- Version: 6
+-- Created: 2026-10-16
+
+-- Record when and why an order was cancelled
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancelled_at BIGINT;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
@@ -0,0 +1,6 @@
+-- Rollback: create_idempotency_keys
+-- Version: 7
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_idempotency_keys_created_at;
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,16 @@
+-- Migration: create_idempotency_keys
+-- Version: 7
+-- Created: 2026-10-16
+
+-- Remember CreateOrder responses so client retries replay the original order
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id INTEGER NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    response JSONB,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+-- Supports pruning keys past the retention window
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
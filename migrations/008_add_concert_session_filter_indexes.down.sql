@@ -0,0 +1,9 @@
+-- Rollback: add_concert_session_filter_indexes
+-- Version: 8
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_tickets_available_session_id;
+DROP INDEX IF EXISTS idx_concerts_location_lower;
+DROP INDEX IF EXISTS idx_concert_sessions_venue_lower;
+DROP INDEX IF EXISTS idx_concert_sessions_price;
+DROP INDEX IF EXISTS idx_concert_sessions_start_time;
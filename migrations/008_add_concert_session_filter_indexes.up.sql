@@ -0,0 +1,12 @@
+-- Migration: add_concert_session_filter_indexes
+-- Version: 8
+-- Created: 2026-10-16
+
+-- Support ListConcertSessions filtering and sorting
+CREATE INDEX IF NOT EXISTS idx_concert_sessions_start_time ON concert_sessions(start_time, id);
+CREATE INDEX IF NOT EXISTS idx_concert_sessions_price ON concert_sessions(price, id);
+CREATE INDEX IF NOT EXISTS idx_concert_sessions_venue_lower ON concert_sessions(LOWER(venue));
+CREATE INDEX IF NOT EXISTS idx_concerts_location_lower ON concerts(LOWER(location));
+
+-- Count remaining seats and find sessions with seats left without scanning sold tickets
+CREATE INDEX IF NOT EXISTS idx_tickets_available_session_id ON tickets(session_id) WHERE status = 'available';
@@ -0,0 +1,8 @@
+-- Rollback: add_ticket_seats
+-- Version: 9
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_tickets_session_seat;
+ALTER TABLE tickets DROP COLUMN IF EXISTS seat_number;
+ALTER TABLE tickets DROP COLUMN IF EXISTS seat_row;
+ALTER TABLE tickets DROP COLUMN IF EXISTS section;
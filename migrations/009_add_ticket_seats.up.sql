@@ -0,0 +1,13 @@
+-- Migration: add_ticket_seats
+-- Version: 9
+-- Created: 2026-10-16
+
+-- Reserved seating: a ticket may be tied to a seat. General admission tickets leave these NULL.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS section VARCHAR(50);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS seat_row VARCHAR(10);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS seat_number INTEGER;
+
+-- A seat is sold at most once per session; also serves GetSeatMap ordering
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_session_seat
+  ON tickets(session_id, section, seat_row, seat_number)
+  WHERE seat_number IS NOT NULL;
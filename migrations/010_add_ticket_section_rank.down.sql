@@ -0,0 +1,6 @@
+-- Rollback: add_ticket_section_rank
+-- Version: 10
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_tickets_session_available_seats;
+ALTER TABLE tickets DROP COLUMN IF EXISTS section_rank;
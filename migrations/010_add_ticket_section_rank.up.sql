@@ -0,0 +1,12 @@
+-- Migration: add_ticket_section_rank
+-- Version: 10
+-- Created: 2026-10-16
+
+-- Preference of a seat's section within its session, 0 being the best. Sections are ranked in
+-- the order they were listed when the session was created; general admission tickets leave it NULL.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS section_rank SMALLINT;
+
+-- Serves best-available seat allocation, which scans a session's available seats in rank order
+CREATE INDEX IF NOT EXISTS idx_tickets_session_available_seats
+  ON tickets(session_id, section_rank, section, seat_row, seat_number)
+  WHERE status = 'available' AND seat_number IS NOT NULL;
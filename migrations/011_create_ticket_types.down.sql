@@ -0,0 +1,7 @@
+-- Rollback: create_ticket_types
+-- Version: 11
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_order_items_ticket_type_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS ticket_type_id;
+DROP TABLE IF EXISTS ticket_types;
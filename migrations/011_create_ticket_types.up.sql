@@ -0,0 +1,23 @@
+-- Migration: create_ticket_types
+-- Version: 11
+-- Created: 2026-10-16
+
+-- Ticket types (GA, VIP, student, ...) price a session's tickets in tiers. quota caps how many
+-- tickets of the type pending and paid orders may hold at once.
+CREATE TABLE IF NOT EXISTS ticket_types (
+  id SERIAL PRIMARY KEY,
+  session_id INTEGER NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  description TEXT,
+  price DECIMAL(10,2) NOT NULL CHECK (price > 0),
+  quota INTEGER NOT NULL CHECK (quota > 0),
+  FOREIGN KEY (session_id) REFERENCES concert_sessions(id) ON DELETE CASCADE,
+  UNIQUE (session_id, name)
+);
+
+-- The type a ticket was sold as; NULL for orders priced at concert_sessions.price
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS ticket_type_id INTEGER REFERENCES ticket_types(id);
+
+-- Supports counting a type's held and sold tickets against its quota
+CREATE INDEX IF NOT EXISTS idx_order_items_ticket_type_id ON order_items(ticket_type_id)
+  WHERE ticket_type_id IS NOT NULL;
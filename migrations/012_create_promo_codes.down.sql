@@ -0,0 +1,9 @@
+-- Rollback: create_promo_codes
+-- Version: 12
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_orders_promo_code_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_code_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS discount_amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS subtotal;
+DROP TABLE IF EXISTS promo_codes;
@@ -0,0 +1,34 @@
+-- Migration: create_promo_codes
+-- Version: 12
+-- Created: 2026-10-16
+
+-- Promo codes discount an order by a percentage of its subtotal or by a fixed amount. A code may be
+-- limited to one concert or one session, to a validity window and to a number of redemptions overall
+-- and per user; NULL means no limit. Pending and paid orders count as redemptions.
+CREATE TABLE IF NOT EXISTS promo_codes (
+  id SERIAL PRIMARY KEY,
+  code VARCHAR(50) NOT NULL UNIQUE,
+  discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+  discount_value DECIMAL(10,2) NOT NULL CHECK (discount_value > 0),
+  valid_from BIGINT,
+  valid_until BIGINT,
+  max_redemptions INTEGER CHECK (max_redemptions > 0),
+  max_redemptions_per_user INTEGER CHECK (max_redemptions_per_user > 0),
+  concert_id INTEGER REFERENCES concerts(id) ON DELETE CASCADE,
+  session_id INTEGER REFERENCES concert_sessions(id) ON DELETE CASCADE,
+  created_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000,
+  CHECK (discount_type <> 'percentage' OR discount_value <= 100),
+  CHECK (valid_from IS NULL OR valid_until IS NULL OR valid_from < valid_until)
+);
+
+-- The applied discount: total_price = subtotal - discount_amount
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal DECIMAL(10,2);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code_id INTEGER REFERENCES promo_codes(id);
+
+-- Orders placed before discounts existed were charged their subtotal
+UPDATE orders SET subtotal = total_price WHERE subtotal IS NULL;
+
+-- Supports counting a code's redemptions against its limits
+CREATE INDEX IF NOT EXISTS idx_orders_promo_code_id ON orders(promo_code_id, user_id)
+  WHERE promo_code_id IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Rollback: create_payments
+-- Version: 13
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_payments_order_id_active;
+DROP INDEX IF EXISTS idx_payments_order_id;
+DROP TABLE IF EXISTS payments;
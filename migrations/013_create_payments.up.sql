@@ -0,0 +1,25 @@
+-- Migration: create_payments
+-- Version: 13
+-- Created: 2026-10-16
+
+-- Each attempt to pay an order with the payment provider. A payment is recorded as pending before
+-- the provider is called so an attempt whose outcome is unknown can be reconciled later.
+CREATE TABLE IF NOT EXISTS payments (
+  id SERIAL PRIMARY KEY,
+  order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  provider VARCHAR(50) NOT NULL,
+  provider_reference VARCHAR(255),
+  amount DECIMAL(10,2) NOT NULL CHECK (amount >= 0),
+  status VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK (status IN ('pending', 'authorized', 'captured', 'declined', 'failed', 'voided', 'refunded')),
+  failure_code VARCHAR(100),
+  failure_message TEXT,
+  created_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000,
+  updated_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(order_id);
+
+-- An order has at most one payment in flight or collected at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_order_id_active ON payments(order_id)
+  WHERE status IN ('pending', 'authorized', 'captured');
@@ -0,0 +1,6 @@
+-- Rollback: create_payment_events
+-- Version: 14
+-- Created: 2026-10-16
+
+DROP INDEX IF EXISTS idx_payments_provider_reference;
+DROP TABLE IF EXISTS payment_events;
@@ -0,0 +1,18 @@
+-- Migration: create_payment_events
+-- Version: 14
+-- Created: 2026-10-16
+
+-- Payment provider webhook events that have been applied, so a redelivered event is
+-- acknowledged without being applied twice
+CREATE TABLE IF NOT EXISTS payment_events (
+  provider VARCHAR(50) NOT NULL,
+  event_id VARCHAR(255) NOT NULL,
+  payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+  event_type VARCHAR(50) NOT NULL,
+  received_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000,
+  PRIMARY KEY (provider, event_id)
+);
+
+-- Webhooks look payments up by the provider's reference
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_provider_reference ON payments(provider, provider_reference)
+  WHERE provider_reference IS NOT NULL;
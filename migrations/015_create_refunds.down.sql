@@ -0,0 +1,12 @@
+-- Rollback: create_refunds
+-- Version: 15
+-- Created: 2026-10-16
+
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_status_check;
+UPDATE tickets SET status = 'sold' WHERE status = 'void';
+ALTER TABLE tickets ADD CONSTRAINT tickets_status_check CHECK (status IN ('pending', 'sold', 'available'));
+ALTER TABLE orders DROP COLUMN IF EXISTS refunded_amount;
+DROP INDEX IF EXISTS idx_order_items_refund_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS refund_id;
+DROP INDEX IF EXISTS idx_refunds_order_id;
+DROP TABLE IF EXISTS refunds;
@@ -0,0 +1,31 @@
+-- Migration: create_refunds
+-- Version: 15
+-- Created: 2026-10-16
+
+-- Refunds of paid orders, in full or per ticket, with who issued them and why. A refund is
+-- recorded as pending before the payment provider is called; payment_id is NULL for orders
+-- that were confirmed without a payment.
+CREATE TABLE IF NOT EXISTS refunds (
+  id SERIAL PRIMARY KEY,
+  order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  payment_id INTEGER REFERENCES payments(id),
+  amount DECIMAL(10,2) NOT NULL CHECK (amount >= 0),
+  reason TEXT NOT NULL,
+  refunded_by VARCHAR(100) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+  failure_message TEXT,
+  created_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds(order_id);
+
+-- The refund each order item was refunded by; cleared again if the refund fails
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS refund_id INTEGER REFERENCES refunds(id);
+CREATE INDEX IF NOT EXISTS idx_order_items_refund_id ON order_items(refund_id) WHERE refund_id IS NOT NULL;
+
+-- The sum of an order's succeeded refunds
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS refunded_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+-- Refunded tickets of sessions that have already started are voided instead of resold
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_status_check;
+ALTER TABLE tickets ADD CONSTRAINT tickets_status_check CHECK (status IN ('pending', 'sold', 'available', 'void'));
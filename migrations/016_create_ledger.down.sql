@@ -0,0 +1,7 @@
+-- Rollback: create_ledger
+-- Version: 16
+-- Created: 2026-10-16
+
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
+DROP FUNCTION IF EXISTS ledger_append_only();
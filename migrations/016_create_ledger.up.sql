@@ -0,0 +1,45 @@
+-- Migration: create_ledger
+-- Version: 16
+-- Created: 2026-10-16
+
+-- Double-entry ledger of every money movement. Each transaction posts entries whose debits
+-- equal their credits, written in the same database transaction as the order, payment or
+-- refund change it records.
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+  id SERIAL PRIMARY KEY,
+  kind VARCHAR(30) NOT NULL,
+  order_id INTEGER NOT NULL REFERENCES orders(id),
+  payment_id INTEGER REFERENCES payments(id),
+  refund_id INTEGER REFERENCES refunds(id),
+  created_at BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()) * 1000
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_transactions_order_id ON ledger_transactions(order_id);
+
+-- Each entry debits or credits one account by a positive amount
+CREATE TABLE IF NOT EXISTS ledger_entries (
+  id SERIAL PRIMARY KEY,
+  transaction_id INTEGER NOT NULL REFERENCES ledger_transactions(id),
+  account VARCHAR(50) NOT NULL,
+  debit DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (debit >= 0),
+  credit DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (credit >= 0),
+  CHECK ((debit > 0) <> (credit > 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account ON ledger_entries(account);
+
+-- The ledger is append-only: corrections are posted as new transactions
+CREATE OR REPLACE FUNCTION ledger_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ledger_transactions_append_only ON ledger_transactions;
+CREATE TRIGGER ledger_transactions_append_only BEFORE UPDATE OR DELETE ON ledger_transactions
+  FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+
+DROP TRIGGER IF EXISTS ledger_entries_append_only ON ledger_entries;
+CREATE TRIGGER ledger_entries_append_only BEFORE UPDATE OR DELETE ON ledger_entries
+  FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
@@ -0,0 +1,8 @@
+-- Rollback: add_order_fees_and_tax
+-- Version: 17
+-- Created: 2026-10-16
+
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_jurisdiction;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_rate;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS service_fee;
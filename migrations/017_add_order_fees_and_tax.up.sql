@@ -0,0 +1,11 @@
+-- Migration: add_order_fees_and_tax
+-- Version: 17
+-- Created: 2026-10-16
+
+-- The price breakdown of an order: total_price = subtotal - discount_amount + service_fee + tax_amount.
+-- tax_rate is the percentage tax_amount was charged at and tax_jurisdiction where it was charged;
+-- the jurisdiction is NULL for untaxed orders, including every order placed before this migration.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS service_fee DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (service_fee >= 0);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_amount DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (tax_amount >= 0);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_rate DECIMAL(6,3) NOT NULL DEFAULT 0 CHECK (tax_rate BETWEEN 0 AND 100);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_jurisdiction VARCHAR(100);